@@ -0,0 +1,55 @@
+// Command secrets writes the encrypted secrets file read through SECRETS_FILE.
+//
+// It reads a JSON object of name -> value from stdin and writes the file encrypted with SECRETS_KEY to stdout:
+//
+//	SECRETS_KEY=$(go run ./cmd/secrets -genkey)
+//	go run ./cmd/secrets < secrets.json > config/secrets.enc
+package main
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/axxonsoft-assignment/pkg/secrets"
+)
+
+func main() {
+	genKey := flag.Bool("genkey", false, "print a new random key for SECRETS_KEY")
+	flag.Parse()
+
+	if *genKey {
+		key := make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			log.Fatalf("Error generating key: %v", err)
+		}
+
+		fmt.Println(base64.StdEncoding.EncodeToString(key))
+
+		return
+	}
+
+	data, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		log.Fatalf("Error reading secrets: %v", err)
+	}
+
+	var values map[string]string
+	if err = json.Unmarshal(data, &values); err != nil {
+		log.Fatalf("Error reading secrets: %v", err)
+	}
+
+	sealed, err := secrets.Seal(values, os.Getenv("SECRETS_KEY"))
+	if err != nil {
+		log.Fatalf("Error encrypting secrets: %v", err)
+	}
+
+	if _, err = os.Stdout.Write(sealed); err != nil {
+		log.Fatalf("Error writing secrets: %v", err)
+	}
+}
@@ -1,17 +1,35 @@
 package main
 
 import (
+	"context"
 	"log"
+	"net"
 	"net/http"
+	"net/url"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/axxonsoft-assignment/pkg/blob"
+	"github.com/axxonsoft-assignment/pkg/breaker"
 	"github.com/axxonsoft-assignment/pkg/cache"
+	tasksServer "github.com/axxonsoft-assignment/pkg/grpc/server"
+	"github.com/axxonsoft-assignment/pkg/grpc/taskspb"
 	tasksHandler "github.com/axxonsoft-assignment/pkg/http/handlers"
 	"github.com/axxonsoft-assignment/pkg/http/routes"
+	"github.com/axxonsoft-assignment/pkg/model"
+	"github.com/axxonsoft-assignment/pkg/policy"
+	"github.com/axxonsoft-assignment/pkg/scheduler"
+	"github.com/axxonsoft-assignment/pkg/secrets"
 	taskService "github.com/axxonsoft-assignment/pkg/service"
+	"github.com/axxonsoft-assignment/pkg/signing"
+	"github.com/axxonsoft-assignment/pkg/ssrf"
+	"github.com/axxonsoft-assignment/pkg/tlsprofiles"
 	"github.com/go-redis/redis/v8"
 	"github.com/gorilla/mux"
 	"github.com/joho/godotenv"
+	"google.golang.org/grpc"
 )
 
 func main() {
@@ -24,13 +42,38 @@ func main() {
 
 	// Initialize layers
 	cacheLayer := cache.New(redisClient)
-	service := taskService.New(cacheLayer)
+	breakers := breaker.New(BreakerSettings())
+	service := taskService.New(cacheLayer, breakers, ServiceSettings())
+	schedulesService := taskService.NewSchedules(cacheLayer, service)
+	rateLimitsService := taskService.NewRateLimits(cacheLayer)
+	breakersService := taskService.NewBreakers(breakers)
+	templatesService := taskService.NewTemplates(cacheLayer, service)
+	chainsService := taskService.NewChains(cacheLayer, service)
+	workflowsService := taskService.NewWorkflows(cacheLayer, service)
 	handler := tasksHandler.New(service)
+	schedulesHandler := tasksHandler.NewSchedules(schedulesService)
+	rateLimitsHandler := tasksHandler.NewRateLimits(rateLimitsService)
+	breakersHandler := tasksHandler.NewBreakers(breakersService)
+	templatesHandler := tasksHandler.NewTemplates(templatesService)
+	chainsHandler := tasksHandler.NewChains(chainsService)
+	workflowsHandler := tasksHandler.NewWorkflows(workflowsService)
+
+	// Fire the recurring tasks in the background
+	go scheduler.Run(context.Background(), schedulesService, SchedulerInterval())
+
+	// Resume the workflows left by stopped instances in the background
+	go scheduler.ResumeWorkflows(context.Background(), workflowsService, WorkflowsResumeInterval())
 
 	router := mux.NewRouter()
 
 	// Initialize routes
-	routes.New(router, handler)
+	routes.New(router, handler, schedulesHandler, rateLimitsHandler, breakersHandler, templatesHandler, chainsHandler,
+		workflowsHandler)
+
+	// Serve the gRPC API on its own port, next to the REST API
+	if grpcPort := os.Getenv("GRPC_PORT"); grpcPort != "" {
+		go ServeGRPC(grpcPort, service)
+	}
 
 	port := os.Getenv("HTTP_PORT")
 	log.Printf("Server is running on http://localhost:%v\n", port)
@@ -42,6 +85,33 @@ func main() {
 	}
 }
 
+// ServeGRPC serves the gRPC API of the tasks on the port
+func ServeGRPC(port string, service taskService.Tasks) {
+	listener, err := net.Listen("tcp", ":"+port)
+	if err != nil {
+		log.Fatalf("Error listening on GRPC_PORT: %v", err)
+	}
+
+	grpcServer := grpc.NewServer()
+	taskspb.RegisterTasksServer(grpcServer, tasksServer.New(service, GRPCWatchInterval()))
+
+	log.Printf("gRPC server is running on localhost:%v\n", port)
+
+	if err := grpcServer.Serve(listener); err != nil {
+		log.Fatalf("Error running the gRPC server: %v", err)
+	}
+}
+
+// GRPCWatchInterval reads how often a watched task is checked for changes, defaults to 1 second
+func GRPCWatchInterval() time.Duration {
+	interval, err := time.ParseDuration(os.Getenv("GRPC_WATCH_INTERVAL"))
+	if err != nil || interval <= 0 {
+		return time.Second
+	}
+
+	return interval
+}
+
 func NewRedisClient() *redis.Client {
 	client := redis.NewClient(&redis.Options{
 		Addr: os.Getenv("REDIS_HOST") + ":" + os.Getenv("REDIS_PORT"),
@@ -50,6 +120,162 @@ func NewRedisClient() *redis.Client {
 	return client
 }
 
+// SchedulerInterval reads how often the due schedules are checked, defaults to 10 seconds
+func SchedulerInterval() time.Duration {
+	interval, err := time.ParseDuration(os.Getenv("SCHEDULER_INTERVAL"))
+	if err != nil || interval <= 0 {
+		return 10 * time.Second
+	}
+
+	return interval
+}
+
+// WorkflowsResumeInterval reads how often the workflows nobody runs are looked for, defaults to 30 seconds
+func WorkflowsResumeInterval() time.Duration {
+	interval, err := time.ParseDuration(os.Getenv("WORKFLOWS_RESUME_INTERVAL"))
+	if err != nil || interval <= 0 {
+		return 30 * time.Second
+	}
+
+	return interval
+}
+
+// BreakerSettings reads the thresholds of the per host circuit breakers, unset values fall back to the defaults
+func BreakerSettings() breaker.Settings {
+	consecutiveFailures, _ := strconv.Atoi(os.Getenv("BREAKER_CONSECUTIVE_FAILURES"))
+	failureRate, _ := strconv.ParseFloat(os.Getenv("BREAKER_FAILURE_RATE"), 64)
+	minRequests, _ := strconv.Atoi(os.Getenv("BREAKER_MIN_REQUESTS"))
+	window, _ := time.ParseDuration(os.Getenv("BREAKER_WINDOW"))
+	openTimeout, _ := time.ParseDuration(os.Getenv("BREAKER_OPEN_TIMEOUT"))
+	halfOpenRequests, _ := strconv.Atoi(os.Getenv("BREAKER_HALF_OPEN_REQUESTS"))
+
+	return breaker.Settings{
+		ConsecutiveFailures: consecutiveFailures,
+		FailureRate:         failureRate,
+		MinRequests:         minRequests,
+		Window:              window,
+		OpenTimeout:         openTimeout,
+		HalfOpenRequests:    halfOpenRequests,
+	}
+}
+
+// ServiceSettings reads the configuration of the outbound calls
+func ServiceSettings() taskService.Settings {
+	concurrencyLimits, err := model.ParseConcurrencyLimits(os.Getenv("CONCURRENCY_LIMITS"))
+	if err != nil {
+		log.Fatalf("Error reading CONCURRENCY_LIMITS: %v", err)
+	}
+
+	var proxyURL *url.URL
+
+	if rawProxy := os.Getenv("OUTBOUND_PROXY"); rawProxy != "" {
+		proxyURL, err = model.ParseProxy(rawProxy)
+		if err != nil {
+			log.Fatalf("Error reading OUTBOUND_PROXY: %v", err)
+		}
+	}
+
+	allowlist := strings.Split(os.Getenv("SSRF_ALLOWLIST"), ",")
+
+	// the global proxy is trusted even when it lives on an internal address
+	if proxyURL != nil {
+		allowlist = append(allowlist, proxyURL.Hostname())
+	}
+
+	guard, err := ssrf.New(allowlist)
+	if err != nil {
+		log.Fatalf("Error reading SSRF_ALLOWLIST: %v", err)
+	}
+
+	reloadInterval, err := time.ParseDuration(os.Getenv("POLICY_RELOAD_INTERVAL"))
+	if err != nil {
+		reloadInterval = 30 * time.Second
+	}
+
+	policyEngine, err := policy.New(os.Getenv("POLICY_FILE"), reloadInterval)
+	if err != nil {
+		log.Fatalf("Error loading POLICY_FILE: %v", err)
+	}
+
+	// Reload the policy file whenever it changes
+	go policyEngine.Watch(context.Background())
+
+	tlsProfiles, err := tlsprofiles.Load(os.Getenv("TLS_PROFILES_FILE"))
+	if err != nil {
+		log.Fatalf("Error loading TLS_PROFILES_FILE: %v", err)
+	}
+
+	hmacSchemes, err := signing.LoadSchemes(os.Getenv("HMAC_SCHEMES_FILE"))
+	if err != nil {
+		log.Fatalf("Error loading HMAC_SCHEMES_FILE: %v", err)
+	}
+
+	// the threshold defaults to 1MiB and the max response size to 100MiB when not set
+	blobThreshold, _ := strconv.ParseInt(os.Getenv("BLOB_THRESHOLD"), 10, 64)
+	maxResponseBytes, _ := strconv.ParseInt(os.Getenv("MAX_RESPONSE_BYTES"), 10, 64)
+
+	return taskService.Settings{
+		ConcurrencyLimits: concurrencyLimits,
+		Guard:             guard,
+		Policy:            policyEngine,
+		Proxy:             proxyURL,
+		NoProxy:           strings.Split(os.Getenv("NO_PROXY"), ","),
+		TLSProfiles:       tlsProfiles,
+		Secrets:           SecretsProvider(),
+		HMACSchemes:       hmacSchemes,
+		Blobs:             BlobStore(),
+		BlobThreshold:     blobThreshold,
+		MaxResponseBytes:  maxResponseBytes,
+	}
+}
+
+// BlobStore builds the store of the large response bodies as per BLOB_STORE, either local (files under BLOB_DIR)
+// or s3 (objects of S3_BUCKET at S3_ENDPOINT), large bodies are read in memory like the others if not set
+func BlobStore() blob.Store {
+	switch os.Getenv("BLOB_STORE") {
+	case "":
+		return nil
+	case "local":
+		store, err := blob.NewLocal(os.Getenv("BLOB_DIR"))
+		if err != nil {
+			log.Fatalf("Error opening BLOB_DIR: %v", err)
+		}
+
+		return store
+	case "s3":
+		signer := signing.NewSigV4(os.Getenv("S3_ACCESS_KEY_ID"), os.Getenv("S3_SECRET_ACCESS_KEY"),
+			os.Getenv("S3_SESSION_TOKEN"), os.Getenv("S3_REGION"), "s3")
+
+		return blob.NewS3(os.Getenv("S3_ENDPOINT"), os.Getenv("S3_BUCKET"), signer)
+	default:
+		log.Fatalf("Error reading BLOB_STORE: should be one of [local, s3]")
+
+		return nil
+	}
+}
+
+// SecretsProvider looks up the secrets in the encrypted SECRETS_FILE if set, then in the environment variables
+// prefixed with SECRETS_ENV_PREFIX (defaults to SECRET_)
+func SecretsProvider() secrets.Provider {
+	prefix, ok := os.LookupEnv("SECRETS_ENV_PREFIX")
+	if !ok {
+		prefix = "SECRET_"
+	}
+
+	providers := []secrets.Provider{}
+
+	if file := os.Getenv("SECRETS_FILE"); file != "" {
+		fileProvider, err := secrets.NewFile(file, os.Getenv("SECRETS_KEY"))
+		if err != nil {
+			log.Fatalf("Error loading SECRETS_FILE: %v", err)
+		}
+
+		providers = append(providers, fileProvider)
+	}
+
+	return secrets.NewChain(append(providers, secrets.NewEnv(prefix))...)
+}
+
 func LoadEnv() {
 	envPath := "./config/.env"
 
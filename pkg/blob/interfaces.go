@@ -0,0 +1,19 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+// ErrNotFound is returned when no blob is stored under the key
+var ErrNotFound = errors.New("blob not found")
+
+// Store keeps the response bodies too large to be held along with the tasks, under keys made of
+// '/' separated segments
+type Store interface {
+	// Put streams the body into the blob of the key, replacing it if any, and gives the number of bytes written
+	Put(ctx context.Context, key string, body io.Reader) (int64, error)
+	// Open reads the blob of the key from the offset till its end
+	Open(ctx context.Context, key string, offset int64) (io.ReadCloser, error)
+}
@@ -0,0 +1,91 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+type local struct {
+	dir string
+}
+
+// NewLocal builds a store keeping the blobs as files under the directory, which is created if missing
+func NewLocal(dir string) (Store, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, err
+	}
+
+	return &local{dir: dir}, nil
+}
+
+func (l local) Put(_ context.Context, key string, body io.Reader) (int64, error) {
+	path, err := l.path(key)
+	if err != nil {
+		return 0, err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return 0, err
+	}
+
+	// the body is written aside and renamed once complete, so that a blob being replaced is never read half written
+	file, err := os.CreateTemp(filepath.Dir(path), ".put-*")
+	if err != nil {
+		return 0, err
+	}
+	defer os.Remove(file.Name())
+
+	written, err := io.Copy(file, body)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	if err = os.Rename(file.Name(), path); err != nil {
+		return 0, err
+	}
+
+	return written, nil
+}
+
+func (l local) Open(_ context.Context, key string, offset int64) (io.ReadCloser, error) {
+	path, err := l.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = file.Seek(offset, io.SeekStart); err != nil {
+		file.Close()
+
+		return nil, err
+	}
+
+	return file, nil
+}
+
+// path gives the file of the key, which cannot point outside of the directory
+func (l local) path(key string) (string, error) {
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "" || segment == "." || segment == ".." || strings.HasPrefix(segment, ".put-") {
+			return "", errors.New("Invalid blob key: " + key)
+		}
+	}
+
+	return filepath.Join(l.dir, filepath.FromSlash(key)), nil
+}
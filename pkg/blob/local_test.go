@@ -0,0 +1,73 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocal(t *testing.T) {
+	store, err := NewLocal(t.TempDir())
+	assert.Nil(t, err)
+
+	written, err := store.Put(context.TODO(), "tasks/task-1", strings.NewReader("first version"))
+	assert.Nil(t, err)
+	assert.Equal(t, int64(13), written)
+
+	// a blob put again is replaced
+	written, err = store.Put(context.TODO(), "tasks/task-1", strings.NewReader("0123456789"))
+	assert.Nil(t, err)
+	assert.Equal(t, int64(10), written)
+
+	tcs := []struct {
+		description string
+		key         string
+		offset      int64
+		expBody     string
+		expErr      error
+	}{
+		{
+			description: "Positive case: blob read from the start",
+			key:         "tasks/task-1",
+			expBody:     "0123456789",
+		},
+		{
+			description: "Positive case: blob read from an offset",
+			key:         "tasks/task-1",
+			offset:      6,
+			expBody:     "6789",
+		},
+		{
+			description: "Negative case: blob not found",
+			key:         "tasks/task-2",
+			expErr:      ErrNotFound,
+		},
+		{
+			description: "Negative case: key out of the directory",
+			key:         "tasks/../../etc/passwd",
+			expErr:      errors.New("Invalid blob key: tasks/../../etc/passwd"),
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			body, err := store.Open(context.TODO(), tc.key, tc.offset)
+			assert.Equal(t, tc.expErr, err)
+
+			if err != nil {
+				return
+			}
+
+			defer body.Close()
+
+			data, _ := io.ReadAll(body)
+			assert.Equal(t, tc.expBody, string(data))
+		})
+	}
+}
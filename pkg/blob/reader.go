@@ -0,0 +1,73 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+type reader struct {
+	ctx    context.Context
+	store  Store
+	key    string
+	size   int64
+	offset int64
+	body   io.ReadCloser
+}
+
+// NewReader gives a seekable reader of the blob of the key and size, e.g. for http.ServeContent to serve its
+// ranges. The blob is opened lazily at the offset of the first read following a seek.
+func NewReader(ctx context.Context, store Store, key string, size int64) io.ReadSeekCloser {
+	return &reader{ctx: ctx, store: store, key: key, size: size}
+}
+
+func (r *reader) Read(p []byte) (int, error) {
+	if r.offset >= r.size {
+		return 0, io.EOF
+	}
+
+	if r.body == nil {
+		body, err := r.store.Open(r.ctx, r.key, r.offset)
+		if err != nil {
+			return 0, err
+		}
+
+		r.body = body
+	}
+
+	n, err := r.body.Read(p)
+	r.offset += int64(n)
+
+	return n, err
+}
+
+func (r *reader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekCurrent:
+		offset += r.offset
+	case io.SeekEnd:
+		offset += r.size
+	}
+
+	if offset < 0 {
+		return 0, errors.New("blob: negative offset")
+	}
+
+	if offset != r.offset {
+		r.Close()
+		r.offset = offset
+	}
+
+	return offset, nil
+}
+
+func (r *reader) Close() error {
+	if r.body == nil {
+		return nil
+	}
+
+	err := r.body.Close()
+	r.body = nil
+
+	return err
+}
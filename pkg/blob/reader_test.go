@@ -0,0 +1,72 @@
+package blob
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReader(t *testing.T) {
+	store, _ := NewLocal(t.TempDir())
+	_, _ = store.Put(context.TODO(), "tasks/task-1", strings.NewReader("0123456789"))
+
+	tcs := []struct {
+		description string
+		rangeHeader string
+		expStatus   int
+		expBody     string
+	}{
+		{
+			description: "Positive case: whole blob",
+			expStatus:   http.StatusOK,
+			expBody:     "0123456789",
+		},
+		{
+			description: "Positive case: range of the blob",
+			rangeHeader: "bytes=2-4",
+			expStatus:   http.StatusPartialContent,
+			expBody:     "234",
+		},
+		{
+			description: "Positive case: suffix of the blob",
+			rangeHeader: "bytes=-3",
+			expStatus:   http.StatusPartialContent,
+			expBody:     "789",
+		},
+		{
+			description: "Negative case: range past the end",
+			rangeHeader: "bytes=20-30",
+			expStatus:   http.StatusRequestedRangeNotSatisfiable,
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			content := NewReader(context.TODO(), store, "tasks/task-1", 10)
+			defer content.Close()
+
+			request := httptest.NewRequest(http.MethodGet, "/task/task-1/body", nil)
+			if tc.rangeHeader != "" {
+				request.Header.Set("Range", tc.rangeHeader)
+			}
+
+			recorder := httptest.NewRecorder()
+			http.ServeContent(recorder, request, "", time.Time{}, content)
+
+			assert.Equal(t, tc.expStatus, recorder.Code)
+
+			if tc.expBody != "" {
+				body, _ := io.ReadAll(recorder.Body)
+				assert.Equal(t, tc.expBody, string(body))
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package blob
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/axxonsoft-assignment/pkg/signing"
+)
+
+type s3 struct {
+	endpoint string
+	bucket   string
+	signer   signing.Signer
+	client   *http.Client
+}
+
+// NewS3 builds a store keeping the blobs as the objects of a bucket of an S3-compatible service, addressed path-style
+// as <endpoint>/<bucket>/<key> and signed by the signer, e.g. signing.NewSigV4 for the "s3" service
+func NewS3(endpoint, bucket string, signer signing.Signer) Store {
+	return &s3{
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		bucket:   bucket,
+		signer:   signer,
+		client:   &http.Client{},
+	}
+}
+
+// Put spools the body to a temporary file while hashing it, as the object is uploaded with its length and the
+// hash of its content, without holding it in memory
+func (s s3) Put(ctx context.Context, key string, body io.Reader) (int64, error) {
+	spool, err := os.CreateTemp("", "blob-*")
+	if err != nil {
+		return 0, err
+	}
+
+	defer func() {
+		spool.Close()
+		os.Remove(spool.Name())
+	}()
+
+	hash := sha256.New()
+
+	size, err := io.Copy(io.MultiWriter(spool, hash), body)
+	if err != nil {
+		return 0, err
+	}
+
+	if _, err = spool.Seek(0, io.SeekStart); err != nil {
+		return 0, err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPut, s.url(key), io.NopCloser(spool))
+	if err != nil {
+		return 0, err
+	}
+
+	request.ContentLength = size
+	request.Header.Set("X-Amz-Content-Sha256", hex.EncodeToString(hash.Sum(nil)))
+
+	response, err := s.do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+
+	return size, nil
+}
+
+func (s s3) Open(ctx context.Context, key string, offset int64) (io.ReadCloser, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url(key), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if offset > 0 {
+		request.Header.Set("Range", "bytes="+strconv.FormatInt(offset, 10)+"-")
+	}
+
+	response, err := s.do(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if offset == 0 || response.StatusCode == http.StatusPartialContent {
+		return response.Body, nil
+	}
+
+	// the range was ignored, the whole object is given so the bytes before the offset are skipped
+	if response.StatusCode == http.StatusOK {
+		if _, err = io.CopyN(io.Discard, response.Body, offset); err != nil {
+			response.Body.Close()
+
+			return nil, fmt.Errorf("s3 GET %s: skipping to offset %d: %w", request.URL.Path, offset, err)
+		}
+
+		return response.Body, nil
+	}
+
+	response.Body.Close()
+
+	return nil, fmt.Errorf("s3 GET %s: unexpected status %d for a range request", request.URL.Path, response.StatusCode)
+}
+
+// do signs and sends the request, an error status is given as an error
+func (s s3) do(request *http.Request) (*http.Response, error) {
+	if err := s.signer.Sign(request, nil, time.Now()); err != nil {
+		return nil, err
+	}
+
+	response, err := s.client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode < http.StatusBadRequest {
+		return response, nil
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+
+	message, _ := io.ReadAll(io.LimitReader(response.Body, 512))
+
+	return nil, fmt.Errorf("s3 %s %s: %d %s", request.Method, request.URL.Path, response.StatusCode,
+		strings.TrimSpace(string(message)))
+}
+
+func (s s3) url(key string) string {
+	return s.endpoint + "/" + s.bucket + "/" + key
+}
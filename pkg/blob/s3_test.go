@@ -0,0 +1,107 @@
+package blob
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/axxonsoft-assignment/internal/s3test"
+	"github.com/axxonsoft-assignment/pkg/signing"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestS3(t *testing.T) {
+	server := s3test.NewServer()
+	defer server.Close()
+
+	store := NewS3(server.URL+"/", "responses",
+		signing.NewSigV4(s3test.AccessKeyID, s3test.SecretAccessKey, "", s3test.Region, "s3"))
+
+	written, err := store.Put(context.TODO(), "tasks/task-1", strings.NewReader("0123456789"))
+	assert.Nil(t, err)
+	assert.Equal(t, int64(10), written)
+
+	object, ok := server.Object("responses/tasks/task-1")
+	assert.True(t, ok)
+	assert.Equal(t, "0123456789", string(object))
+
+	tcs := []struct {
+		description string
+		key         string
+		offset      int64
+		expBody     string
+		expErr      error
+	}{
+		{
+			description: "Positive case: object read from the start",
+			key:         "tasks/task-1",
+			expBody:     "0123456789",
+		},
+		{
+			description: "Positive case: object read from an offset",
+			key:         "tasks/task-1",
+			offset:      6,
+			expBody:     "6789",
+		},
+		{
+			description: "Negative case: object not found",
+			key:         "tasks/task-2",
+			expErr:      ErrNotFound,
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			body, err := store.Open(context.TODO(), tc.key, tc.offset)
+			assert.Equal(t, tc.expErr, err)
+
+			if err != nil {
+				return
+			}
+
+			defer body.Close()
+
+			data, _ := io.ReadAll(body)
+			assert.Equal(t, tc.expBody, string(data))
+		})
+	}
+
+	// the requests signed with other credentials are rejected
+	wrong := NewS3(server.URL, "responses", signing.NewSigV4(s3test.AccessKeyID, "wrong", "", s3test.Region, "s3"))
+
+	_, err = wrong.Put(context.TODO(), "tasks/task-3", strings.NewReader("data"))
+	assert.EqualError(t, err, "s3 PUT /responses/tasks/task-3: 403 SignatureDoesNotMatch")
+}
+
+func TestS3_Open_rangeIgnored(t *testing.T) {
+	// a server that ignores the Range header, giving the whole object
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/other") {
+			w.WriteHeader(http.StatusNonAuthoritativeInfo)
+		}
+
+		_, _ = w.Write([]byte("0123456789"))
+	}))
+	defer server.Close()
+
+	store := NewS3(server.URL, "responses",
+		signing.NewSigV4(s3test.AccessKeyID, s3test.SecretAccessKey, "", s3test.Region, "s3"))
+
+	body, err := store.Open(context.TODO(), "tasks/task-1", 6)
+	assert.Nil(t, err)
+
+	data, _ := io.ReadAll(body)
+	body.Close()
+	assert.Equal(t, "6789", string(data))
+
+	_, err = store.Open(context.TODO(), "tasks/task-1", 20)
+	assert.EqualError(t, err, "s3 GET /responses/tasks/task-1: skipping to offset 20: EOF")
+
+	_, err = store.Open(context.TODO(), "tasks/other", 6)
+	assert.EqualError(t, err, "s3 GET /responses/tasks/other: unexpected status 203 for a range request")
+}
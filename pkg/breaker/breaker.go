@@ -0,0 +1,269 @@
+package breaker
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/axxonsoft-assignment/pkg/model"
+)
+
+// ErrCircuitOpen is returned by Allow while the host's circuit is open, or half-open with all the probes in flight
+var ErrCircuitOpen = errors.New("circuit breaker is open for the host")
+
+// Settings holds the thresholds of the circuit breakers, a zero value falls back to its default
+type Settings struct {
+	// ConsecutiveFailures opens the circuit after that many failures in a row
+	ConsecutiveFailures int
+	// FailureRate opens the circuit once the ratio of failures within the window reaches it
+	FailureRate float64
+	// MinRequests is the number of calls within the window before the failure rate is considered
+	MinRequests int
+	// Window is the interval over which the failure rate is counted
+	Window time.Duration
+	// OpenTimeout is how long the circuit stays open before letting probes through
+	OpenTimeout time.Duration
+	// HalfOpenRequests is the number of probes let through while half-open
+	HalfOpenRequests int
+}
+
+// Generation identifies a state a circuit went through, the outcome of a call only counts in the state it was allowed in
+type Generation uint64
+
+type circuit struct {
+	state               string
+	generation          Generation
+	consecutiveFailures int
+	requests            int
+	failures            int
+	windowStart         time.Time
+	openedAt            time.Time
+	probes              int
+}
+
+type breakers struct {
+	mu       sync.Mutex
+	settings Settings
+	circuits map[string]*circuit
+	now      func() time.Time
+	// generation is the last generation given to a circuit, the generations are never reused, even by a reset circuit
+	generation Generation
+}
+
+func New(settings Settings) Breakers {
+	if settings.ConsecutiveFailures <= 0 {
+		settings.ConsecutiveFailures = 5
+	}
+
+	if settings.FailureRate <= 0 || settings.FailureRate > 1 {
+		settings.FailureRate = 0.5
+	}
+
+	if settings.MinRequests <= 0 {
+		settings.MinRequests = 20
+	}
+
+	if settings.Window <= 0 {
+		settings.Window = time.Minute
+	}
+
+	if settings.OpenTimeout <= 0 {
+		settings.OpenTimeout = 30 * time.Second
+	}
+
+	if settings.HalfOpenRequests <= 0 {
+		settings.HalfOpenRequests = 1
+	}
+
+	return &breakers{settings: settings, circuits: map[string]*circuit{}, now: time.Now}
+}
+
+// Allow checks if a call to the host can be made, every allowed call must be followed by Success, Failure or Cancel
+// with the generation of the circuit it was allowed in.
+func (b *breakers) Allow(host string) (Generation, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c := b.circuit(host)
+	now := b.now()
+
+	switch c.state {
+	case model.CircuitOpen:
+		if now.Sub(c.openedAt) < b.settings.OpenTimeout {
+			return 0, ErrCircuitOpen
+		}
+
+		c.state = model.CircuitHalfOpen
+		c.generation = b.next()
+		c.probes = 0
+
+		fallthrough
+	case model.CircuitHalfOpen:
+		if c.probes >= b.settings.HalfOpenRequests {
+			return 0, ErrCircuitOpen
+		}
+
+		c.probes++
+	default:
+		if now.Sub(c.windowStart) >= b.settings.Window {
+			c.requests, c.failures, c.windowStart = 0, 0, now
+		}
+	}
+
+	return c.generation, nil
+}
+
+// Success records a successful call to the host, a successful probe closes the circuit. A call allowed in an earlier
+// generation of the circuit, e.g. made before it opened, is not counted.
+func (b *breakers) Success(host string, generation Generation) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c := b.circuit(host)
+	if c.generation != generation {
+		return
+	}
+
+	if c.state == model.CircuitHalfOpen {
+		b.close(c)
+
+		return
+	}
+
+	c.requests++
+	c.consecutiveFailures = 0
+}
+
+// Failure records a failed call to the host, opening the circuit if a threshold is reached or the probe failed. A call
+// allowed in an earlier generation of the circuit is not counted.
+func (b *breakers) Failure(host string, generation Generation) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c := b.circuit(host)
+	if c.generation != generation {
+		return
+	}
+
+	if c.state == model.CircuitHalfOpen {
+		b.open(c)
+
+		return
+	}
+
+	c.requests++
+	c.failures++
+	c.consecutiveFailures++
+
+	if c.consecutiveFailures >= b.settings.ConsecutiveFailures ||
+		(c.requests >= b.settings.MinRequests && float64(c.failures)/float64(c.requests) >= b.settings.FailureRate) {
+		b.open(c)
+	}
+}
+
+// Cancel records that an allowed call was not made after all, giving a half-open circuit its probe back.
+func (b *breakers) Cancel(host string, generation Generation) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c := b.circuit(host)
+
+	if c.generation == generation && c.state == model.CircuitHalfOpen && c.probes > 0 {
+		c.probes--
+	}
+}
+
+// State gives the state of the host's circuit, hosts never called are closed.
+func (b *breakers) State(host string) model.CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	host = strings.ToLower(host)
+
+	c, ok := b.circuits[host]
+	if !ok {
+		return model.CircuitState{Host: host, State: model.CircuitClosed}
+	}
+
+	return b.snapshot(host, c)
+}
+
+// States gives the state of the circuits of all the hosts called so far, sorted by host.
+func (b *breakers) States() []model.CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	states := make([]model.CircuitState, 0, len(b.circuits))
+
+	for host, c := range b.circuits {
+		states = append(states, b.snapshot(host, c))
+	}
+
+	sort.Slice(states, func(i, j int) bool {
+		return states[i].Host < states[j].Host
+	})
+
+	return states
+}
+
+// Reset closes the host's circuit and clears its counts.
+func (b *breakers) Reset(host string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	delete(b.circuits, strings.ToLower(host))
+}
+
+func (b *breakers) circuit(host string) *circuit {
+	host = strings.ToLower(host)
+
+	c, ok := b.circuits[host]
+	if !ok {
+		c = &circuit{state: model.CircuitClosed, generation: b.next(), windowStart: b.now()}
+		b.circuits[host] = c
+	}
+
+	return c
+}
+
+func (b *breakers) next() Generation {
+	b.generation++
+
+	return b.generation
+}
+
+func (b *breakers) open(c *circuit) {
+	c.state = model.CircuitOpen
+	c.generation = b.next()
+	c.openedAt = b.now()
+	c.probes = 0
+}
+
+func (b *breakers) close(c *circuit) {
+	c.state = model.CircuitClosed
+	c.generation = b.next()
+	c.consecutiveFailures, c.requests, c.failures, c.probes = 0, 0, 0, 0
+	c.windowStart = b.now()
+}
+
+func (b *breakers) snapshot(host string, c *circuit) model.CircuitState {
+	state := model.CircuitState{
+		Host:                host,
+		State:               c.state,
+		ConsecutiveFailures: c.consecutiveFailures,
+		Requests:            c.requests,
+		Failures:            c.failures,
+	}
+
+	if c.state == model.CircuitOpen {
+		openedAt := c.openedAt
+		retryAt := c.openedAt.Add(b.settings.OpenTimeout)
+
+		state.OpenedAt = &openedAt
+		state.RetryAt = &retryAt
+	}
+
+	return state
+}
@@ -0,0 +1,165 @@
+package breaker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/axxonsoft-assignment/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestBreakers(settings Settings, now *time.Time) *breakers {
+	b := New(settings).(*breakers)
+	b.now = func() time.Time { return *now }
+
+	return b
+}
+
+// call makes an allowed call to the host, with the given outcome
+func call(b Breakers, host string, success bool) {
+	generation, err := b.Allow(host)
+	if err != nil {
+		return
+	}
+
+	if success {
+		b.Success(host, generation)
+	} else {
+		b.Failure(host, generation)
+	}
+}
+
+func TestBreakers_ConsecutiveFailures(t *testing.T) {
+	now := time.Date(2023, 11, 20, 10, 30, 0, 0, time.UTC)
+	b := newTestBreakers(Settings{ConsecutiveFailures: 3, OpenTimeout: 10 * time.Second}, &now)
+
+	for i := 0; i < 3; i++ {
+		generation, err := b.Allow("api.partner.com")
+		assert.Nil(t, err)
+		b.Failure("api.partner.com", generation)
+	}
+
+	assert.Equal(t, model.CircuitOpen, b.State("api.partner.com").State)
+	_, err := b.Allow("api.partner.com")
+	assert.Equal(t, ErrCircuitOpen, err)
+
+	// other hosts are not affected
+	_, err = b.Allow("other.partner.com")
+	assert.Nil(t, err)
+
+	// after the timeout a single probe is let through
+	now = now.Add(10 * time.Second)
+	probe, err := b.Allow("api.partner.com")
+	assert.Nil(t, err)
+	assert.Equal(t, model.CircuitHalfOpen, b.State("api.partner.com").State)
+	_, err = b.Allow("api.partner.com")
+	assert.Equal(t, ErrCircuitOpen, err)
+
+	// a failed probe opens the circuit again
+	b.Failure("api.partner.com", probe)
+	assert.Equal(t, model.CircuitOpen, b.State("api.partner.com").State)
+
+	// a successful probe closes it
+	now = now.Add(10 * time.Second)
+	probe, err = b.Allow("api.partner.com")
+	assert.Nil(t, err)
+	b.Success("api.partner.com", probe)
+	assert.Equal(t, model.CircuitState{Host: "api.partner.com", State: model.CircuitClosed}, b.State("api.partner.com"))
+}
+
+func TestBreakers_FailureRate(t *testing.T) {
+	now := time.Date(2023, 11, 20, 10, 30, 0, 0, time.UTC)
+	b := newTestBreakers(Settings{ConsecutiveFailures: 100, FailureRate: 0.5, MinRequests: 4, Window: time.Minute}, &now)
+
+	// alternate failures never reach the consecutive threshold, but reach the failure rate once the minimum calls are made
+	call(b, "api.partner.com", false)
+	call(b, "api.partner.com", true)
+	call(b, "api.partner.com", false)
+	assert.Equal(t, model.CircuitClosed, b.State("api.partner.com").State)
+
+	call(b, "api.partner.com", true)
+	call(b, "api.partner.com", false)
+	assert.Equal(t, model.CircuitOpen, b.State("api.partner.com").State)
+
+	// counts of an elapsed window are discarded
+	b.Reset("api.partner.com")
+	call(b, "api.partner.com", false)
+	call(b, "api.partner.com", false)
+	call(b, "api.partner.com", true)
+
+	now = now.Add(time.Minute)
+	call(b, "api.partner.com", false)
+	assert.Equal(t, model.CircuitClosed, b.State("api.partner.com").State)
+	assert.Equal(t, 1, b.State("api.partner.com").Requests)
+}
+
+func TestBreakers_States(t *testing.T) {
+	b := New(Settings{ConsecutiveFailures: 1})
+
+	call(b, "b.partner.com", false)
+	call(b, "A.partner.com", true)
+
+	states := b.States()
+
+	assert.Len(t, states, 2)
+	assert.Equal(t, "a.partner.com", states[0].Host)
+	assert.Equal(t, model.CircuitClosed, states[0].State)
+	assert.Equal(t, "b.partner.com", states[1].Host)
+	assert.Equal(t, model.CircuitOpen, states[1].State)
+	assert.NotNil(t, states[1].RetryAt)
+}
+
+func TestBreakers_Cancel(t *testing.T) {
+	now := time.Date(2023, 11, 20, 10, 30, 0, 0, time.UTC)
+	b := newTestBreakers(Settings{ConsecutiveFailures: 1, OpenTimeout: time.Second}, &now)
+
+	call(b, "api.partner.com", false)
+	now = now.Add(time.Second)
+
+	probe, err := b.Allow("api.partner.com")
+	assert.Nil(t, err)
+	_, err = b.Allow("api.partner.com")
+	assert.Equal(t, ErrCircuitOpen, err)
+
+	// the probe was not made, so another one is let through
+	b.Cancel("api.partner.com", probe)
+	_, err = b.Allow("api.partner.com")
+	assert.Nil(t, err)
+	assert.Equal(t, model.CircuitHalfOpen, b.State("api.partner.com").State)
+}
+
+func TestBreakers_Generation(t *testing.T) {
+	now := time.Date(2023, 11, 20, 10, 30, 0, 0, time.UTC)
+	b := newTestBreakers(Settings{ConsecutiveFailures: 1, OpenTimeout: time.Second}, &now)
+
+	// a slow call is allowed while the circuit is closed, another call then opens it
+	slow, err := b.Allow("api.partner.com")
+	assert.Nil(t, err)
+
+	call(b, "api.partner.com", false)
+	now = now.Add(time.Second)
+
+	probe, err := b.Allow("api.partner.com")
+	assert.Nil(t, err)
+
+	// the slow call ending while half-open neither closes nor opens the circuit, only the probe does
+	b.Success("api.partner.com", slow)
+	assert.Equal(t, model.CircuitHalfOpen, b.State("api.partner.com").State)
+
+	b.Failure("api.partner.com", slow)
+	assert.Equal(t, model.CircuitHalfOpen, b.State("api.partner.com").State)
+
+	b.Cancel("api.partner.com", slow)
+	_, err = b.Allow("api.partner.com")
+	assert.Equal(t, ErrCircuitOpen, err)
+
+	b.Success("api.partner.com", probe)
+	assert.Equal(t, model.CircuitClosed, b.State("api.partner.com").State)
+
+	// nor is a call allowed before a reset counted after it
+	stale, _ := b.Allow("api.partner.com")
+	b.Reset("api.partner.com")
+	b.Failure("api.partner.com", stale)
+	assert.Equal(t, model.CircuitClosed, b.State("api.partner.com").State)
+	assert.Equal(t, 0, b.State("api.partner.com").Failures)
+}
@@ -0,0 +1,14 @@
+package breaker
+
+import "github.com/axxonsoft-assignment/pkg/model"
+
+// Breakers keeps a circuit breaker per target host
+type Breakers interface {
+	Allow(host string) (Generation, error)
+	Success(host string, generation Generation)
+	Failure(host string, generation Generation)
+	Cancel(host string, generation Generation)
+	State(host string) model.CircuitState
+	States() []model.CircuitState
+	Reset(host string)
+}
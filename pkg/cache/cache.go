@@ -4,12 +4,21 @@ import (
 	"context"
 	"encoding/json"
 	"log"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/axxonsoft-assignment/pkg/model"
 	"github.com/go-redis/redis/v8"
 )
 
+const (
+	taskTTL = 7 * 24 * time.Hour
+	// tasksKey is the sorted set of the task IDs, scored by the creation time of the task in unix milliseconds. It is
+	// prefixed so that it cannot be taken for the ID of a task.
+	tasksKey = "index:tasks"
+)
+
 // cache represents a client for interacting with a Redis cache.
 type cache struct {
 	client *redis.Client
@@ -19,7 +28,27 @@ func New(client *redis.Client) Cache {
 	return &cache{client: client}
 }
 
-// StoreTask stores the task details into the cache with a TTL of 1 week
+// storeTaskScript stores the task, indexing it by its creation time the first time it is stored. The task expires
+// along with its entry in the index, however often it is stored again, and the expired entries are trimmed.
+// KEYS: task, index
+var storeTaskScript = redis.NewScript(`
+local now = tonumber(ARGV[3])
+local ttl = tonumber(ARGV[4])
+
+local created = tonumber(redis.call("ZSCORE", KEYS[2], ARGV[2]))
+if not created or created <= now - ttl then
+	created = now
+	redis.call("ZADD", KEYS[2], created, ARGV[2])
+end
+
+redis.call("SET", KEYS[1], ARGV[1], "PX", created + ttl - now)
+redis.call("ZREMRANGEBYSCORE", KEYS[2], "-inf", ARGV[5])
+
+return 1
+`)
+
+// StoreTask stores the task details into the cache for 1 week from its creation, indexing the task by its creation
+// time the first time it is stored
 func (c cache) StoreTask(ctx context.Context, taskId string, taskObj *model.TasksObject) error {
 	data, err := json.Marshal(taskObj)
 	if err != nil {
@@ -28,7 +57,13 @@ func (c cache) StoreTask(ctx context.Context, taskId string, taskObj *model.Task
 		return err
 	}
 
-	err = c.client.Set(ctx, taskId, data, 7*24*time.Hour).Err()
+	now := time.Now()
+
+	// the tasks created before the TTL have expired
+	expired := "(" + strconv.FormatInt(now.Add(-taskTTL).UnixMilli(), 10)
+
+	err = storeTaskScript.Run(ctx, c.client, []string{taskId, tasksKey}, data, taskId, now.UnixMilli(),
+		taskTTL.Milliseconds(), expired).Err()
 	if err != nil {
 		log.Printf("Error updating cache for task:%s: %v", taskId, err)
 
@@ -42,8 +77,8 @@ func (c cache) StoreTask(ctx context.Context, taskId string, taskObj *model.Task
 func (c cache) GetTask(ctx context.Context, taskID string) (*model.TasksObject, error) {
 	data, err := c.client.Get(ctx, taskID).Result()
 	if err != nil {
-		// If taskID is not present, return an empty object
-		if err == redis.Nil {
+		// If taskID is not present, return an empty object. Neither is a key holding something else than a task.
+		if err == redis.Nil || strings.HasPrefix(err.Error(), "WRONGTYPE") {
 			return &model.TasksObject{}, nil
 		}
 
@@ -62,3 +97,42 @@ func (c cache) GetTask(ctx context.Context, taskID string) (*model.TasksObject,
 
 	return taskObj, nil
 }
+
+// ListTasks fetches count tasks from the offset, the newest first, along with the offset of the next page, 0 if there
+// is none. The tasks expired but still in the index are skipped, the page being filled with the ones after them.
+func (c cache) ListTasks(ctx context.Context, offset, count int64) ([]*model.TasksObject, int64, error) {
+	tasks := make([]*model.TasksObject, 0, count)
+
+	for int64(len(tasks)) < count {
+		want := count - int64(len(tasks))
+
+		taskIDs, err := c.client.ZRevRange(ctx, tasksKey, offset, offset+want-1).Result()
+		if err != nil {
+			log.Printf("Error in listing the tasks from cache: %v", err)
+
+			return nil, 0, err
+		}
+
+		offset += int64(len(taskIDs))
+
+		for _, taskID := range taskIDs {
+			taskObj, err := c.GetTask(ctx, taskID)
+			if err != nil {
+				return nil, 0, err
+			}
+
+			// the index drops the expired tasks when the next task is stored, not to shift the pages being read
+			if taskObj.ID == "" {
+				continue
+			}
+
+			tasks = append(tasks, taskObj)
+		}
+
+		if int64(len(taskIDs)) < want {
+			return tasks, 0, nil
+		}
+	}
+
+	return tasks, offset, nil
+}
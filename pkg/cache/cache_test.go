@@ -3,15 +3,20 @@ package cache
 import (
 	"context"
 	"testing"
+	"time"
 
+	"github.com/alicebob/miniredis/v2"
 	"github.com/axxonsoft-assignment/pkg/model"
 	"github.com/go-redis/redis/v8"
 	"github.com/stretchr/testify/assert"
 )
 
-func NewCache() *cache {
+// NewCache gives a cache backed by an in-memory redis server, which is shut down at the end of the test
+func NewCache(t *testing.T) *cache {
+	server := miniredis.RunT(t)
+
 	client := redis.NewClient(&redis.Options{
-		Addr: "localhost:6379",
+		Addr: server.Addr(),
 	})
 
 	return &cache{
@@ -20,7 +25,7 @@ func NewCache() *cache {
 }
 
 func TestCache(t *testing.T) {
-	c := NewCache()
+	c := NewCache(t)
 	ctx := context.Background()
 	data := &model.TasksObject{
 		ID:     "2321",
@@ -40,3 +45,62 @@ func TestCache(t *testing.T) {
 	assert.Equal(t, err, nil)
 	assert.Equal(t, nil, nil)
 }
+
+func TestCache_StoreTask_expiry(t *testing.T) {
+	c := NewCache(t)
+	ctx := context.Background()
+
+	assert.Nil(t, c.StoreTask(ctx, "task-1", &model.TasksObject{ID: "task-1", Status: model.InProcess}))
+	assert.InDelta(t, taskTTL, c.client.PTTL(ctx, "task-1").Val(), float64(time.Second))
+
+	// a task created 6 days ago and stored again expires along with its entry in the index, a day later
+	created := time.Now().Add(-6 * 24 * time.Hour).UnixMilli()
+	c.client.ZAdd(ctx, tasksKey, &redis.Z{Score: float64(created), Member: "task-1"})
+
+	assert.Nil(t, c.StoreTask(ctx, "task-1", &model.TasksObject{ID: "task-1", Status: model.Done}))
+	assert.InDelta(t, 24*time.Hour, c.client.PTTL(ctx, "task-1").Val(), float64(time.Second))
+	assert.Equal(t, float64(created), c.client.ZScore(ctx, tasksKey, "task-1").Val())
+
+	// an entry past the TTL is trimmed when the next task is stored
+	c.client.ZAdd(ctx, tasksKey, &redis.Z{Score: float64(time.Now().Add(-taskTTL - time.Minute).UnixMilli()), Member: "task-0"})
+
+	assert.Nil(t, c.StoreTask(ctx, "task-2", &model.TasksObject{ID: "task-2", Status: model.InProcess}))
+	assert.Equal(t, []string{"task-2", "task-1"}, c.client.ZRevRange(ctx, tasksKey, 0, -1).Val())
+}
+
+func TestCache_ListTasks(t *testing.T) {
+	c := NewCache(t)
+	ctx := context.Background()
+
+	for _, taskID := range []string{"task-1", "task-2", "task-3"} {
+		assert.Nil(t, c.StoreTask(ctx, taskID, &model.TasksObject{ID: taskID, Status: model.InProcess}))
+		time.Sleep(2 * time.Millisecond)
+	}
+
+	// storing a task again keeps its place in the index
+	assert.Nil(t, c.StoreTask(ctx, "task-1", &model.TasksObject{ID: "task-1", Status: model.Done}))
+
+	tasks, next, err := c.ListTasks(ctx, 0, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, []*model.TasksObject{{ID: "task-3", Status: model.InProcess}, {ID: "task-2", Status: model.InProcess}}, tasks)
+	assert.Equal(t, int64(2), next)
+
+	tasks, next, err = c.ListTasks(ctx, 2, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, []*model.TasksObject{{ID: "task-1", Status: model.Done}}, tasks)
+	assert.Equal(t, int64(0), next)
+
+	// an expired task still in the index is skipped, the page being filled with the next ones
+	c.client.Del(ctx, "task-3")
+
+	tasks, next, err = c.ListTasks(ctx, 0, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, []*model.TasksObject{{ID: "task-2", Status: model.InProcess}, {ID: "task-1", Status: model.Done}}, tasks)
+	assert.Equal(t, int64(3), next)
+	assert.Equal(t, int64(3), c.client.ZCard(ctx, tasksKey).Val())
+
+	// the index is not taken for a task
+	taskObj, err := c.GetTask(ctx, tasksKey)
+	assert.Nil(t, err)
+	assert.Equal(t, &model.TasksObject{}, taskObj)
+}
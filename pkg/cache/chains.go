@@ -0,0 +1,54 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/axxonsoft-assignment/pkg/model"
+	"github.com/go-redis/redis/v8"
+)
+
+const chainPrefix = "chain:"
+
+// StoreChain stores the chain details into the cache with a TTL of 1 week, like its tasks
+func (c cache) StoreChain(ctx context.Context, chain *model.Chain) error {
+	data, err := json.Marshal(chain)
+	if err != nil {
+		log.Printf("Error marshalling chain object")
+
+		return err
+	}
+
+	if err = c.client.Set(ctx, chainPrefix+chain.ID, data, 7*24*time.Hour).Err(); err != nil {
+		log.Printf("Error updating cache for chain:%s: %v", chain.ID, err)
+
+		return err
+	}
+
+	return nil
+}
+
+// GetChain fetches the chain details from the cache using the chainID, returns an empty object if not found
+func (c cache) GetChain(ctx context.Context, chainID string) (*model.Chain, error) {
+	data, err := c.client.Get(ctx, chainPrefix+chainID).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return &model.Chain{}, nil
+		}
+
+		log.Printf("Error in fetching the chain:%s details from cache: %v", chainID, err)
+
+		return nil, err
+	}
+
+	chain := &model.Chain{}
+	if err = json.Unmarshal([]byte(data), chain); err != nil {
+		log.Printf("Error unmarshalling chain object")
+
+		return nil, err
+	}
+
+	return chain, nil
+}
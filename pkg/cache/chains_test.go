@@ -0,0 +1,29 @@
+package cache
+
+import (
+	"context"
+	"testing"
+
+	"github.com/axxonsoft-assignment/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCache_Chains(t *testing.T) {
+	c := NewCache(t)
+	ctx := context.Background()
+	chain := &model.Chain{ID: "chain-1", Status: model.New, Steps: []model.ChainStep{
+		{Name: "login", Task: model.Task{Method: "GET", URL: "https://www.getyourtasks.com/login"}, TaskID: "task-1"},
+	}}
+
+	err := c.StoreChain(ctx, chain)
+	assert.Nil(t, err)
+
+	resp, err := c.GetChain(ctx, "chain-1")
+	assert.Nil(t, err)
+	assert.Equal(t, chain, resp)
+
+	// Chain does not exist
+	resp, err = c.GetChain(ctx, "chain-2")
+	assert.Nil(t, err)
+	assert.Equal(t, &model.Chain{}, resp)
+}
@@ -10,6 +10,43 @@ import (
 type Cache interface {
 	StoreTask(ctx context.Context, taskId string, taskObj *model.TasksObject) error
 	GetTask(ctx context.Context, taskID string) (*model.TasksObject, error)
+	ListTasks(ctx context.Context, offset, count int64) ([]*model.TasksObject, int64, error)
+
+	StoreSchedule(ctx context.Context, schedule *model.Schedule) error
+	GetSchedule(ctx context.Context, scheduleID string) (*model.Schedule, error)
+	ListSchedules(ctx context.Context) ([]*model.Schedule, error)
+	DeleteSchedule(ctx context.Context, scheduleID string) error
+	ClaimScheduleRun(ctx context.Context, scheduleID string, scheduledAt time.Time) (bool, error)
+	AddScheduleRun(ctx context.Context, scheduleID string, run *model.ScheduleRun) error
+	GetScheduleRuns(ctx context.Context, scheduleID string) ([]*model.ScheduleRun, error)
+
+	StoreRateLimit(ctx context.Context, limit *model.RateLimit) error
+	GetRateLimit(ctx context.Context, host string) (*model.RateLimit, error)
+	ListRateLimits(ctx context.Context) ([]*model.RateLimit, error)
+	DeleteRateLimit(ctx context.Context, host string) error
+	TakeToken(ctx context.Context, host string, limit *model.RateLimit, now time.Time) (time.Duration, error)
+
+	AcquireSlot(ctx context.Context, host string, holderID string, limit int, lease time.Duration, now time.Time) (bool, error)
+	RenewSlot(ctx context.Context, host string, holderID string, lease time.Duration, now time.Time) (bool, error)
+	ReleaseSlot(ctx context.Context, host string, holderID string) error
+
+	StoreOAuthToken(ctx context.Context, key string, token *model.OAuthToken) error
+	GetOAuthToken(ctx context.Context, key string) (*model.OAuthToken, error)
+	DeleteOAuthToken(ctx context.Context, key string) error
+
+	StoreTemplate(ctx context.Context, tmpl *model.TaskTemplate) error
+	GetTemplate(ctx context.Context, name string) (*model.TaskTemplate, error)
+	ListTemplates(ctx context.Context) ([]*model.TaskTemplate, error)
+	DeleteTemplate(ctx context.Context, name string) error
+
+	StoreChain(ctx context.Context, chain *model.Chain) error
+	GetChain(ctx context.Context, chainID string) (*model.Chain, error)
+
+	StoreWorkflow(ctx context.Context, workflow *model.Workflow) error
+	GetWorkflow(ctx context.Context, workflowID string) (*model.Workflow, error)
+	ListActiveWorkflows(ctx context.Context) ([]*model.Workflow, error)
+	ClaimWorkflow(ctx context.Context, workflowID string, holderID string, lease time.Duration) (bool, error)
+	ReleaseWorkflow(ctx context.Context, workflowID string, holderID string) error
 }
 
 // Client interface for mocking redis client
@@ -37,6 +37,196 @@ func (m *MockCache) EXPECT() *MockCacheMockRecorder {
 	return m.recorder
 }
 
+// AcquireSlot mocks base method.
+func (m *MockCache) AcquireSlot(ctx context.Context, host, holderID string, limit int, lease time.Duration, now time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcquireSlot", ctx, host, holderID, limit, lease, now)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcquireSlot indicates an expected call of AcquireSlot.
+func (mr *MockCacheMockRecorder) AcquireSlot(ctx, host, holderID, limit, lease, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcquireSlot", reflect.TypeOf((*MockCache)(nil).AcquireSlot), ctx, host, holderID, limit, lease, now)
+}
+
+// AddScheduleRun mocks base method.
+func (m *MockCache) AddScheduleRun(ctx context.Context, scheduleID string, run *model.ScheduleRun) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddScheduleRun", ctx, scheduleID, run)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddScheduleRun indicates an expected call of AddScheduleRun.
+func (mr *MockCacheMockRecorder) AddScheduleRun(ctx, scheduleID, run interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddScheduleRun", reflect.TypeOf((*MockCache)(nil).AddScheduleRun), ctx, scheduleID, run)
+}
+
+// ClaimScheduleRun mocks base method.
+func (m *MockCache) ClaimScheduleRun(ctx context.Context, scheduleID string, scheduledAt time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimScheduleRun", ctx, scheduleID, scheduledAt)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimScheduleRun indicates an expected call of ClaimScheduleRun.
+func (mr *MockCacheMockRecorder) ClaimScheduleRun(ctx, scheduleID, scheduledAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimScheduleRun", reflect.TypeOf((*MockCache)(nil).ClaimScheduleRun), ctx, scheduleID, scheduledAt)
+}
+
+// ClaimWorkflow mocks base method.
+func (m *MockCache) ClaimWorkflow(ctx context.Context, workflowID, holderID string, lease time.Duration) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimWorkflow", ctx, workflowID, holderID, lease)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimWorkflow indicates an expected call of ClaimWorkflow.
+func (mr *MockCacheMockRecorder) ClaimWorkflow(ctx, workflowID, holderID, lease interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimWorkflow", reflect.TypeOf((*MockCache)(nil).ClaimWorkflow), ctx, workflowID, holderID, lease)
+}
+
+// DeleteOAuthToken mocks base method.
+func (m *MockCache) DeleteOAuthToken(ctx context.Context, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOAuthToken", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOAuthToken indicates an expected call of DeleteOAuthToken.
+func (mr *MockCacheMockRecorder) DeleteOAuthToken(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOAuthToken", reflect.TypeOf((*MockCache)(nil).DeleteOAuthToken), ctx, key)
+}
+
+// DeleteRateLimit mocks base method.
+func (m *MockCache) DeleteRateLimit(ctx context.Context, host string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRateLimit", ctx, host)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRateLimit indicates an expected call of DeleteRateLimit.
+func (mr *MockCacheMockRecorder) DeleteRateLimit(ctx, host interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRateLimit", reflect.TypeOf((*MockCache)(nil).DeleteRateLimit), ctx, host)
+}
+
+// DeleteSchedule mocks base method.
+func (m *MockCache) DeleteSchedule(ctx context.Context, scheduleID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSchedule", ctx, scheduleID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSchedule indicates an expected call of DeleteSchedule.
+func (mr *MockCacheMockRecorder) DeleteSchedule(ctx, scheduleID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSchedule", reflect.TypeOf((*MockCache)(nil).DeleteSchedule), ctx, scheduleID)
+}
+
+// DeleteTemplate mocks base method.
+func (m *MockCache) DeleteTemplate(ctx context.Context, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTemplate", ctx, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTemplate indicates an expected call of DeleteTemplate.
+func (mr *MockCacheMockRecorder) DeleteTemplate(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTemplate", reflect.TypeOf((*MockCache)(nil).DeleteTemplate), ctx, name)
+}
+
+// GetChain mocks base method.
+func (m *MockCache) GetChain(ctx context.Context, chainID string) (*model.Chain, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChain", ctx, chainID)
+	ret0, _ := ret[0].(*model.Chain)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChain indicates an expected call of GetChain.
+func (mr *MockCacheMockRecorder) GetChain(ctx, chainID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChain", reflect.TypeOf((*MockCache)(nil).GetChain), ctx, chainID)
+}
+
+// GetOAuthToken mocks base method.
+func (m *MockCache) GetOAuthToken(ctx context.Context, key string) (*model.OAuthToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOAuthToken", ctx, key)
+	ret0, _ := ret[0].(*model.OAuthToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOAuthToken indicates an expected call of GetOAuthToken.
+func (mr *MockCacheMockRecorder) GetOAuthToken(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOAuthToken", reflect.TypeOf((*MockCache)(nil).GetOAuthToken), ctx, key)
+}
+
+// GetRateLimit mocks base method.
+func (m *MockCache) GetRateLimit(ctx context.Context, host string) (*model.RateLimit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRateLimit", ctx, host)
+	ret0, _ := ret[0].(*model.RateLimit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRateLimit indicates an expected call of GetRateLimit.
+func (mr *MockCacheMockRecorder) GetRateLimit(ctx, host interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRateLimit", reflect.TypeOf((*MockCache)(nil).GetRateLimit), ctx, host)
+}
+
+// GetSchedule mocks base method.
+func (m *MockCache) GetSchedule(ctx context.Context, scheduleID string) (*model.Schedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSchedule", ctx, scheduleID)
+	ret0, _ := ret[0].(*model.Schedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSchedule indicates an expected call of GetSchedule.
+func (mr *MockCacheMockRecorder) GetSchedule(ctx, scheduleID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSchedule", reflect.TypeOf((*MockCache)(nil).GetSchedule), ctx, scheduleID)
+}
+
+// GetScheduleRuns mocks base method.
+func (m *MockCache) GetScheduleRuns(ctx context.Context, scheduleID string) ([]*model.ScheduleRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScheduleRuns", ctx, scheduleID)
+	ret0, _ := ret[0].([]*model.ScheduleRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetScheduleRuns indicates an expected call of GetScheduleRuns.
+func (mr *MockCacheMockRecorder) GetScheduleRuns(ctx, scheduleID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScheduleRuns", reflect.TypeOf((*MockCache)(nil).GetScheduleRuns), ctx, scheduleID)
+}
+
 // GetTask mocks base method.
 func (m *MockCache) GetTask(ctx context.Context, taskID string) (*model.TasksObject, error) {
 	m.ctrl.T.Helper()
@@ -52,6 +242,211 @@ func (mr *MockCacheMockRecorder) GetTask(ctx, taskID interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTask", reflect.TypeOf((*MockCache)(nil).GetTask), ctx, taskID)
 }
 
+// GetTemplate mocks base method.
+func (m *MockCache) GetTemplate(ctx context.Context, name string) (*model.TaskTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTemplate", ctx, name)
+	ret0, _ := ret[0].(*model.TaskTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTemplate indicates an expected call of GetTemplate.
+func (mr *MockCacheMockRecorder) GetTemplate(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplate", reflect.TypeOf((*MockCache)(nil).GetTemplate), ctx, name)
+}
+
+// GetWorkflow mocks base method.
+func (m *MockCache) GetWorkflow(ctx context.Context, workflowID string) (*model.Workflow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkflow", ctx, workflowID)
+	ret0, _ := ret[0].(*model.Workflow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkflow indicates an expected call of GetWorkflow.
+func (mr *MockCacheMockRecorder) GetWorkflow(ctx, workflowID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkflow", reflect.TypeOf((*MockCache)(nil).GetWorkflow), ctx, workflowID)
+}
+
+// ListActiveWorkflows mocks base method.
+func (m *MockCache) ListActiveWorkflows(ctx context.Context) ([]*model.Workflow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActiveWorkflows", ctx)
+	ret0, _ := ret[0].([]*model.Workflow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActiveWorkflows indicates an expected call of ListActiveWorkflows.
+func (mr *MockCacheMockRecorder) ListActiveWorkflows(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActiveWorkflows", reflect.TypeOf((*MockCache)(nil).ListActiveWorkflows), ctx)
+}
+
+// ListRateLimits mocks base method.
+func (m *MockCache) ListRateLimits(ctx context.Context) ([]*model.RateLimit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRateLimits", ctx)
+	ret0, _ := ret[0].([]*model.RateLimit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRateLimits indicates an expected call of ListRateLimits.
+func (mr *MockCacheMockRecorder) ListRateLimits(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRateLimits", reflect.TypeOf((*MockCache)(nil).ListRateLimits), ctx)
+}
+
+// ListSchedules mocks base method.
+func (m *MockCache) ListSchedules(ctx context.Context) ([]*model.Schedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSchedules", ctx)
+	ret0, _ := ret[0].([]*model.Schedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSchedules indicates an expected call of ListSchedules.
+func (mr *MockCacheMockRecorder) ListSchedules(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSchedules", reflect.TypeOf((*MockCache)(nil).ListSchedules), ctx)
+}
+
+// ListTasks mocks base method.
+func (m *MockCache) ListTasks(ctx context.Context, offset, count int64) ([]*model.TasksObject, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTasks", ctx, offset, count)
+	ret0, _ := ret[0].([]*model.TasksObject)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListTasks indicates an expected call of ListTasks.
+func (mr *MockCacheMockRecorder) ListTasks(ctx, offset, count interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTasks", reflect.TypeOf((*MockCache)(nil).ListTasks), ctx, offset, count)
+}
+
+// ListTemplates mocks base method.
+func (m *MockCache) ListTemplates(ctx context.Context) ([]*model.TaskTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTemplates", ctx)
+	ret0, _ := ret[0].([]*model.TaskTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTemplates indicates an expected call of ListTemplates.
+func (mr *MockCacheMockRecorder) ListTemplates(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTemplates", reflect.TypeOf((*MockCache)(nil).ListTemplates), ctx)
+}
+
+// ReleaseSlot mocks base method.
+func (m *MockCache) ReleaseSlot(ctx context.Context, host, holderID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseSlot", ctx, host, holderID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseSlot indicates an expected call of ReleaseSlot.
+func (mr *MockCacheMockRecorder) ReleaseSlot(ctx, host, holderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseSlot", reflect.TypeOf((*MockCache)(nil).ReleaseSlot), ctx, host, holderID)
+}
+
+// ReleaseWorkflow mocks base method.
+func (m *MockCache) ReleaseWorkflow(ctx context.Context, workflowID, holderID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseWorkflow", ctx, workflowID, holderID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseWorkflow indicates an expected call of ReleaseWorkflow.
+func (mr *MockCacheMockRecorder) ReleaseWorkflow(ctx, workflowID, holderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseWorkflow", reflect.TypeOf((*MockCache)(nil).ReleaseWorkflow), ctx, workflowID, holderID)
+}
+
+// RenewSlot mocks base method.
+func (m *MockCache) RenewSlot(ctx context.Context, host, holderID string, lease time.Duration, now time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenewSlot", ctx, host, holderID, lease, now)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RenewSlot indicates an expected call of RenewSlot.
+func (mr *MockCacheMockRecorder) RenewSlot(ctx, host, holderID, lease, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenewSlot", reflect.TypeOf((*MockCache)(nil).RenewSlot), ctx, host, holderID, lease, now)
+}
+
+// StoreChain mocks base method.
+func (m *MockCache) StoreChain(ctx context.Context, chain *model.Chain) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreChain", ctx, chain)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreChain indicates an expected call of StoreChain.
+func (mr *MockCacheMockRecorder) StoreChain(ctx, chain interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreChain", reflect.TypeOf((*MockCache)(nil).StoreChain), ctx, chain)
+}
+
+// StoreOAuthToken mocks base method.
+func (m *MockCache) StoreOAuthToken(ctx context.Context, key string, token *model.OAuthToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreOAuthToken", ctx, key, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreOAuthToken indicates an expected call of StoreOAuthToken.
+func (mr *MockCacheMockRecorder) StoreOAuthToken(ctx, key, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreOAuthToken", reflect.TypeOf((*MockCache)(nil).StoreOAuthToken), ctx, key, token)
+}
+
+// StoreRateLimit mocks base method.
+func (m *MockCache) StoreRateLimit(ctx context.Context, limit *model.RateLimit) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreRateLimit", ctx, limit)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreRateLimit indicates an expected call of StoreRateLimit.
+func (mr *MockCacheMockRecorder) StoreRateLimit(ctx, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreRateLimit", reflect.TypeOf((*MockCache)(nil).StoreRateLimit), ctx, limit)
+}
+
+// StoreSchedule mocks base method.
+func (m *MockCache) StoreSchedule(ctx context.Context, schedule *model.Schedule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreSchedule", ctx, schedule)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreSchedule indicates an expected call of StoreSchedule.
+func (mr *MockCacheMockRecorder) StoreSchedule(ctx, schedule interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreSchedule", reflect.TypeOf((*MockCache)(nil).StoreSchedule), ctx, schedule)
+}
+
 // StoreTask mocks base method.
 func (m *MockCache) StoreTask(ctx context.Context, taskId string, taskObj *model.TasksObject) error {
 	m.ctrl.T.Helper()
@@ -66,6 +461,49 @@ func (mr *MockCacheMockRecorder) StoreTask(ctx, taskId, taskObj interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreTask", reflect.TypeOf((*MockCache)(nil).StoreTask), ctx, taskId, taskObj)
 }
 
+// StoreTemplate mocks base method.
+func (m *MockCache) StoreTemplate(ctx context.Context, tmpl *model.TaskTemplate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreTemplate", ctx, tmpl)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreTemplate indicates an expected call of StoreTemplate.
+func (mr *MockCacheMockRecorder) StoreTemplate(ctx, tmpl interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreTemplate", reflect.TypeOf((*MockCache)(nil).StoreTemplate), ctx, tmpl)
+}
+
+// StoreWorkflow mocks base method.
+func (m *MockCache) StoreWorkflow(ctx context.Context, workflow *model.Workflow) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreWorkflow", ctx, workflow)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreWorkflow indicates an expected call of StoreWorkflow.
+func (mr *MockCacheMockRecorder) StoreWorkflow(ctx, workflow interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreWorkflow", reflect.TypeOf((*MockCache)(nil).StoreWorkflow), ctx, workflow)
+}
+
+// TakeToken mocks base method.
+func (m *MockCache) TakeToken(ctx context.Context, host string, limit *model.RateLimit, now time.Time) (time.Duration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TakeToken", ctx, host, limit, now)
+	ret0, _ := ret[0].(time.Duration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TakeToken indicates an expected call of TakeToken.
+func (mr *MockCacheMockRecorder) TakeToken(ctx, host, limit, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TakeToken", reflect.TypeOf((*MockCache)(nil).TakeToken), ctx, host, limit, now)
+}
+
 // MockClient is a mock of Client interface.
 type MockClient struct {
 	ctrl     *gomock.Controller
@@ -0,0 +1,71 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/axxonsoft-assignment/pkg/model"
+	"github.com/go-redis/redis/v8"
+)
+
+const oauthTokenPrefix = "oauthtoken:"
+
+// StoreOAuthToken stores the access token into the cache till it expires, so that it is shared by all the instances
+func (c cache) StoreOAuthToken(ctx context.Context, key string, token *model.OAuthToken) error {
+	ttl := time.Until(token.ExpiresAt)
+	if ttl <= 0 {
+		return nil
+	}
+
+	data, err := json.Marshal(token)
+	if err != nil {
+		log.Printf("Error marshalling oauth token object")
+
+		return err
+	}
+
+	err = c.client.Set(ctx, oauthTokenPrefix+key, data, ttl).Err()
+	if err != nil {
+		log.Printf("Error updating cache for oauth token:%s: %v", key, err)
+
+		return err
+	}
+
+	return nil
+}
+
+// GetOAuthToken fetches the access token from the cache, returns an empty object if there is none
+func (c cache) GetOAuthToken(ctx context.Context, key string) (*model.OAuthToken, error) {
+	data, err := c.client.Get(ctx, oauthTokenPrefix+key).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return &model.OAuthToken{}, nil
+		}
+
+		log.Printf("Error in fetching the oauth token:%s from cache: %v", key, err)
+
+		return nil, err
+	}
+
+	token := &model.OAuthToken{}
+	if err = json.Unmarshal([]byte(data), token); err != nil {
+		log.Printf("Error unmarshalling oauth token object")
+
+		return nil, err
+	}
+
+	return token, nil
+}
+
+// DeleteOAuthToken removes the access token from the cache, e.g. once the target has rejected it
+func (c cache) DeleteOAuthToken(ctx context.Context, key string) error {
+	if err := c.client.Del(ctx, oauthTokenPrefix+key).Err(); err != nil {
+		log.Printf("Error deleting oauth token:%s from cache: %v", key, err)
+
+		return err
+	}
+
+	return nil
+}
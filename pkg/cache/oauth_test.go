@@ -0,0 +1,39 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/axxonsoft-assignment/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCache_OAuthTokens(t *testing.T) {
+	c := NewCache(t)
+	ctx := context.Background()
+	token := &model.OAuthToken{AccessToken: "abc", TokenType: "Bearer", ExpiresAt: time.Now().Add(time.Hour).UTC().Round(0)}
+
+	err := c.StoreOAuthToken(ctx, "partner", token)
+	assert.Nil(t, err)
+
+	resp, err := c.GetOAuthToken(ctx, "partner")
+	assert.Nil(t, err)
+	assert.Equal(t, token, resp)
+
+	err = c.DeleteOAuthToken(ctx, "partner")
+	assert.Nil(t, err)
+
+	// Token does not exist
+	resp, err = c.GetOAuthToken(ctx, "partner")
+	assert.Nil(t, err)
+	assert.Equal(t, &model.OAuthToken{}, resp)
+
+	// An expired token is not stored
+	err = c.StoreOAuthToken(ctx, "partner", &model.OAuthToken{AccessToken: "abc", ExpiresAt: time.Now().Add(-time.Minute)})
+	assert.Nil(t, err)
+
+	resp, err = c.GetOAuthToken(ctx, "partner")
+	assert.Nil(t, err)
+	assert.Equal(t, &model.OAuthToken{}, resp)
+}
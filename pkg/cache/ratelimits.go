@@ -0,0 +1,149 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/axxonsoft-assignment/pkg/model"
+	"github.com/go-redis/redis/v8"
+)
+
+const (
+	rateLimitsKey     = "ratelimits"
+	rateLimitPrefix   = "ratelimit:"
+	tokenBucketPrefix = "tokenbucket:"
+)
+
+// takeTokenScript refills the host's bucket for the time elapsed since the last call and takes a token out of it.
+// It returns 0 if a token was taken, otherwise the milliseconds to wait for the next token.
+var takeTokenScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+
+local bucket = redis.call("HMGET", KEYS[1], "tokens", "ts")
+local tokens = tonumber(bucket[1]) or burst
+local ts = tonumber(bucket[2]) or now
+
+tokens = math.min(burst, tokens + math.max(0, now - ts) / 1000 * rate)
+
+local wait = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+else
+	wait = math.ceil((1 - tokens) / rate * 1000)
+end
+
+redis.call("HSET", KEYS[1], "tokens", tostring(tokens), "ts", tostring(now))
+redis.call("PEXPIRE", KEYS[1], math.ceil(burst / rate * 1000) + 1000)
+
+return wait
+`)
+
+// StoreRateLimit stores the rate limit of a host into the cache and indexes the host for listing
+func (c cache) StoreRateLimit(ctx context.Context, limit *model.RateLimit) error {
+	data, err := json.Marshal(limit)
+	if err != nil {
+		log.Printf("Error marshalling rate limit object")
+
+		return err
+	}
+
+	_, err = c.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, rateLimitPrefix+limit.Host, data, 0)
+		pipe.SAdd(ctx, rateLimitsKey, limit.Host)
+
+		return nil
+	})
+	if err != nil {
+		log.Printf("Error updating cache for rate limit of host:%s: %v", limit.Host, err)
+
+		return err
+	}
+
+	return nil
+}
+
+// GetRateLimit fetches the rate limit of a host from the cache, returns an empty object if the host has none
+func (c cache) GetRateLimit(ctx context.Context, host string) (*model.RateLimit, error) {
+	data, err := c.client.Get(ctx, rateLimitPrefix+host).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return &model.RateLimit{}, nil
+		}
+
+		log.Printf("Error in fetching the rate limit of host:%s from cache: %v", host, err)
+
+		return nil, err
+	}
+
+	limit := &model.RateLimit{}
+	err = json.Unmarshal([]byte(data), limit)
+	if err != nil {
+		log.Printf("Error unmarshalling rate limit object")
+
+		return nil, err
+	}
+
+	return limit, nil
+}
+
+// ListRateLimits fetches the rate limits of all the hosts present in the cache
+func (c cache) ListRateLimits(ctx context.Context) ([]*model.RateLimit, error) {
+	hosts, err := c.client.SMembers(ctx, rateLimitsKey).Result()
+	if err != nil {
+		log.Printf("Error in fetching the rate limits from cache: %v", err)
+
+		return nil, err
+	}
+
+	limits := make([]*model.RateLimit, 0, len(hosts))
+
+	for _, host := range hosts {
+		limit, err := c.GetRateLimit(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+
+		if limit.Host == "" {
+			continue
+		}
+
+		limits = append(limits, limit)
+	}
+
+	return limits, nil
+}
+
+// DeleteRateLimit removes the rate limit of a host along with its token bucket
+func (c cache) DeleteRateLimit(ctx context.Context, host string) error {
+	_, err := c.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, rateLimitPrefix+host, tokenBucketPrefix+host)
+		pipe.SRem(ctx, rateLimitsKey, host)
+
+		return nil
+	})
+	if err != nil {
+		log.Printf("Error deleting rate limit of host:%s from cache: %v", host, err)
+
+		return err
+	}
+
+	return nil
+}
+
+// TakeToken takes a token from the host's bucket shared by all the instances, the bucket is sized as per the given limit.
+// It returns 0 if the call can be made right away, otherwise the duration to wait before trying again.
+func (c cache) TakeToken(ctx context.Context, host string, limit *model.RateLimit, now time.Time) (time.Duration, error) {
+	wait, err := takeTokenScript.Run(ctx, c.client, []string{tokenBucketPrefix + host},
+		limit.Rate, limit.Burst, now.UnixMilli()).Int64()
+	if err != nil {
+		log.Printf("Error taking token for host:%s: %v", host, err)
+
+		return 0, err
+	}
+
+	return time.Duration(wait) * time.Millisecond, nil
+}
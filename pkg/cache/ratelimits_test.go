@@ -0,0 +1,67 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/axxonsoft-assignment/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCache_RateLimits(t *testing.T) {
+	c := NewCache(t)
+	ctx := context.Background()
+	limit := &model.RateLimit{Host: "api.partner.com", Rate: 5, Burst: 10}
+
+	err := c.StoreRateLimit(ctx, limit)
+	assert.Nil(t, err)
+
+	resp, err := c.GetRateLimit(ctx, "api.partner.com")
+	assert.Nil(t, err)
+	assert.Equal(t, limit, resp)
+
+	limits, err := c.ListRateLimits(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, []*model.RateLimit{limit}, limits)
+
+	err = c.DeleteRateLimit(ctx, "api.partner.com")
+	assert.Nil(t, err)
+
+	// Rate limit does not exist
+	resp, err = c.GetRateLimit(ctx, "api.partner.com")
+	assert.Nil(t, err)
+	assert.Equal(t, &model.RateLimit{}, resp)
+}
+
+func TestCache_TakeToken(t *testing.T) {
+	c := NewCache(t)
+	ctx := context.Background()
+	limit := &model.RateLimit{Host: "api.partner.com", Rate: 2, Burst: 2}
+	now := time.Date(2023, 11, 20, 10, 30, 0, 0, time.UTC)
+
+	// the bucket starts full
+	for i := 0; i < limit.Burst; i++ {
+		wait, err := c.TakeToken(ctx, "api.partner.com", limit, now)
+		assert.Nil(t, err)
+		assert.Equal(t, time.Duration(0), wait)
+	}
+
+	// the bucket is empty, a token is refilled every 500ms
+	wait, err := c.TakeToken(ctx, "api.partner.com", limit, now)
+	assert.Nil(t, err)
+	assert.Equal(t, 500*time.Millisecond, wait)
+
+	wait, err = c.TakeToken(ctx, "api.partner.com", limit, now.Add(250*time.Millisecond))
+	assert.Nil(t, err)
+	assert.Equal(t, 250*time.Millisecond, wait)
+
+	wait, err = c.TakeToken(ctx, "api.partner.com", limit, now.Add(500*time.Millisecond))
+	assert.Nil(t, err)
+	assert.Equal(t, time.Duration(0), wait)
+
+	// the buckets are per host
+	wait, err = c.TakeToken(ctx, "other.partner.com", limit, now)
+	assert.Nil(t, err)
+	assert.Equal(t, time.Duration(0), wait)
+}
@@ -0,0 +1,180 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/axxonsoft-assignment/pkg/model"
+	"github.com/go-redis/redis/v8"
+)
+
+const (
+	schedulesKey      = "schedules"
+	schedulePrefix    = "schedule:"
+	maxScheduleRuns   = 100
+	scheduleClaimTTL  = 24 * time.Hour
+	scheduleRunsInfix = ":runs"
+)
+
+// StoreSchedule stores the schedule details into the cache and indexes its ID for listing
+func (c cache) StoreSchedule(ctx context.Context, schedule *model.Schedule) error {
+	data, err := json.Marshal(schedule)
+	if err != nil {
+		log.Printf("Error marshalling schedule object")
+
+		return err
+	}
+
+	_, err = c.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, schedulePrefix+schedule.ID, data, 0)
+		pipe.SAdd(ctx, schedulesKey, schedule.ID)
+
+		return nil
+	})
+	if err != nil {
+		log.Printf("Error updating cache for schedule:%s: %v", schedule.ID, err)
+
+		return err
+	}
+
+	return nil
+}
+
+// GetSchedule fetches the schedule details from redis cache using the scheduleID.
+func (c cache) GetSchedule(ctx context.Context, scheduleID string) (*model.Schedule, error) {
+	data, err := c.client.Get(ctx, schedulePrefix+scheduleID).Result()
+	if err != nil {
+		// If scheduleID is not present, return an empty object
+		if err == redis.Nil {
+			return &model.Schedule{}, nil
+		}
+
+		log.Printf("Error in fetching the schedule:%s details from cache: %v", scheduleID, err)
+
+		return nil, err
+	}
+
+	schedule := &model.Schedule{}
+	err = json.Unmarshal([]byte(data), schedule)
+	if err != nil {
+		log.Printf("Error unmarshalling schedule object")
+
+		return nil, err
+	}
+
+	return schedule, nil
+}
+
+// ListSchedules fetches all the schedules present in the cache
+func (c cache) ListSchedules(ctx context.Context) ([]*model.Schedule, error) {
+	scheduleIDs, err := c.client.SMembers(ctx, schedulesKey).Result()
+	if err != nil {
+		log.Printf("Error in fetching the schedules from cache: %v", err)
+
+		return nil, err
+	}
+
+	schedules := make([]*model.Schedule, 0, len(scheduleIDs))
+
+	for _, scheduleID := range scheduleIDs {
+		schedule, err := c.GetSchedule(ctx, scheduleID)
+		if err != nil {
+			return nil, err
+		}
+
+		// skip the IDs whose schedule got deleted in between
+		if schedule.ID == "" {
+			continue
+		}
+
+		schedules = append(schedules, schedule)
+	}
+
+	return schedules, nil
+}
+
+// DeleteSchedule removes the schedule along with its run history from the cache
+func (c cache) DeleteSchedule(ctx context.Context, scheduleID string) error {
+	_, err := c.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, schedulePrefix+scheduleID, schedulePrefix+scheduleID+scheduleRunsInfix)
+		pipe.SRem(ctx, schedulesKey, scheduleID)
+
+		return nil
+	})
+	if err != nil {
+		log.Printf("Error deleting schedule:%s from cache: %v", scheduleID, err)
+
+		return err
+	}
+
+	return nil
+}
+
+// ClaimScheduleRun marks a firing of the schedule as taken, so that only one instance creates a task for it.
+// It returns false if the firing was already claimed.
+func (c cache) ClaimScheduleRun(ctx context.Context, scheduleID string, scheduledAt time.Time) (bool, error) {
+	key := schedulePrefix + scheduleID + ":claim:" + strconv.FormatInt(scheduledAt.Unix(), 10)
+
+	claimed, err := c.client.SetNX(ctx, key, 1, scheduleClaimTTL).Result()
+	if err != nil {
+		log.Printf("Error claiming run of schedule:%s: %v", scheduleID, err)
+
+		return false, err
+	}
+
+	return claimed, nil
+}
+
+// AddScheduleRun records a firing of the schedule, only the latest 100 runs are retained
+func (c cache) AddScheduleRun(ctx context.Context, scheduleID string, run *model.ScheduleRun) error {
+	data, err := json.Marshal(run)
+	if err != nil {
+		log.Printf("Error marshalling schedule run object")
+
+		return err
+	}
+
+	key := schedulePrefix + scheduleID + scheduleRunsInfix
+
+	_, err = c.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.LPush(ctx, key, data)
+		pipe.LTrim(ctx, key, 0, maxScheduleRuns-1)
+
+		return nil
+	})
+	if err != nil {
+		log.Printf("Error updating run history for schedule:%s: %v", scheduleID, err)
+
+		return err
+	}
+
+	return nil
+}
+
+// GetScheduleRuns fetches the run history of the schedule, latest first
+func (c cache) GetScheduleRuns(ctx context.Context, scheduleID string) ([]*model.ScheduleRun, error) {
+	data, err := c.client.LRange(ctx, schedulePrefix+scheduleID+scheduleRunsInfix, 0, -1).Result()
+	if err != nil {
+		log.Printf("Error in fetching the run history of schedule:%s from cache: %v", scheduleID, err)
+
+		return nil, err
+	}
+
+	runs := make([]*model.ScheduleRun, 0, len(data))
+
+	for _, item := range data {
+		run := &model.ScheduleRun{}
+		if err = json.Unmarshal([]byte(item), run); err != nil {
+			log.Printf("Error unmarshalling schedule run object")
+
+			return nil, err
+		}
+
+		runs = append(runs, run)
+	}
+
+	return runs, nil
+}
@@ -0,0 +1,68 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/axxonsoft-assignment/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCache_Schedules(t *testing.T) {
+	c := NewCache(t)
+	ctx := context.Background()
+	schedule := &model.Schedule{
+		ID:   "5463",
+		Cron: "*/5 * * * *",
+		Task: model.Task{Method: "GET", URL: "https://www.getyourtasks.com/health"},
+	}
+
+	err := c.StoreSchedule(ctx, schedule)
+	assert.Nil(t, err)
+
+	resp, err := c.GetSchedule(ctx, "5463")
+	assert.Nil(t, err)
+	assert.Equal(t, schedule, resp)
+
+	schedules, err := c.ListSchedules(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, []*model.Schedule{schedule}, schedules)
+
+	// Schedule does not exist
+	resp, err = c.GetSchedule(ctx, "224321")
+	assert.Nil(t, err)
+	assert.Equal(t, &model.Schedule{}, resp)
+
+	err = c.DeleteSchedule(ctx, "5463")
+	assert.Nil(t, err)
+
+	schedules, err = c.ListSchedules(ctx)
+	assert.Nil(t, err)
+	assert.Empty(t, schedules)
+}
+
+func TestCache_ScheduleRuns(t *testing.T) {
+	c := NewCache(t)
+	ctx := context.Background()
+	scheduledAt := time.Date(2023, 11, 20, 10, 30, 0, 0, time.UTC)
+
+	claimed, err := c.ClaimScheduleRun(ctx, "5463", scheduledAt)
+	assert.Nil(t, err)
+	assert.True(t, claimed)
+
+	// the same firing cannot be claimed twice
+	claimed, err = c.ClaimScheduleRun(ctx, "5463", scheduledAt)
+	assert.Nil(t, err)
+	assert.False(t, claimed)
+
+	first := &model.ScheduleRun{TaskID: "1", ScheduledAt: scheduledAt, CreatedAt: scheduledAt}
+	second := &model.ScheduleRun{TaskID: "2", ScheduledAt: scheduledAt.Add(time.Minute), CreatedAt: scheduledAt.Add(time.Minute)}
+
+	assert.Nil(t, c.AddScheduleRun(ctx, "5463", first))
+	assert.Nil(t, c.AddScheduleRun(ctx, "5463", second))
+
+	runs, err := c.GetScheduleRuns(ctx, "5463")
+	assert.Nil(t, err)
+	assert.Equal(t, []*model.ScheduleRun{second, first}, runs)
+}
@@ -0,0 +1,119 @@
+package cache
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+const (
+	semaphorePrefix = "semaphore:"
+
+	// waiters not seen for this long are considered gone and lose their place in the queue
+	semaphoreWaiterTTL = 30 * time.Second
+)
+
+// acquireSlotScript takes a slot of the host's semaphore for the holder, in the order the holders first asked for one.
+// KEYS: holders (holder -> lease expiry), queue (waiter -> ticket), seen (waiter -> last attempt), ticket counter
+// It returns 1 if the holder has the slot, 0 if it has to wait.
+var acquireSlotScript = redis.NewScript(`
+local id = ARGV[1]
+local limit = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+local lease = tonumber(ARGV[4])
+local waiterTTL = tonumber(ARGV[5])
+
+redis.call("ZREMRANGEBYSCORE", KEYS[1], "-inf", now)
+
+local gone = redis.call("ZRANGEBYSCORE", KEYS[3], "-inf", now - waiterTTL)
+for _, waiter in ipairs(gone) do
+	redis.call("ZREM", KEYS[2], waiter)
+	redis.call("ZREM", KEYS[3], waiter)
+end
+
+-- a holder asking again keeps its slot
+if redis.call("ZSCORE", KEYS[1], id) then
+	redis.call("ZADD", KEYS[1], now + lease, id)
+	return 1
+end
+
+if not redis.call("ZSCORE", KEYS[2], id) then
+	redis.call("ZADD", KEYS[2], redis.call("INCR", KEYS[4]), id)
+end
+redis.call("ZADD", KEYS[3], now, id)
+
+local free = limit - redis.call("ZCARD", KEYS[1])
+if free > 0 and redis.call("ZRANK", KEYS[2], id) < free then
+	redis.call("ZREM", KEYS[2], id)
+	redis.call("ZREM", KEYS[3], id)
+	redis.call("ZADD", KEYS[1], now + lease, id)
+	return 1
+end
+
+return 0
+`)
+
+// renewSlotScript extends the holder's lease, if the holder still has its slot.
+// KEYS: holders (holder -> lease expiry)
+// It returns 1 if the lease was renewed, 0 if the slot was lost.
+var renewSlotScript = redis.NewScript(`
+local id = ARGV[1]
+local now = tonumber(ARGV[2])
+local lease = tonumber(ARGV[3])
+
+redis.call("ZREMRANGEBYSCORE", KEYS[1], "-inf", now)
+
+if not redis.call("ZSCORE", KEYS[1], id) then
+	return 0
+end
+
+redis.call("ZADD", KEYS[1], "XX", now + lease, id)
+
+return 1
+`)
+
+// AcquireSlot takes one of the limited slots of the host's semaphore shared by all the instances, waiters get the
+// slots in FIFO order. It returns false if the holder has to try again later.
+func (c cache) AcquireSlot(ctx context.Context, host string, holderID string, limit int, lease time.Duration,
+	now time.Time) (bool, error) {
+	key := semaphorePrefix + host
+	keys := []string{key + ":holders", key + ":queue", key + ":seen", key + ":ticket"}
+
+	acquired, err := acquireSlotScript.Run(ctx, c.client, keys, holderID, limit, now.UnixMilli(),
+		lease.Milliseconds(), semaphoreWaiterTTL.Milliseconds()).Int()
+	if err != nil {
+		log.Printf("Error acquiring slot for host:%s: %v", host, err)
+
+		return false, err
+	}
+
+	return acquired == 1, nil
+}
+
+// RenewSlot extends the lease of the holder's slot of the host's semaphore. It returns false if the lease expired and
+// the slot was lost, in which case it is not taken again.
+func (c cache) RenewSlot(ctx context.Context, host string, holderID string, lease time.Duration, now time.Time) (bool, error) {
+	renewed, err := renewSlotScript.Run(ctx, c.client, []string{semaphorePrefix + host + ":holders"}, holderID,
+		now.UnixMilli(), lease.Milliseconds()).Int()
+	if err != nil {
+		log.Printf("Error renewing slot for host:%s: %v", host, err)
+
+		return false, err
+	}
+
+	return renewed == 1, nil
+}
+
+// ReleaseSlot gives the holder's slot of the host's semaphore back
+func (c cache) ReleaseSlot(ctx context.Context, host string, holderID string) error {
+	err := c.client.ZRem(ctx, semaphorePrefix+host+":holders", holderID).Err()
+	if err != nil {
+		log.Printf("Error releasing slot for host:%s: %v", host, err)
+
+		return err
+	}
+
+	return nil
+}
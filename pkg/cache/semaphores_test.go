@@ -0,0 +1,94 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCache_AcquireSlot(t *testing.T) {
+	c := NewCache(t)
+	ctx := context.Background()
+	now := time.Date(2023, 11, 20, 10, 30, 0, 0, time.UTC)
+
+	acquire := func(holderID string, at time.Time) bool {
+		acquired, err := c.AcquireSlot(ctx, "api.partner.com", holderID, 2, time.Minute, at)
+		assert.Nil(t, err)
+
+		return acquired
+	}
+
+	assert.True(t, acquire("1", now))
+	assert.True(t, acquire("2", now))
+
+	// the slots are taken, the waiters are queued in the order they asked
+	assert.False(t, acquire("3", now))
+	assert.False(t, acquire("4", now))
+
+	assert.Nil(t, c.ReleaseSlot(ctx, "api.partner.com", "1"))
+
+	// the freed slot goes to the first waiter, even if a later one asks first
+	assert.False(t, acquire("4", now))
+	assert.True(t, acquire("3", now))
+
+	// other hosts have their own slots
+	acquired, err := c.AcquireSlot(ctx, "other.partner.com", "4", 2, time.Minute, now)
+	assert.Nil(t, err)
+	assert.True(t, acquired)
+
+	// a holder renews its lease, the others expire and leave their slots to the waiters
+	renewed, err := c.RenewSlot(ctx, "api.partner.com", "2", time.Minute, now.Add(30*time.Second))
+	assert.Nil(t, err)
+	assert.True(t, renewed)
+	assert.False(t, acquire("4", now.Add(30*time.Second)))
+	assert.True(t, acquire("4", now.Add(time.Minute)))
+	assert.True(t, acquire("2", now.Add(time.Minute)))
+}
+
+func TestCache_AcquireSlot_GoneWaiter(t *testing.T) {
+	c := NewCache(t)
+	ctx := context.Background()
+	now := time.Date(2023, 11, 20, 10, 30, 0, 0, time.UTC)
+
+	acquired, _ := c.AcquireSlot(ctx, "api.partner.com", "1", 1, time.Hour, now)
+	assert.True(t, acquired)
+
+	acquired, _ = c.AcquireSlot(ctx, "api.partner.com", "2", 1, time.Hour, now)
+	assert.False(t, acquired)
+
+	acquired, _ = c.AcquireSlot(ctx, "api.partner.com", "3", 1, time.Hour, now.Add(10*time.Second))
+	assert.False(t, acquired)
+
+	assert.Nil(t, c.ReleaseSlot(ctx, "api.partner.com", "1"))
+
+	// waiter 2 stopped asking, so it loses its place to waiter 3
+	acquired, _ = c.AcquireSlot(ctx, "api.partner.com", "3", 1, time.Hour, now.Add(35*time.Second))
+	assert.True(t, acquired)
+}
+
+func TestCache_RenewSlot(t *testing.T) {
+	c := NewCache(t)
+	ctx := context.Background()
+	now := time.Date(2023, 11, 20, 10, 30, 0, 0, time.UTC)
+
+	acquired, _ := c.AcquireSlot(ctx, "api.partner.com", "1", 1, time.Minute, now)
+	assert.True(t, acquired)
+
+	// a holder that never had a slot does not get one by renewing
+	renewed, err := c.RenewSlot(ctx, "api.partner.com", "2", time.Minute, now)
+	assert.Nil(t, err)
+	assert.False(t, renewed)
+
+	// once the lease expired the slot is lost, and is not taken again over the waiters
+	acquired, _ = c.AcquireSlot(ctx, "api.partner.com", "2", 1, time.Minute, now.Add(time.Minute))
+	assert.True(t, acquired)
+
+	renewed, err = c.RenewSlot(ctx, "api.partner.com", "1", time.Minute, now.Add(time.Minute))
+	assert.Nil(t, err)
+	assert.False(t, renewed)
+
+	acquired, _ = c.AcquireSlot(ctx, "api.partner.com", "3", 1, time.Minute, now.Add(time.Minute))
+	assert.False(t, acquired)
+}
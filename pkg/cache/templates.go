@@ -0,0 +1,107 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+
+	"github.com/axxonsoft-assignment/pkg/model"
+	"github.com/go-redis/redis/v8"
+)
+
+const (
+	templatesKey   = "templates"
+	templatePrefix = "template:"
+)
+
+// StoreTemplate stores the task template into the cache and indexes its name for listing
+func (c cache) StoreTemplate(ctx context.Context, tmpl *model.TaskTemplate) error {
+	data, err := json.Marshal(tmpl)
+	if err != nil {
+		log.Printf("Error marshalling template object")
+
+		return err
+	}
+
+	_, err = c.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, templatePrefix+tmpl.Name, data, 0)
+		pipe.SAdd(ctx, templatesKey, tmpl.Name)
+
+		return nil
+	})
+	if err != nil {
+		log.Printf("Error updating cache for template:%s: %v", tmpl.Name, err)
+
+		return err
+	}
+
+	return nil
+}
+
+// GetTemplate fetches the task template from the cache using its name, returns an empty object if not found
+func (c cache) GetTemplate(ctx context.Context, name string) (*model.TaskTemplate, error) {
+	data, err := c.client.Get(ctx, templatePrefix+name).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return &model.TaskTemplate{}, nil
+		}
+
+		log.Printf("Error in fetching the template:%s from cache: %v", name, err)
+
+		return nil, err
+	}
+
+	tmpl := &model.TaskTemplate{}
+	if err = json.Unmarshal([]byte(data), tmpl); err != nil {
+		log.Printf("Error unmarshalling template object")
+
+		return nil, err
+	}
+
+	return tmpl, nil
+}
+
+// ListTemplates fetches all the task templates present in the cache
+func (c cache) ListTemplates(ctx context.Context) ([]*model.TaskTemplate, error) {
+	names, err := c.client.SMembers(ctx, templatesKey).Result()
+	if err != nil {
+		log.Printf("Error in fetching the templates from cache: %v", err)
+
+		return nil, err
+	}
+
+	templates := make([]*model.TaskTemplate, 0, len(names))
+
+	for _, name := range names {
+		tmpl, err := c.GetTemplate(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+
+		// skip the names whose template got deleted in between
+		if tmpl.Name == "" {
+			continue
+		}
+
+		templates = append(templates, tmpl)
+	}
+
+	return templates, nil
+}
+
+// DeleteTemplate removes the task template from the cache
+func (c cache) DeleteTemplate(ctx context.Context, name string) error {
+	_, err := c.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, templatePrefix+name)
+		pipe.SRem(ctx, templatesKey, name)
+
+		return nil
+	})
+	if err != nil {
+		log.Printf("Error deleting template:%s from cache: %v", name, err)
+
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,34 @@
+package cache
+
+import (
+	"context"
+	"testing"
+
+	"github.com/axxonsoft-assignment/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCache_Templates(t *testing.T) {
+	c := NewCache(t)
+	ctx := context.Background()
+	tmpl := &model.TaskTemplate{Name: "health", Task: model.Task{Method: "GET", URL: "https://{{.region}}.partner.com/health"}}
+
+	err := c.StoreTemplate(ctx, tmpl)
+	assert.Nil(t, err)
+
+	resp, err := c.GetTemplate(ctx, "health")
+	assert.Nil(t, err)
+	assert.Equal(t, tmpl, resp)
+
+	templates, err := c.ListTemplates(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, []*model.TaskTemplate{tmpl}, templates)
+
+	err = c.DeleteTemplate(ctx, "health")
+	assert.Nil(t, err)
+
+	// Template does not exist
+	resp, err = c.GetTemplate(ctx, "health")
+	assert.Nil(t, err)
+	assert.Equal(t, &model.TaskTemplate{}, resp)
+}
@@ -0,0 +1,146 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/axxonsoft-assignment/pkg/model"
+	"github.com/go-redis/redis/v8"
+)
+
+const (
+	activeWorkflowsKey = "workflows:active"
+	workflowPrefix     = "workflow:"
+	workflowLeaseInfix = ":lease"
+)
+
+// claimWorkflowScript gives the lease of the workflow to the holder if nobody holds it, or renews it if the holder
+// already does. KEYS: lease. It returns 1 if the holder has the lease, 0 otherwise.
+var claimWorkflowScript = redis.NewScript(`
+local holder = redis.call("GET", KEYS[1])
+if holder and holder ~= ARGV[1] then
+	return 0
+end
+
+redis.call("SET", KEYS[1], ARGV[1], "PX", ARGV[2])
+return 1
+`)
+
+// releaseWorkflowScript drops the lease of the workflow if the holder still has it. KEYS: lease.
+var releaseWorkflowScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// StoreWorkflow stores the workflow details into the cache with a TTL of 1 week, like its tasks. The workflow
+// is indexed as active till it reaches a terminal status.
+func (c cache) StoreWorkflow(ctx context.Context, workflow *model.Workflow) error {
+	data, err := json.Marshal(workflow)
+	if err != nil {
+		log.Printf("Error marshalling workflow object")
+
+		return err
+	}
+
+	_, err = c.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, workflowPrefix+workflow.ID, data, 7*24*time.Hour)
+
+		if model.Terminal(workflow.Status) {
+			pipe.SRem(ctx, activeWorkflowsKey, workflow.ID)
+		} else {
+			pipe.SAdd(ctx, activeWorkflowsKey, workflow.ID)
+		}
+
+		return nil
+	})
+	if err != nil {
+		log.Printf("Error updating cache for workflow:%s: %v", workflow.ID, err)
+
+		return err
+	}
+
+	return nil
+}
+
+// GetWorkflow fetches the workflow details from the cache using the workflowID, returns an empty object if not found
+func (c cache) GetWorkflow(ctx context.Context, workflowID string) (*model.Workflow, error) {
+	data, err := c.client.Get(ctx, workflowPrefix+workflowID).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return &model.Workflow{}, nil
+		}
+
+		log.Printf("Error in fetching the workflow:%s details from cache: %v", workflowID, err)
+
+		return nil, err
+	}
+
+	workflow := &model.Workflow{}
+	if err = json.Unmarshal([]byte(data), workflow); err != nil {
+		log.Printf("Error unmarshalling workflow object")
+
+		return nil, err
+	}
+
+	return workflow, nil
+}
+
+// ListActiveWorkflows fetches the workflows which did not reach a terminal status yet
+func (c cache) ListActiveWorkflows(ctx context.Context) ([]*model.Workflow, error) {
+	workflowIDs, err := c.client.SMembers(ctx, activeWorkflowsKey).Result()
+	if err != nil {
+		log.Printf("Error in fetching the active workflows from cache: %v", err)
+
+		return nil, err
+	}
+
+	workflows := make([]*model.Workflow, 0, len(workflowIDs))
+
+	for _, workflowID := range workflowIDs {
+		workflow, err := c.GetWorkflow(ctx, workflowID)
+		if err != nil {
+			return nil, err
+		}
+
+		// the workflow expired, drop it from the index
+		if workflow.ID == "" {
+			c.client.SRem(ctx, activeWorkflowsKey, workflowID)
+
+			continue
+		}
+
+		workflows = append(workflows, workflow)
+	}
+
+	return workflows, nil
+}
+
+// ClaimWorkflow gives the workflow's lease to the holder for the lease duration, so that a single instance runs it.
+// It returns false if another holder has it, calling it again as the holder renews the lease.
+func (c cache) ClaimWorkflow(ctx context.Context, workflowID string, holderID string, lease time.Duration) (bool, error) {
+	claimed, err := claimWorkflowScript.Run(ctx, c.client, []string{workflowPrefix + workflowID + workflowLeaseInfix},
+		holderID, lease.Milliseconds()).Int()
+	if err != nil {
+		log.Printf("Error claiming workflow:%s: %v", workflowID, err)
+
+		return false, err
+	}
+
+	return claimed == 1, nil
+}
+
+// ReleaseWorkflow gives the workflow's lease back if the holder still has it
+func (c cache) ReleaseWorkflow(ctx context.Context, workflowID string, holderID string) error {
+	err := releaseWorkflowScript.Run(ctx, c.client, []string{workflowPrefix + workflowID + workflowLeaseInfix}, holderID).Err()
+	if err != nil {
+		log.Printf("Error releasing workflow:%s: %v", workflowID, err)
+
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/axxonsoft-assignment/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCache_Workflows(t *testing.T) {
+	c := NewCache(t)
+	ctx := context.Background()
+	workflow := &model.Workflow{ID: "workflow-1", Status: model.InProcess, Nodes: []model.WorkflowNode{
+		{ID: "login", Task: model.Task{Method: "GET", URL: "https://www.getyourtasks.com/login"}, Status: model.Done, TaskID: "task-1"},
+	}}
+
+	err := c.StoreWorkflow(ctx, workflow)
+	assert.Nil(t, err)
+
+	resp, err := c.GetWorkflow(ctx, "workflow-1")
+	assert.Nil(t, err)
+	assert.Equal(t, workflow, resp)
+
+	active, err := c.ListActiveWorkflows(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, []*model.Workflow{workflow}, active)
+
+	// a finished workflow is not active anymore
+	workflow.Status = model.Done
+	assert.Nil(t, c.StoreWorkflow(ctx, workflow))
+
+	active, err = c.ListActiveWorkflows(ctx)
+	assert.Nil(t, err)
+	assert.Empty(t, active)
+
+	// Workflow does not exist
+	resp, err = c.GetWorkflow(ctx, "workflow-2")
+	assert.Nil(t, err)
+	assert.Equal(t, &model.Workflow{}, resp)
+}
+
+func TestCache_ClaimWorkflow(t *testing.T) {
+	c := NewCache(t)
+	ctx := context.Background()
+
+	claimed, err := c.ClaimWorkflow(ctx, "workflow-1", "holder-1", time.Minute)
+	assert.Nil(t, err)
+	assert.True(t, claimed)
+
+	// the holder renews its lease, another holder cannot take it
+	claimed, _ = c.ClaimWorkflow(ctx, "workflow-1", "holder-1", time.Minute)
+	assert.True(t, claimed)
+
+	claimed, _ = c.ClaimWorkflow(ctx, "workflow-1", "holder-2", time.Minute)
+	assert.False(t, claimed)
+
+	// only the holder releases its lease
+	assert.Nil(t, c.ReleaseWorkflow(ctx, "workflow-1", "holder-2"))
+
+	claimed, _ = c.ClaimWorkflow(ctx, "workflow-1", "holder-2", time.Minute)
+	assert.False(t, claimed)
+
+	assert.Nil(t, c.ReleaseWorkflow(ctx, "workflow-1", "holder-1"))
+
+	claimed, _ = c.ClaimWorkflow(ctx, "workflow-1", "holder-2", time.Minute)
+	assert.True(t, claimed)
+}
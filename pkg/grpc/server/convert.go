@@ -0,0 +1,89 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/axxonsoft-assignment/pkg/grpc/taskspb"
+	"github.com/axxonsoft-assignment/pkg/model"
+	"github.com/axxonsoft-assignment/pkg/service"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// The messages mirror the JSON encoding of the model types, the fields of a message being named after the JSON
+// properties, so they are converted through JSON. The fields of the model types unknown to the messages are dropped.
+
+// toTask converts the task message to the model task
+func toTask(msg *taskspb.Task) (*model.Task, error) {
+	data, err := protojson.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	taskData := &model.Task{}
+
+	if err := json.Unmarshal(data, taskData); err != nil {
+		return nil, err
+	}
+
+	return taskData, nil
+}
+
+// toTaskObject converts the model task object to its message, failing with an Internal status
+func toTaskObject(taskObj *model.TasksObject) (*taskspb.TaskObject, error) {
+	data, err := json.Marshal(taskObj)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "Error marshalling task object")
+	}
+
+	msg := &taskspb.TaskObject{}
+
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(data, msg); err != nil {
+		return nil, status.Error(codes.Internal, "Error converting task object: "+err.Error())
+	}
+
+	return msg, nil
+}
+
+// statusError maps the kind of the error of the services to a status code, the violations of an invalid request
+// being given as the field violations of a BadRequest detail, their field being the JSON pointer in the task
+func statusError(err error) error {
+	code := codes.Internal
+
+	switch service.KindOf(err) {
+	case service.KindValidation:
+		code = codes.InvalidArgument
+	case service.KindNotFound:
+		code = codes.NotFound
+	case service.KindConflict:
+		code = codes.FailedPrecondition
+	case service.KindUnavailable:
+		code = codes.Unavailable
+	}
+
+	st := status.New(code, err.Error())
+
+	var validationErr *model.ValidationError
+	if !errors.As(err, &validationErr) {
+		return st.Err()
+	}
+
+	badRequest := &errdetails.BadRequest{}
+
+	for _, violation := range validationErr.Violations {
+		badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       violation.Pointer,
+			Description: violation.Message,
+		})
+	}
+
+	// the details only fail on a message that cannot be marshalled, the status is given without them then
+	if withDetails, err := st.WithDetails(badRequest); err == nil {
+		return withDetails.Err()
+	}
+
+	return st.Err()
+}
@@ -0,0 +1,154 @@
+package server
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/axxonsoft-assignment/pkg/grpc/taskspb"
+	"github.com/axxonsoft-assignment/pkg/model"
+	"github.com/axxonsoft-assignment/pkg/service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// defaultPageSize is the number of tasks listed when the page size is not given
+	defaultPageSize = 20
+	// defaultWatchInterval is how often a watched task is fetched when the interval is not given
+	defaultWatchInterval = time.Second
+)
+
+// Server implements the Tasks gRPC service on top of the tasks service, sharing its validation and storage with the
+// REST handlers
+type Server struct {
+	taskspb.UnimplementedTasksServer
+
+	tasksService  service.Tasks
+	watchInterval time.Duration
+}
+
+// New gives the gRPC server of the tasks, a watched task being fetched every watchInterval, defaults to 1 second
+func New(tasksService service.Tasks, watchInterval time.Duration) *Server {
+	if watchInterval <= 0 {
+		watchInterval = defaultWatchInterval
+	}
+
+	return &Server{tasksService: tasksService, watchInterval: watchInterval}
+}
+
+// CreateTask validates the task and runs it in the background, giving its taskID
+func (s *Server) CreateTask(_ context.Context, req *taskspb.CreateTaskRequest) (*taskspb.CreateTaskResponse, error) {
+	taskData, err := toTask(req.GetTask())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "Error in converting the task: "+err.Error())
+	}
+
+	// the task outlives the call, as for the REST handler it is not bound to the context of the request
+	resp, err := s.tasksService.TasksCreate(context.Background(), *taskData)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return &taskspb.CreateTaskResponse{Id: resp.ID}, nil
+}
+
+// GetTask gives the status and the result of the task
+func (s *Server) GetTask(ctx context.Context, req *taskspb.GetTaskRequest) (*taskspb.TaskObject, error) {
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Missing value for the field: id")
+	}
+
+	taskObj, err := s.tasksService.TasksGet(ctx, req.GetId())
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return toTaskObject(taskObj)
+}
+
+// ListTasks gives a page of the tasks, the newest first, the page token being the offset of the page
+func (s *Server) ListTasks(ctx context.Context, req *taskspb.ListTasksRequest) (*taskspb.ListTasksResponse, error) {
+	pageSize := int(req.GetPageSize())
+	if pageSize == 0 {
+		pageSize = defaultPageSize
+	}
+
+	offset := 0
+
+	if req.GetPageToken() != "" {
+		var err error
+
+		offset, err = strconv.Atoi(req.GetPageToken())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "Invalid request: invalid page_token")
+		}
+	}
+
+	tasks, next, err := s.tasksService.TasksList(ctx, offset, pageSize)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	resp := &taskspb.ListTasksResponse{Tasks: make([]*taskspb.TaskObject, 0, len(tasks))}
+
+	for _, taskObj := range tasks {
+		msg, err := toTaskObject(taskObj)
+		if err != nil {
+			return nil, err
+		}
+
+		resp.Tasks = append(resp.Tasks, msg)
+	}
+
+	if next > 0 {
+		resp.NextPageToken = strconv.Itoa(next)
+	}
+
+	return resp, nil
+}
+
+// WatchTask sends the task, then again every time it changes, until it is done or failed or the client goes away
+func (s *Server) WatchTask(req *taskspb.WatchTaskRequest, stream taskspb.Tasks_WatchTaskServer) error {
+	if req.GetId() == "" {
+		return status.Error(codes.InvalidArgument, "Missing value for the field: id")
+	}
+
+	ctx := stream.Context()
+
+	ticker := time.NewTicker(s.watchInterval)
+	defer ticker.Stop()
+
+	var last *taskspb.TaskObject
+
+	for {
+		taskObj, err := s.tasksService.TasksGet(ctx, req.GetId())
+		if err != nil {
+			return statusError(err)
+		}
+
+		msg, err := toTaskObject(taskObj)
+		if err != nil {
+			return err
+		}
+
+		if last == nil || !proto.Equal(last, msg) {
+			if err := stream.Send(msg); err != nil {
+				return err
+			}
+
+			last = msg
+		}
+
+		if taskObj.Status == model.Done || taskObj.Status == model.Error {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case <-ticker.C:
+		}
+	}
+}
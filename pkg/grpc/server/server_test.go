@@ -0,0 +1,312 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/axxonsoft-assignment/pkg/grpc/taskspb"
+	"github.com/axxonsoft-assignment/pkg/model"
+	"github.com/axxonsoft-assignment/pkg/service"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// newClient gives a client of the server running on top of the tasks service, both shut down at the end of the test
+func newClient(t *testing.T, tasksService service.Tasks) taskspb.TasksClient {
+	listener := bufconn.Listen(1024 * 1024)
+
+	grpcServer := grpc.NewServer()
+	taskspb.RegisterTasksServer(grpcServer, New(tasksService, time.Millisecond))
+
+	go grpcServer.Serve(listener)
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { conn.Close() })
+
+	return taskspb.NewTasksClient(conn)
+}
+
+func TestServer_CreateTask(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tasksMock := service.NewMockTasks(ctrl)
+	client := newClient(t, tasksMock)
+
+	maxRedirects := 3
+	data, _ := structpb.NewStruct(map[string]interface{}{"name": "alice", "age": 42})
+
+	tcs := []struct {
+		description string
+		req         *taskspb.CreateTaskRequest
+		mockCalls   []*gomock.Call
+		resp        *taskspb.CreateTaskResponse
+		expCode     codes.Code
+		expFields   []*errdetails.BadRequest_FieldViolation
+	}{
+		{
+			description: "Positive case: the task is converted to the model",
+			req: &taskspb.CreateTaskRequest{Task: &taskspb.Task{Method: "POST", Url: "https://www.getyourtasks.com/users",
+				Headers: map[string]string{model.ContentType: "application/json"}, Data: data, MaxRedirects: proto32(3),
+				FanOut:  &taskspb.FanOut{Hosts: []string{"eu", "us"}, Quorum: 1},
+				Extract: map[string]*taskspb.ExtractRule{"id": {From: model.ExtractFromBody, Path: "$.id"}}}},
+			mockCalls: []*gomock.Call{
+				tasksMock.EXPECT().TasksCreate(gomock.Any(), model.Task{Method: "POST", URL: "https://www.getyourtasks.com/users",
+					Headers:      map[string]interface{}{model.ContentType: "application/json"},
+					Data:         map[string]interface{}{"name": "alice", "age": float64(42)},
+					MaxRedirects: &maxRedirects, FanOut: &model.FanOut{Hosts: []string{"eu", "us"}, Quorum: 1},
+					Extract: map[string]model.ExtractRule{"id": {From: model.ExtractFromBody, Path: "$.id"}}}).
+					Return(&model.TasksResponse{ID: "42"}, nil),
+			},
+			resp: &taskspb.CreateTaskResponse{Id: "42"},
+		},
+		{
+			description: "Negative case: the violations are given as field violations",
+			req:         &taskspb.CreateTaskRequest{},
+			mockCalls: []*gomock.Call{
+				tasksMock.EXPECT().TasksCreate(gomock.Any(), model.Task{}).Return(nil, &service.Error{
+					Kind: service.KindValidation,
+					Err: &model.ValidationError{Violations: []model.Violation{
+						{Pointer: "/method", Rule: model.RuleRequired, Message: "Invalid request: method cannot be empty"},
+						{Pointer: "/url", Rule: model.RuleRequired, Message: "Invalid request: url cannot be empty"},
+					}},
+				}),
+			},
+			expCode: codes.InvalidArgument,
+			expFields: []*errdetails.BadRequest_FieldViolation{
+				{Field: "/method", Description: "Invalid request: method cannot be empty"},
+				{Field: "/url", Description: "Invalid request: url cannot be empty"},
+			},
+		},
+		{
+			description: "Negative case: redis is down",
+			req:         &taskspb.CreateTaskRequest{Task: &taskspb.Task{Method: "GET", Url: "https://www.getyourtasks.com"}},
+			mockCalls: []*gomock.Call{
+				tasksMock.EXPECT().TasksCreate(gomock.Any(), gomock.Any()).
+					Return(nil, &service.Error{Kind: service.KindUnavailable, Err: errors.New("DB error")}),
+			},
+			expCode: codes.Unavailable,
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			resp, err := client.CreateTask(context.Background(), tc.req)
+
+			assert.Equal(t, tc.expCode, status.Code(err))
+
+			if tc.resp != nil {
+				assert.Equal(t, tc.resp.Id, resp.GetId())
+			}
+
+			if tc.expFields != nil {
+				details := status.Convert(err).Details()
+				if assert.Len(t, details, 1) {
+					badRequest := details[0].(*errdetails.BadRequest)
+					assert.Len(t, badRequest.FieldViolations, len(tc.expFields))
+
+					for i, field := range tc.expFields {
+						assert.Equal(t, field.Field, badRequest.FieldViolations[i].Field)
+						assert.Equal(t, field.Description, badRequest.FieldViolations[i].Description)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestServer_GetTask(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tasksMock := service.NewMockTasks(ctrl)
+	client := newClient(t, tasksMock)
+
+	statusCode := http.StatusOK
+	length := int64(17)
+	at := time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+
+	tasksMock.EXPECT().TasksGet(gomock.Any(), "42").Return(&model.TasksObject{ID: "42", Status: model.Done,
+		HTTPStatusCode: &statusCode, Headers: http.Header{"Set-Cookie": {"a=1", "b=2"}}, Length: &length,
+		Outputs:  map[string]interface{}{"token": "abc"},
+		Attempts: []model.PollAttempt{{At: at, HTTPStatusCode: &statusCode, Matched: true}},
+		Error:    &model.TaskError{Code: model.ErrCodeCallFailed, Message: "timeout"}}, nil)
+	tasksMock.EXPECT().TasksGet(gomock.Any(), "43").
+		Return(nil, &service.Error{Kind: service.KindNotFound, Err: errors.New("Task not found")})
+
+	resp, err := client.GetTask(context.Background(), &taskspb.GetTaskRequest{Id: "42"})
+	assert.Nil(t, err)
+	assert.Equal(t, "42", resp.GetId())
+	assert.Equal(t, int32(http.StatusOK), resp.GetHttpStatusCode())
+	assert.Equal(t, []interface{}{"a=1", "b=2"}, resp.GetHeaders()["Set-Cookie"].AsSlice())
+	assert.Equal(t, int64(17), resp.GetLength())
+	assert.Equal(t, "abc", resp.GetOutputs().AsMap()["token"])
+	assert.Equal(t, at, resp.GetAttempts()[0].GetAt().AsTime())
+	assert.Equal(t, model.ErrCodeCallFailed, resp.GetError().GetCode())
+
+	_, err = client.GetTask(context.Background(), &taskspb.GetTaskRequest{Id: "43"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.Equal(t, "Task not found", status.Convert(err).Message())
+
+	_, err = client.GetTask(context.Background(), &taskspb.GetTaskRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestServer_ListTasks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tasksMock := service.NewMockTasks(ctrl)
+	client := newClient(t, tasksMock)
+
+	tcs := []struct {
+		description string
+		req         *taskspb.ListTasksRequest
+		mockCalls   []*gomock.Call
+		ids         []string
+		nextToken   string
+		expCode     codes.Code
+	}{
+		{
+			description: "Positive case: a page is followed by another",
+			req:         &taskspb.ListTasksRequest{PageSize: 2},
+			mockCalls: []*gomock.Call{
+				tasksMock.EXPECT().TasksList(gomock.Any(), 0, 2).
+					Return([]*model.TasksObject{{ID: "3"}, {ID: "2"}}, 2, nil),
+			},
+			ids:       []string{"3", "2"},
+			nextToken: "2",
+		},
+		{
+			description: "Positive case: a page short of expired tasks is still followed by another",
+			req:         &taskspb.ListTasksRequest{PageSize: 2, PageToken: "2"},
+			mockCalls: []*gomock.Call{
+				tasksMock.EXPECT().TasksList(gomock.Any(), 2, 2).Return([]*model.TasksObject{{ID: "1"}}, 5, nil),
+			},
+			ids:       []string{"1"},
+			nextToken: "5",
+		},
+		{
+			description: "Positive case: the last page",
+			req:         &taskspb.ListTasksRequest{PageSize: 2, PageToken: "2"},
+			mockCalls: []*gomock.Call{
+				tasksMock.EXPECT().TasksList(gomock.Any(), 2, 2).Return([]*model.TasksObject{{ID: "1"}}, 0, nil),
+			},
+			ids: []string{"1"},
+		},
+		{
+			description: "Positive case: the page size defaults to 20",
+			req:         &taskspb.ListTasksRequest{},
+			mockCalls: []*gomock.Call{
+				tasksMock.EXPECT().TasksList(gomock.Any(), 0, 20).Return([]*model.TasksObject{}, 0, nil),
+			},
+		},
+		{
+			description: "Negative case: invalid page token",
+			req:         &taskspb.ListTasksRequest{PageToken: "abc"},
+			expCode:     codes.InvalidArgument,
+		},
+		{
+			description: "Negative case: page size too large",
+			req:         &taskspb.ListTasksRequest{PageSize: 1000},
+			mockCalls: []*gomock.Call{
+				tasksMock.EXPECT().TasksList(gomock.Any(), 0, 1000).Return(nil, 0, &service.Error{
+					Kind: service.KindValidation, Err: errors.New("Invalid request: limit should be between 1 and 100")}),
+			},
+			expCode: codes.InvalidArgument,
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			resp, err := client.ListTasks(context.Background(), tc.req)
+
+			assert.Equal(t, tc.expCode, status.Code(err))
+
+			var ids []string
+			for _, taskObj := range resp.GetTasks() {
+				ids = append(ids, taskObj.GetId())
+			}
+
+			assert.Equal(t, tc.ids, ids)
+			assert.Equal(t, tc.nextToken, resp.GetNextPageToken())
+		})
+	}
+}
+
+func TestServer_WatchTask(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tasksMock := service.NewMockTasks(ctrl)
+	client := newClient(t, tasksMock)
+
+	statusCode := http.StatusOK
+
+	// the task is sent once per change, until it is done
+	gomock.InOrder(
+		tasksMock.EXPECT().TasksGet(gomock.Any(), "42").Return(&model.TasksObject{ID: "42", Status: model.InProcess}, nil),
+		tasksMock.EXPECT().TasksGet(gomock.Any(), "42").Return(&model.TasksObject{ID: "42", Status: model.InProcess}, nil),
+		tasksMock.EXPECT().TasksGet(gomock.Any(), "42").Return(&model.TasksObject{ID: "42", Status: model.Done,
+			HTTPStatusCode: &statusCode}, nil),
+	)
+
+	stream, err := client.WatchTask(context.Background(), &taskspb.WatchTaskRequest{Id: "42"})
+	assert.Nil(t, err)
+
+	var statuses []string
+
+	for {
+		msg, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+
+		if !assert.Nil(t, err) {
+			return
+		}
+
+		statuses = append(statuses, msg.GetStatus())
+	}
+
+	assert.Equal(t, []string{model.InProcess, model.Done}, statuses)
+
+	// a task that does not exist ends the stream
+	tasksMock.EXPECT().TasksGet(gomock.Any(), "43").
+		Return(nil, &service.Error{Kind: service.KindNotFound, Err: errors.New("Task not found")})
+
+	stream, err = client.WatchTask(context.Background(), &taskspb.WatchTaskRequest{Id: "43"})
+	assert.Nil(t, err)
+
+	_, err = stream.Recv()
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func proto32(v int32) *int32 {
+	return &v
+}
@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/axxonsoft-assignment/pkg/service"
+)
+
+type Breaker struct {
+	breakersService service.Breakers
+}
+
+func NewBreakers(breakersService service.Breakers) Breakers {
+	return Breaker{breakersService: breakersService}
+}
+
+// ListBreakers handles incoming list HTTP requests, and returns the circuit state of every host called so far.
+func (b Breaker) ListBreakers(w http.ResponseWriter, r *http.Request) {
+	// Initialize context
+	ctx := context.Background()
+
+	writeJSON(w, b.breakersService.BreakersList(ctx))
+}
+
+// GetBreaker handles incoming get HTTP requests, and returns the circuit state of the host.
+func (b Breaker) GetBreaker(w http.ResponseWriter, r *http.Request) {
+	// Initialize context
+	ctx := context.Background()
+
+	host, ok := hostParam(w, r)
+	if !ok {
+		return
+	}
+
+	writeJSON(w, b.breakersService.BreakersGet(ctx, host))
+}
+
+// ResetBreaker handles incoming reset HTTP requests, and returns the closed circuit of the host.
+func (b Breaker) ResetBreaker(w http.ResponseWriter, r *http.Request) {
+	// Initialize context
+	ctx := context.Background()
+
+	host, ok := hostParam(w, r)
+	if !ok {
+		return
+	}
+
+	writeJSON(w, b.breakersService.BreakersReset(ctx, host))
+}
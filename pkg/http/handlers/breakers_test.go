@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/axxonsoft-assignment/pkg/model"
+	"github.com/axxonsoft-assignment/pkg/service"
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBreaker_GetBreaker(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	breakersServiceMock := service.NewMockBreakers(ctrl)
+
+	testCases := []struct {
+		description string
+		host        string
+		mockCalls   []*gomock.Call
+		expCode     int
+		expBody     string
+	}{
+		{
+			description: "Positive case: valid request",
+			host:        "api.partner.com",
+			mockCalls: []*gomock.Call{
+				breakersServiceMock.EXPECT().BreakersGet(gomock.Any(), "api.partner.com").
+					Return(model.CircuitState{Host: "api.partner.com", State: model.CircuitOpen}),
+			},
+			expCode: http.StatusOK,
+			expBody: `"state":"open"`,
+		},
+		{
+			description: "Negative case: missing host",
+			expCode:     http.StatusBadRequest,
+			expBody:     "Missing value for the parameter: host",
+		},
+	}
+
+	handler := NewBreakers(breakersServiceMock)
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/admin/breakers/"+tc.host, nil)
+			r = mux.SetURLVars(r, map[string]string{"host": tc.host})
+			w := httptest.NewRecorder()
+
+			handler.GetBreaker(w, r)
+
+			assert.Equal(t, tc.expCode, w.Code)
+			assert.Contains(t, w.Body.String(), tc.expBody)
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/axxonsoft-assignment/pkg/model"
+	"github.com/axxonsoft-assignment/pkg/service"
+	"github.com/gorilla/mux"
+)
+
+type Chain struct {
+	chainsService service.Chains
+}
+
+func NewChains(chainsService service.Chains) Chains {
+	return Chain{chainsService: chainsService}
+}
+
+// CreateChain handles incoming create HTTP requests for a chain of tasks and returns the created chain
+func (c Chain) CreateChain(w http.ResponseWriter, r *http.Request) {
+	// Initialize context
+	ctx := context.Background()
+
+	var chain model.Chain
+	if !readJSON(w, r, &chain) {
+		return
+	}
+
+	resp, err := c.chainsService.ChainsCreate(ctx, chain)
+	if err != nil {
+		writeError(w, err)
+
+		return
+	}
+
+	// the steps are run in the background
+	writeCreated(w, http.StatusAccepted, "/chains/"+resp.ID, resp)
+}
+
+// GetChain handles incoming get HTTP requests, and returns the chain along with the task details of its steps.
+func (c Chain) GetChain(w http.ResponseWriter, r *http.Request) {
+	// Initialize context
+	ctx := context.Background()
+
+	chainID := mux.Vars(r)["chainID"]
+	if chainID == "" {
+		writeProblem(w, http.StatusBadRequest, codeMissingParameter, "Missing value for the parameter: chainID", nil)
+
+		return
+	}
+
+	resp, err := c.chainsService.ChainsGet(ctx, chainID)
+	if err != nil {
+		writeError(w, err)
+
+		return
+	}
+
+	writeJSON(w, resp)
+}
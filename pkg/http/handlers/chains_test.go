@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/axxonsoft-assignment/pkg/model"
+	"github.com/axxonsoft-assignment/pkg/service"
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChain_CreateChain(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	chainsServiceMock := service.NewMockChains(ctrl)
+
+	testCases := []struct {
+		description string
+		reqBody     string
+		mockCalls   []*gomock.Call
+		expCode     int
+	}{
+		{
+			description: "Positive case: valid request",
+			reqBody:     `{"steps":[{"task":{"method":"GET","url":"https://www.getyourtasks.com/login"}}]}`,
+			mockCalls: []*gomock.Call{
+				chainsServiceMock.EXPECT().ChainsCreate(gomock.Any(), gomock.Any()).
+					Return(&model.Chain{ID: "chain-1", Status: model.New}, nil),
+			},
+			expCode: http.StatusAccepted,
+		},
+		{
+			description: "Negative case: error from service layer",
+			reqBody:     `{"steps":[]}`,
+			mockCalls: []*gomock.Call{
+				chainsServiceMock.EXPECT().ChainsCreate(gomock.Any(), gomock.Any()).
+					Return(nil, &service.Error{Kind: service.KindValidation, Err: errors.New("Invalid request: steps cannot be empty")}),
+			},
+			expCode: http.StatusBadRequest,
+		},
+		{
+			description: "Negative case: invalid request body",
+			reqBody:     `{`,
+			expCode:     http.StatusBadRequest,
+		},
+	}
+
+	handler := NewChains(chainsServiceMock)
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/chains", strings.NewReader(tc.reqBody))
+			w := httptest.NewRecorder()
+
+			handler.CreateChain(w, r)
+
+			assert.Equal(t, tc.expCode, w.Code)
+		})
+	}
+}
+
+func TestChain_GetChain(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	chainsServiceMock := service.NewMockChains(ctrl)
+	chainsServiceMock.EXPECT().ChainsGet(gomock.Any(), "chain-1").Return(&model.Chain{ID: "chain-1", Status: model.Done}, nil)
+
+	handler := NewChains(chainsServiceMock)
+
+	r := httptest.NewRequest(http.MethodGet, "/chains/chain-1", nil)
+	r = mux.SetURLVars(r, map[string]string{"chainID": "chain-1"})
+	w := httptest.NewRecorder()
+
+	handler.GetChain(w, r)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `{"id":"chain-1","status":"done","steps":null}`, w.Body.String())
+}
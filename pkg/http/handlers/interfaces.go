@@ -5,4 +5,47 @@ import "net/http"
 type Tasks interface {
 	CreateTask(w http.ResponseWriter, r *http.Request)
 	GetTask(w http.ResponseWriter, r *http.Request)
+	GetTaskBody(w http.ResponseWriter, r *http.Request)
+}
+
+type Schedules interface {
+	CreateSchedule(w http.ResponseWriter, r *http.Request)
+	GetSchedule(w http.ResponseWriter, r *http.Request)
+	ListSchedules(w http.ResponseWriter, r *http.Request)
+	UpdateSchedule(w http.ResponseWriter, r *http.Request)
+	DeleteSchedule(w http.ResponseWriter, r *http.Request)
+	PauseSchedule(w http.ResponseWriter, r *http.Request)
+	ResumeSchedule(w http.ResponseWriter, r *http.Request)
+	GetScheduleHistory(w http.ResponseWriter, r *http.Request)
+}
+
+type RateLimits interface {
+	SetRateLimit(w http.ResponseWriter, r *http.Request)
+	GetRateLimit(w http.ResponseWriter, r *http.Request)
+	ListRateLimits(w http.ResponseWriter, r *http.Request)
+	DeleteRateLimit(w http.ResponseWriter, r *http.Request)
+}
+
+type Breakers interface {
+	ListBreakers(w http.ResponseWriter, r *http.Request)
+	GetBreaker(w http.ResponseWriter, r *http.Request)
+	ResetBreaker(w http.ResponseWriter, r *http.Request)
+}
+
+type Templates interface {
+	SetTemplate(w http.ResponseWriter, r *http.Request)
+	GetTemplate(w http.ResponseWriter, r *http.Request)
+	ListTemplates(w http.ResponseWriter, r *http.Request)
+	DeleteTemplate(w http.ResponseWriter, r *http.Request)
+	CreateTaskFromTemplate(w http.ResponseWriter, r *http.Request)
+}
+
+type Chains interface {
+	CreateChain(w http.ResponseWriter, r *http.Request)
+	GetChain(w http.ResponseWriter, r *http.Request)
+}
+
+type Workflows interface {
+	CreateWorkflow(w http.ResponseWriter, r *http.Request)
+	GetWorkflow(w http.ResponseWriter, r *http.Request)
 }
@@ -0,0 +1,15 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/axxonsoft-assignment/pkg/openapi"
+)
+
+// the document only depends on the model types, it is generated once
+var openAPIDocument = openapi.New()
+
+// OpenAPI handles incoming HTTP requests for the OpenAPI document describing the routes of the service.
+func OpenAPI(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, openAPIDocument)
+}
@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/axxonsoft-assignment/pkg/model"
+	"github.com/axxonsoft-assignment/pkg/service"
+	"github.com/gorilla/mux"
+)
+
+type RateLimit struct {
+	rateLimitsService service.RateLimits
+}
+
+func NewRateLimits(rateLimitsService service.RateLimits) RateLimits {
+	return RateLimit{rateLimitsService: rateLimitsService}
+}
+
+// SetRateLimit handles incoming HTTP requests to create or replace the rate limit of a host
+func (rl RateLimit) SetRateLimit(w http.ResponseWriter, r *http.Request) {
+	// Initialize context
+	ctx := context.Background()
+
+	host, ok := hostParam(w, r)
+	if !ok {
+		return
+	}
+
+	reqBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeProblem(w, http.StatusBadRequest, codeInvalidBody, "Error reading request body", nil)
+
+		return
+	}
+
+	var limit model.RateLimit
+
+	err = json.Unmarshal(reqBody, &limit)
+	if err != nil {
+		writeProblem(w, http.StatusBadRequest, codeInvalidBody, "Error in unmarshalling JSON", nil)
+
+		return
+	}
+
+	resp, err := rl.rateLimitsService.RateLimitsSet(ctx, host, limit)
+	if err != nil {
+		writeError(w, err)
+
+		return
+	}
+
+	writeJSON(w, resp)
+}
+
+// GetRateLimit handles incoming get HTTP requests, and returns the rate limit of the host.
+func (rl RateLimit) GetRateLimit(w http.ResponseWriter, r *http.Request) {
+	// Initialize context
+	ctx := context.Background()
+
+	host, ok := hostParam(w, r)
+	if !ok {
+		return
+	}
+
+	resp, err := rl.rateLimitsService.RateLimitsGet(ctx, host)
+	if err != nil {
+		writeError(w, err)
+
+		return
+	}
+
+	writeJSON(w, resp)
+}
+
+// ListRateLimits handles incoming list HTTP requests, and returns the rate limits of all the hosts.
+func (rl RateLimit) ListRateLimits(w http.ResponseWriter, r *http.Request) {
+	// Initialize context
+	ctx := context.Background()
+
+	resp, err := rl.rateLimitsService.RateLimitsList(ctx)
+	if err != nil {
+		writeError(w, err)
+
+		return
+	}
+
+	writeJSON(w, resp)
+}
+
+// DeleteRateLimit handles incoming delete HTTP requests, and removes the rate limit of the host.
+func (rl RateLimit) DeleteRateLimit(w http.ResponseWriter, r *http.Request) {
+	// Initialize context
+	ctx := context.Background()
+
+	host, ok := hostParam(w, r)
+	if !ok {
+		return
+	}
+
+	if err := rl.rateLimitsService.RateLimitsDelete(ctx, host); err != nil {
+		writeError(w, err)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func hostParam(w http.ResponseWriter, r *http.Request) (string, bool) {
+	host := mux.Vars(r)["host"]
+	if host == "" {
+		writeProblem(w, http.StatusBadRequest, codeMissingParameter, "Missing value for the parameter: host", nil)
+
+		return "", false
+	}
+
+	return host, true
+}
@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/axxonsoft-assignment/pkg/model"
+	"github.com/axxonsoft-assignment/pkg/service"
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimit_SetRateLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	rateLimitsServiceMock := service.NewMockRateLimits(ctrl)
+
+	testCases := []struct {
+		description string
+		host        string
+		reqBody     string
+		mockCalls   []*gomock.Call
+		expCode     int
+	}{
+		{
+			description: "Positive case: valid request",
+			host:        "api.partner.com",
+			reqBody:     `{"rate":5,"burst":10}`,
+			mockCalls: []*gomock.Call{
+				rateLimitsServiceMock.EXPECT().RateLimitsSet(gomock.Any(), "api.partner.com", model.RateLimit{Rate: 5, Burst: 10}).
+					Return(&model.RateLimit{Host: "api.partner.com", Rate: 5, Burst: 10}, nil),
+			},
+			expCode: http.StatusOK,
+		},
+		{
+			description: "Negative case: error from service layer",
+			host:        "api.partner.com",
+			reqBody:     `{"rate":0,"burst":10}`,
+			mockCalls: []*gomock.Call{
+				rateLimitsServiceMock.EXPECT().RateLimitsSet(gomock.Any(), "api.partner.com", model.RateLimit{Burst: 10}).
+					Return(nil, &service.Error{Kind: service.KindValidation, Err: errors.New("error from service layer")}),
+			},
+			expCode: http.StatusBadRequest,
+		},
+		{
+			description: "Negative case: missing host",
+			reqBody:     `{"rate":5,"burst":10}`,
+			expCode:     http.StatusBadRequest,
+		},
+		{
+			description: "Negative case: invalid request body",
+			host:        "api.partner.com",
+			reqBody:     `{`,
+			expCode:     http.StatusBadRequest,
+		},
+	}
+
+	handler := NewRateLimits(rateLimitsServiceMock)
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPut, "/ratelimits/"+tc.host, strings.NewReader(tc.reqBody))
+			r = mux.SetURLVars(r, map[string]string{"host": tc.host})
+			w := httptest.NewRecorder()
+
+			handler.SetRateLimit(w, r)
+
+			assert.Equal(t, tc.expCode, w.Code)
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/axxonsoft-assignment/pkg/model"
+	"github.com/axxonsoft-assignment/pkg/service"
+)
+
+// problemContentType is the content type of the error responses, as per RFC 7807
+const problemContentType = "application/problem+json"
+
+// codes of the error responses
+const (
+	codeInvalidRequest   = "invalid_request"
+	codeInvalidBody      = "invalid_body"
+	codeMissingParameter = "missing_parameter"
+	codeNotFound         = "not_found"
+	codeConflict         = "conflict"
+	codeUnavailable      = "unavailable"
+	codeInternal         = "internal_error"
+)
+
+// writeJSON marshals the response and writes it with the JSON content type
+func writeJSON(w http.ResponseWriter, resp interface{}) {
+	writeJSONStatus(w, http.StatusOK, resp)
+}
+
+// writeCreated writes the response with the status code of a creation, along with the location of what was created
+func writeCreated(w http.ResponseWriter, status int, location string, resp interface{}) {
+	w.Header().Set("Location", location)
+
+	writeJSONStatus(w, status, resp)
+}
+
+// writeJSONStatus marshals the response and writes it with the status code and the JSON content type
+func writeJSONStatus(w http.ResponseWriter, status int, resp interface{}) {
+	respJSON, err := json.Marshal(resp)
+	if err != nil {
+		writeProblem(w, http.StatusInternalServerError, codeInternal, "Error in marshalling response", nil)
+
+		return
+	}
+
+	w.Header().Set(model.ContentType, "application/json")
+	w.WriteHeader(status)
+
+	// the status code is already sent, the failure can only be logged
+	if _, err = w.Write(respJSON); err != nil {
+		log.Printf("Error sending JSON response: %v", err)
+	}
+}
+
+// writeError writes the error of a service as a problem with the status code of its kind, an error of no kind being
+// unexpected. A validation error lists its violations.
+func writeError(w http.ResponseWriter, err error) {
+	status, code := http.StatusInternalServerError, codeInternal
+
+	switch service.KindOf(err) {
+	case service.KindValidation:
+		status, code = http.StatusBadRequest, codeInvalidRequest
+	case service.KindNotFound:
+		status, code = http.StatusNotFound, codeNotFound
+	case service.KindConflict:
+		status, code = http.StatusConflict, codeConflict
+	case service.KindUnavailable:
+		status, code = http.StatusServiceUnavailable, codeUnavailable
+	}
+
+	var violations []model.Violation
+
+	var validationErr *model.ValidationError
+	if errors.As(err, &validationErr) {
+		violations = validationErr.Violations
+	}
+
+	writeProblem(w, status, code, err.Error(), violations)
+}
+
+// writeProblem writes the problem with the status code and the problem+json content type
+func writeProblem(w http.ResponseWriter, status int, code string, detail string, violations []model.Violation) {
+	// a problem only holds strings and numbers, marshalling it cannot fail
+	respJSON, _ := json.Marshal(model.Problem{
+		Type:       "about:blank",
+		Title:      http.StatusText(status),
+		Status:     status,
+		Detail:     detail,
+		Code:       code,
+		Violations: violations,
+	})
+
+	w.Header().Set(model.ContentType, problemContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+
+	// the status code is already sent, the failure can only be logged
+	if _, err := w.Write(respJSON); err != nil {
+		log.Printf("Error sending problem response: %v", err)
+	}
+}
@@ -0,0 +1,210 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/axxonsoft-assignment/pkg/model"
+	"github.com/axxonsoft-assignment/pkg/service"
+	"github.com/gorilla/mux"
+)
+
+type Schedule struct {
+	schedulesService service.Schedules
+}
+
+func NewSchedules(schedulesService service.Schedules) Schedules {
+	return Schedule{schedulesService: schedulesService}
+}
+
+// CreateSchedule handles incoming create HTTP requests for a recurring task and returns the created schedule
+func (s Schedule) CreateSchedule(w http.ResponseWriter, r *http.Request) {
+	// Initialize context
+	ctx := context.Background()
+
+	schedule, ok := readSchedule(w, r)
+	if !ok {
+		return
+	}
+
+	resp, err := s.schedulesService.SchedulesCreate(ctx, schedule)
+	if err != nil {
+		writeError(w, err)
+
+		return
+	}
+
+	writeCreated(w, http.StatusCreated, "/schedules/"+resp.ID, resp)
+}
+
+// GetSchedule handles incoming get HTTP requests, and returns the schedule for that scheduleID.
+func (s Schedule) GetSchedule(w http.ResponseWriter, r *http.Request) {
+	// Initialize context
+	ctx := context.Background()
+
+	scheduleID, ok := scheduleIDParam(w, r)
+	if !ok {
+		return
+	}
+
+	resp, err := s.schedulesService.SchedulesGet(ctx, scheduleID)
+	if err != nil {
+		writeError(w, err)
+
+		return
+	}
+
+	writeJSON(w, resp)
+}
+
+// ListSchedules handles incoming list HTTP requests, and returns all the schedules.
+func (s Schedule) ListSchedules(w http.ResponseWriter, r *http.Request) {
+	// Initialize context
+	ctx := context.Background()
+
+	resp, err := s.schedulesService.SchedulesList(ctx)
+	if err != nil {
+		writeError(w, err)
+
+		return
+	}
+
+	writeJSON(w, resp)
+}
+
+// UpdateSchedule handles incoming update HTTP requests, and returns the updated schedule.
+func (s Schedule) UpdateSchedule(w http.ResponseWriter, r *http.Request) {
+	// Initialize context
+	ctx := context.Background()
+
+	scheduleID, ok := scheduleIDParam(w, r)
+	if !ok {
+		return
+	}
+
+	schedule, ok := readSchedule(w, r)
+	if !ok {
+		return
+	}
+
+	resp, err := s.schedulesService.SchedulesUpdate(ctx, scheduleID, schedule)
+	if err != nil {
+		writeError(w, err)
+
+		return
+	}
+
+	writeJSON(w, resp)
+}
+
+// DeleteSchedule handles incoming delete HTTP requests, the tasks already created by the schedule are retained.
+func (s Schedule) DeleteSchedule(w http.ResponseWriter, r *http.Request) {
+	// Initialize context
+	ctx := context.Background()
+
+	scheduleID, ok := scheduleIDParam(w, r)
+	if !ok {
+		return
+	}
+
+	if err := s.schedulesService.SchedulesDelete(ctx, scheduleID); err != nil {
+		writeError(w, err)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// PauseSchedule handles incoming pause HTTP requests, and returns the paused schedule.
+func (s Schedule) PauseSchedule(w http.ResponseWriter, r *http.Request) {
+	// Initialize context
+	ctx := context.Background()
+
+	scheduleID, ok := scheduleIDParam(w, r)
+	if !ok {
+		return
+	}
+
+	resp, err := s.schedulesService.SchedulesPause(ctx, scheduleID)
+	if err != nil {
+		writeError(w, err)
+
+		return
+	}
+
+	writeJSON(w, resp)
+}
+
+// ResumeSchedule handles incoming resume HTTP requests, and returns the resumed schedule.
+func (s Schedule) ResumeSchedule(w http.ResponseWriter, r *http.Request) {
+	// Initialize context
+	ctx := context.Background()
+
+	scheduleID, ok := scheduleIDParam(w, r)
+	if !ok {
+		return
+	}
+
+	resp, err := s.schedulesService.SchedulesResume(ctx, scheduleID)
+	if err != nil {
+		writeError(w, err)
+
+		return
+	}
+
+	writeJSON(w, resp)
+}
+
+// GetScheduleHistory handles incoming history HTTP requests, and returns the runs of the schedule.
+func (s Schedule) GetScheduleHistory(w http.ResponseWriter, r *http.Request) {
+	// Initialize context
+	ctx := context.Background()
+
+	scheduleID, ok := scheduleIDParam(w, r)
+	if !ok {
+		return
+	}
+
+	resp, err := s.schedulesService.SchedulesHistory(ctx, scheduleID)
+	if err != nil {
+		writeError(w, err)
+
+		return
+	}
+
+	writeJSON(w, resp)
+}
+
+func scheduleIDParam(w http.ResponseWriter, r *http.Request) (string, bool) {
+	scheduleID := mux.Vars(r)["scheduleID"]
+	if scheduleID == "" {
+		writeProblem(w, http.StatusBadRequest, codeMissingParameter, "Missing value for the parameter: scheduleID", nil)
+
+		return "", false
+	}
+
+	return scheduleID, true
+}
+
+func readSchedule(w http.ResponseWriter, r *http.Request) (model.Schedule, bool) {
+	var schedule model.Schedule
+
+	reqBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeProblem(w, http.StatusBadRequest, codeInvalidBody, "Error reading request body", nil)
+
+		return schedule, false
+	}
+
+	err = json.Unmarshal(reqBody, &schedule)
+	if err != nil {
+		writeProblem(w, http.StatusBadRequest, codeInvalidBody, "Error in unmarshalling JSON", nil)
+
+		return schedule, false
+	}
+
+	return schedule, true
+}
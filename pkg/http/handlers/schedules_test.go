@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/axxonsoft-assignment/pkg/model"
+	"github.com/axxonsoft-assignment/pkg/service"
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchedule_CreateSchedule(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	schedulesServiceMock := service.NewMockSchedules(ctrl)
+
+	schedule := model.Schedule{
+		Task: model.Task{Method: "GET", URL: "https://httpstat.us/200"},
+		Cron: "*/5 * * * *",
+	}
+
+	testCases := []struct {
+		description string
+		reqBody     string
+		mockCalls   []*gomock.Call
+		expCode     int
+	}{
+		{
+			description: "Positive case: valid request",
+			reqBody:     `{"task":{"method":"GET","url":"https://httpstat.us/200"},"cron":"*/5 * * * *"}`,
+			mockCalls: []*gomock.Call{
+				schedulesServiceMock.EXPECT().SchedulesCreate(gomock.Any(), schedule).
+					Return(&model.Schedule{ID: "12323"}, nil),
+			},
+			expCode: http.StatusCreated,
+		},
+		{
+			description: "Negative case: error from service layer",
+			reqBody:     `{"task":{"method":"GET","url":"https://httpstat.us/200"},"cron":"*/5 * * * *"}`,
+			mockCalls: []*gomock.Call{
+				schedulesServiceMock.EXPECT().SchedulesCreate(gomock.Any(), schedule).
+					Return(nil, &service.Error{Kind: service.KindValidation, Err: errors.New("error from service layer")}),
+			},
+			expCode: http.StatusBadRequest,
+		},
+		{
+			description: "Negative case: invalid request body",
+			reqBody:     `{`,
+			expCode:     http.StatusBadRequest,
+		},
+	}
+
+	handler := NewSchedules(schedulesServiceMock)
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/schedules", strings.NewReader(tc.reqBody))
+			w := httptest.NewRecorder()
+
+			handler.CreateSchedule(w, r)
+
+			assert.Equal(t, tc.expCode, w.Code)
+		})
+	}
+}
+
+func TestSchedule_DeleteSchedule(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	schedulesServiceMock := service.NewMockSchedules(ctrl)
+
+	testCases := []struct {
+		description string
+		scheduleID  string
+		mockCalls   []*gomock.Call
+		expCode     int
+	}{
+		{
+			description: "Positive case: valid request",
+			scheduleID:  "12323",
+			mockCalls: []*gomock.Call{
+				schedulesServiceMock.EXPECT().SchedulesDelete(gomock.Any(), "12323").Return(nil),
+			},
+			expCode: http.StatusNoContent,
+		},
+		{
+			description: "Negative case: missing scheduleID",
+			expCode:     http.StatusBadRequest,
+		},
+		{
+			description: "Negative case: error from service layer",
+			scheduleID:  "#!@!",
+			mockCalls: []*gomock.Call{
+				schedulesServiceMock.EXPECT().SchedulesDelete(gomock.Any(), "#!@!").
+					Return(&service.Error{Kind: service.KindValidation, Err: errors.New("error from service layer")}),
+			},
+			expCode: http.StatusBadRequest,
+		},
+	}
+
+	handler := NewSchedules(schedulesServiceMock)
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodDelete, "/schedules/"+tc.scheduleID, nil)
+			r = mux.SetURLVars(r, map[string]string{"scheduleID": tc.scheduleID})
+			w := httptest.NewRecorder()
+
+			handler.DeleteSchedule(w, r)
+
+			assert.Equal(t, tc.expCode, w.Code)
+		})
+	}
+}
+
+func TestSchedule_GetScheduleHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	schedulesServiceMock := service.NewMockSchedules(ctrl)
+	schedulesServiceMock.EXPECT().SchedulesHistory(gomock.Any(), "12323").
+		Return([]*model.ScheduleRun{{TaskID: "42"}}, nil)
+
+	r := httptest.NewRequest(http.MethodGet, "/schedules/12323/history", nil)
+	r = mux.SetURLVars(r, map[string]string{"scheduleID": "12323"})
+	w := httptest.NewRecorder()
+
+	NewSchedules(schedulesServiceMock).GetScheduleHistory(w, r)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"taskId":"42"`)
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"io"
 	"net/http"
+	"time"
 
 	"github.com/axxonsoft-assignment/pkg/model"
 	"github.com/axxonsoft-assignment/pkg/service"
@@ -26,7 +27,7 @@ func (t Task) CreateTask(w http.ResponseWriter, r *http.Request) {
 
 	reqBody, err := io.ReadAll(r.Body)
 	if err != nil {
-		http.Error(w, "Error reading request body", http.StatusBadRequest)
+		writeProblem(w, http.StatusBadRequest, codeInvalidBody, "Error reading request body", nil)
 
 		return
 	}
@@ -35,33 +36,20 @@ func (t Task) CreateTask(w http.ResponseWriter, r *http.Request) {
 
 	err = json.Unmarshal(reqBody, &taskData)
 	if err != nil {
-		http.Error(w, "Error in unmarshalling JSON", http.StatusBadRequest)
+		writeProblem(w, http.StatusBadRequest, codeInvalidBody, "Error in unmarshalling JSON", nil)
 
 		return
 	}
 
 	resp, err := t.tasksService.TasksCreate(ctx, taskData)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		writeError(w, err)
 
 		return
 	}
 
-	respJSON, err := json.Marshal(resp)
-	if err != nil {
-		http.Error(w, "Error in marshalling response", http.StatusBadRequest)
-
-		return
-	}
-
-	w.Header().Set(model.ContentType, "application/json")
-
-	_, err = w.Write(respJSON)
-	if err != nil {
-		http.Error(w, "Error sending JSON response", http.StatusInternalServerError)
-
-		return
-	}
+	// the task is run in the background, its details are to be fetched from its location
+	writeCreated(w, http.StatusAccepted, "/task/"+resp.ID, resp)
 }
 
 // GetTask handles incoming get HTTP requests, and returns the data present for that taskID.
@@ -73,31 +61,47 @@ func (t Task) GetTask(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	taskID := vars["taskID"]
 	if taskID == "" {
-		http.Error(w, "Missing value for the parameter: taskID", http.StatusBadRequest)
+		writeProblem(w, http.StatusBadRequest, codeMissingParameter, "Missing value for the parameter: taskID", nil)
 
 		return
 	}
 
 	resp, err := t.tasksService.TasksGet(ctx, taskID)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		writeError(w, err)
 
 		return
 	}
 
-	respJSON, err := json.Marshal(resp)
-	if err != nil {
-		http.Error(w, "Error in marshalling response", http.StatusBadRequest)
+	writeJSON(w, resp)
+}
+
+// GetTaskBody handles incoming get HTTP requests, and serves the response body of the task kept in the blob store,
+// in full or as the ranges of the Range header. The body is always served as an attachment of binary content, so
+// that an html or svg body of the 3rd party service is never rendered from the API's own origin; its content type is
+// given by the task's blob.
+func (t Task) GetTaskBody(w http.ResponseWriter, r *http.Request) {
+	// Initialize context
+	ctx := context.Background()
+
+	taskID := mux.Vars(r)["taskID"]
+	if taskID == "" {
+		writeProblem(w, http.StatusBadRequest, codeMissingParameter, "Missing value for the parameter: taskID", nil)
 
 		return
 	}
 
-	w.Header().Set(model.ContentType, "application/json")
-
-	_, err = w.Write(respJSON)
+	_, content, err := t.tasksService.TasksBody(ctx, taskID)
 	if err != nil {
-		http.Error(w, "Error sending JSON response", http.StatusInternalServerError)
+		writeError(w, err)
 
 		return
 	}
+	defer content.Close()
+
+	w.Header().Set(model.ContentType, "application/octet-stream")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Content-Disposition", "attachment")
+
+	http.ServeContent(w, r, "", time.Time{}, content)
 }
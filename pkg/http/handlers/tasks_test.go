@@ -25,6 +25,8 @@ func TestTask_CreateTask(t *testing.T) {
 		reqBody                string
 		mockCalls              []*gomock.Call
 		expCode                int
+		expLocation            string
+		expBody                string
 		simulateMarshallingErr bool
 	}{
 		{
@@ -38,7 +40,8 @@ func TestTask_CreateTask(t *testing.T) {
 					}).
 					Return(&model.TasksResponse{ID: "12323"}, nil),
 			},
-			expCode: http.StatusOK,
+			expCode:     http.StatusAccepted,
+			expLocation: "/task/12323",
 		},
 		{
 			description: "Negative case: error from service layer",
@@ -49,14 +52,20 @@ func TestTask_CreateTask(t *testing.T) {
 						Method: "PERTH",
 						URL:    "https://httpstat.us/200",
 					}).
-					Return(nil, errors.New("error from service layer")),
+					Return(nil, &service.Error{Kind: service.KindValidation, Err: &model.ValidationError{Violations: []model.Violation{
+						{Pointer: "/method", Rule: model.RuleEnum, Message: "Invalid request: only the following methods are supported: [GET, PATCH, POST, PUT, DELETE]"},
+					}}}),
 			},
 			expCode: http.StatusBadRequest,
+			expBody: `{"type":"about:blank","title":"Bad Request","status":400,` +
+				`"detail":"Invalid request: only the following methods are supported: [GET, PATCH, POST, PUT, DELETE]","code":"invalid_request",` +
+				`"violations":[{"pointer":"/method","rule":"enum","message":"Invalid request: only the following methods are supported: [GET, PATCH, POST, PUT, DELETE]"}]}`,
 		},
 		{
 			description: "Negative case: invalid request body",
 			reqBody:     `{`,
 			expCode:     http.StatusBadRequest,
+			expBody:     `{"type":"about:blank","title":"Bad Request","status":400,"detail":"Error in unmarshalling JSON","code":"invalid_body"}`,
 		},
 		{
 			description: "Negative case: invalid request body",
@@ -77,6 +86,12 @@ func TestTask_CreateTask(t *testing.T) {
 			handler.CreateTask(w, r)
 
 			assert.Equal(t, tc.expCode, w.Code)
+			assert.Equal(t, tc.expLocation, w.Header().Get("Location"))
+
+			if tc.expBody != "" {
+				assert.Equal(t, "application/problem+json", w.Header().Get("Content-Type"))
+				assert.Equal(t, tc.expBody, w.Body.String())
+			}
 		})
 	}
 }
@@ -107,13 +122,30 @@ func TestTask_GetTask(t *testing.T) {
 			expCode:     http.StatusBadRequest,
 		},
 		{
-			description: "Negative case: error from service layer",
-			taskID:      "#!@!",
+			description: "Negative case: task not found",
+			taskID:      "12324",
 			mockCalls: []*gomock.Call{
-				taskServiceMock.EXPECT().TasksGet(gomock.Any(), "#!@!").
-					Return(nil, errors.New("error from service layer")),
+				taskServiceMock.EXPECT().TasksGet(gomock.Any(), "12324").
+					Return(nil, &service.Error{Kind: service.KindNotFound, Err: errors.New("Task not found")}),
 			},
-			expCode: http.StatusBadRequest,
+			expCode: http.StatusNotFound,
+		},
+		{
+			description: "Negative case: cache unavailable",
+			taskID:      "12325",
+			mockCalls: []*gomock.Call{
+				taskServiceMock.EXPECT().TasksGet(gomock.Any(), "12325").
+					Return(nil, &service.Error{Kind: service.KindUnavailable, Err: errors.New("dial tcp: connection refused")}),
+			},
+			expCode: http.StatusServiceUnavailable,
+		},
+		{
+			description: "Negative case: unexpected error",
+			taskID:      "12326",
+			mockCalls: []*gomock.Call{
+				taskServiceMock.EXPECT().TasksGet(gomock.Any(), "12326").Return(nil, errors.New("unexpected")),
+			},
+			expCode: http.StatusInternalServerError,
 		},
 	}
 
@@ -133,3 +165,91 @@ func TestTask_GetTask(t *testing.T) {
 		})
 	}
 }
+
+type nopSeekCloser struct {
+	*strings.Reader
+}
+
+func (nopSeekCloser) Close() error { return nil }
+
+func TestTask_GetTaskBody(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskServiceMock := service.NewMockTasks(ctrl)
+
+	testCases := []struct {
+		description    string
+		taskID         string
+		rangeHeader    string
+		mockCalls      []*gomock.Call
+		expCode        int
+		expBody        string
+		expContentType string
+	}{
+		{
+			description: "Positive case: whole body, served as binary whatever its content type",
+			taskID:      "12323",
+			mockCalls: []*gomock.Call{
+				taskServiceMock.EXPECT().TasksBody(gomock.Any(), "12323").
+					Return(&model.Blob{Key: "tasks/12323", Size: 10, ContentType: "text/html"},
+						nopSeekCloser{strings.NewReader(`[1,2,3,4]`)}, nil),
+			},
+			expCode:        http.StatusOK,
+			expBody:        `[1,2,3,4]`,
+			expContentType: "application/octet-stream",
+		},
+		{
+			description: "Positive case: range of the body",
+			taskID:      "12323",
+			rangeHeader: "bytes=1-3",
+			mockCalls: []*gomock.Call{
+				taskServiceMock.EXPECT().TasksBody(gomock.Any(), "12323").
+					Return(&model.Blob{Key: "tasks/12323", Size: 10}, nopSeekCloser{strings.NewReader(`[1,2,3,4]`)}, nil),
+			},
+			expCode:        http.StatusPartialContent,
+			expBody:        `1,2`,
+			expContentType: "application/octet-stream",
+		},
+		{
+			description: "Negative case: missing taskID",
+			expCode:     http.StatusBadRequest,
+		},
+		{
+			description: "Negative case: error from service layer",
+			taskID:      "12324",
+			mockCalls: []*gomock.Call{
+				taskServiceMock.EXPECT().TasksBody(gomock.Any(), "12324").
+					Return(nil, nil, &service.Error{Kind: service.KindNotFound, Err: errors.New("Task has no stored body")}),
+			},
+			expCode: http.StatusNotFound,
+		},
+	}
+
+	handler := New(taskServiceMock)
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/task/"+tc.taskID+"/body", nil)
+			r = mux.SetURLVars(r, map[string]string{"taskID": tc.taskID})
+			if tc.rangeHeader != "" {
+				r.Header.Set("Range", tc.rangeHeader)
+			}
+
+			w := httptest.NewRecorder()
+
+			handler.GetTaskBody(w, r)
+
+			assert.Equal(t, tc.expCode, w.Code)
+
+			if tc.expBody != "" {
+				assert.Equal(t, tc.expBody, w.Body.String())
+				assert.Equal(t, tc.expContentType, w.Header().Get(model.ContentType))
+				assert.Equal(t, "nosniff", w.Header().Get("X-Content-Type-Options"))
+				assert.Equal(t, "attachment", w.Header().Get("Content-Disposition"))
+			}
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/axxonsoft-assignment/pkg/model"
+	"github.com/axxonsoft-assignment/pkg/service"
+	"github.com/gorilla/mux"
+)
+
+type Template struct {
+	templatesService service.Templates
+}
+
+func NewTemplates(templatesService service.Templates) Templates {
+	return Template{templatesService: templatesService}
+}
+
+// SetTemplate handles incoming HTTP requests to create or replace a named task template
+func (tm Template) SetTemplate(w http.ResponseWriter, r *http.Request) {
+	// Initialize context
+	ctx := context.Background()
+
+	name, ok := templateNameParam(w, r)
+	if !ok {
+		return
+	}
+
+	var tmpl model.TaskTemplate
+	if !readJSON(w, r, &tmpl) {
+		return
+	}
+
+	resp, err := tm.templatesService.TemplatesSet(ctx, name, tmpl)
+	if err != nil {
+		writeError(w, err)
+
+		return
+	}
+
+	writeJSON(w, resp)
+}
+
+// GetTemplate handles incoming get HTTP requests, and returns the task template of that name.
+func (tm Template) GetTemplate(w http.ResponseWriter, r *http.Request) {
+	// Initialize context
+	ctx := context.Background()
+
+	name, ok := templateNameParam(w, r)
+	if !ok {
+		return
+	}
+
+	resp, err := tm.templatesService.TemplatesGet(ctx, name)
+	if err != nil {
+		writeError(w, err)
+
+		return
+	}
+
+	writeJSON(w, resp)
+}
+
+// ListTemplates handles incoming list HTTP requests, and returns all the task templates.
+func (tm Template) ListTemplates(w http.ResponseWriter, r *http.Request) {
+	// Initialize context
+	ctx := context.Background()
+
+	resp, err := tm.templatesService.TemplatesList(ctx)
+	if err != nil {
+		writeError(w, err)
+
+		return
+	}
+
+	writeJSON(w, resp)
+}
+
+// DeleteTemplate handles incoming delete HTTP requests, the tasks already created from the template are retained.
+func (tm Template) DeleteTemplate(w http.ResponseWriter, r *http.Request) {
+	// Initialize context
+	ctx := context.Background()
+
+	name, ok := templateNameParam(w, r)
+	if !ok {
+		return
+	}
+
+	if err := tm.templatesService.TemplatesDelete(ctx, name); err != nil {
+		writeError(w, err)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// CreateTaskFromTemplate handles incoming HTTP requests to create a task from a template with the given variables,
+// and returns the taskID.
+func (tm Template) CreateTaskFromTemplate(w http.ResponseWriter, r *http.Request) {
+	// Initialize context
+	ctx := context.Background()
+
+	name, ok := templateNameParam(w, r)
+	if !ok {
+		return
+	}
+
+	var variables model.TemplateVariables
+	if !readJSON(w, r, &variables) {
+		return
+	}
+
+	resp, err := tm.templatesService.TemplatesCreateTask(ctx, name, variables.Variables)
+	if err != nil {
+		writeError(w, err)
+
+		return
+	}
+
+	writeCreated(w, http.StatusAccepted, "/task/"+resp.ID, resp)
+}
+
+func templateNameParam(w http.ResponseWriter, r *http.Request) (string, bool) {
+	name := mux.Vars(r)["name"]
+	if name == "" {
+		writeProblem(w, http.StatusBadRequest, codeMissingParameter, "Missing value for the parameter: name", nil)
+
+		return "", false
+	}
+
+	return name, true
+}
+
+func readJSON(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	reqBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeProblem(w, http.StatusBadRequest, codeInvalidBody, "Error reading request body", nil)
+
+		return false
+	}
+
+	if err = json.Unmarshal(reqBody, v); err != nil {
+		writeProblem(w, http.StatusBadRequest, codeInvalidBody, "Error in unmarshalling JSON", nil)
+
+		return false
+	}
+
+	return true
+}
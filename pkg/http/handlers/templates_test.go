@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/axxonsoft-assignment/pkg/model"
+	"github.com/axxonsoft-assignment/pkg/service"
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTemplate_SetTemplate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	templatesServiceMock := service.NewMockTemplates(ctrl)
+
+	testCases := []struct {
+		description string
+		name        string
+		reqBody     string
+		mockCalls   []*gomock.Call
+		expCode     int
+	}{
+		{
+			description: "Positive case: valid request",
+			name:        "health",
+			reqBody:     `{"task":{"method":"GET","url":"https://{{.region}}.partner.com/health"}}`,
+			mockCalls: []*gomock.Call{
+				templatesServiceMock.EXPECT().TemplatesSet(gomock.Any(), "health", gomock.Any()).
+					Return(&model.TaskTemplate{Name: "health"}, nil),
+			},
+			expCode: http.StatusOK,
+		},
+		{
+			description: "Negative case: error from service layer",
+			name:        "health",
+			reqBody:     `{"task":{"method":"GET"}}`,
+			mockCalls: []*gomock.Call{
+				templatesServiceMock.EXPECT().TemplatesSet(gomock.Any(), "health", gomock.Any()).
+					Return(nil, &service.Error{Kind: service.KindValidation, Err: errors.New("error from service layer")}),
+			},
+			expCode: http.StatusBadRequest,
+		},
+		{
+			description: "Negative case: invalid request body",
+			name:        "health",
+			reqBody:     `{`,
+			expCode:     http.StatusBadRequest,
+		},
+	}
+
+	handler := NewTemplates(templatesServiceMock)
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPut, "/templates/"+tc.name, strings.NewReader(tc.reqBody))
+			r = mux.SetURLVars(r, map[string]string{"name": tc.name})
+			w := httptest.NewRecorder()
+
+			handler.SetTemplate(w, r)
+
+			assert.Equal(t, tc.expCode, w.Code)
+		})
+	}
+}
+
+func TestTemplate_CreateTaskFromTemplate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	templatesServiceMock := service.NewMockTemplates(ctrl)
+
+	testCases := []struct {
+		description string
+		name        string
+		reqBody     string
+		mockCalls   []*gomock.Call
+		expCode     int
+		expBody     string
+	}{
+		{
+			description: "Positive case: valid request",
+			name:        "health",
+			reqBody:     `{"variables":{"region":"eu"}}`,
+			mockCalls: []*gomock.Call{
+				templatesServiceMock.EXPECT().TemplatesCreateTask(gomock.Any(), "health", map[string]interface{}{"region": "eu"}).
+					Return(&model.TasksResponse{ID: "2313"}, nil),
+			},
+			expCode: http.StatusAccepted,
+			expBody: `{"id":"2313"}`,
+		},
+		{
+			description: "Negative case: error from service layer",
+			name:        "health",
+			reqBody:     `{"variables":{}}`,
+			mockCalls: []*gomock.Call{
+				templatesServiceMock.EXPECT().TemplatesCreateTask(gomock.Any(), "health", map[string]interface{}{}).
+					Return(nil, &service.Error{Kind: service.KindNotFound, Err: errors.New("Template not found")}),
+			},
+			expCode: http.StatusNotFound,
+			expBody: `{"type":"about:blank","title":"Not Found","status":404,"detail":"Template not found","code":"not_found"}`,
+		},
+		{
+			description: "Negative case: missing name",
+			reqBody:     `{"variables":{}}`,
+			expCode:     http.StatusBadRequest,
+			expBody:     `{"type":"about:blank","title":"Bad Request","status":400,"detail":"Missing value for the parameter: name","code":"missing_parameter"}`,
+		},
+	}
+
+	handler := NewTemplates(templatesServiceMock)
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/task/from-template/"+tc.name, strings.NewReader(tc.reqBody))
+			r = mux.SetURLVars(r, map[string]string{"name": tc.name})
+			w := httptest.NewRecorder()
+
+			handler.CreateTaskFromTemplate(w, r)
+
+			assert.Equal(t, tc.expCode, w.Code)
+			assert.Equal(t, tc.expBody, w.Body.String())
+		})
+	}
+}
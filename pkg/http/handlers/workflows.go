@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/axxonsoft-assignment/pkg/model"
+	"github.com/axxonsoft-assignment/pkg/service"
+	"github.com/gorilla/mux"
+)
+
+type Workflow struct {
+	workflowsService service.Workflows
+}
+
+func NewWorkflows(workflowsService service.Workflows) Workflows {
+	return Workflow{workflowsService: workflowsService}
+}
+
+// CreateWorkflow handles incoming create HTTP requests for a workflow of tasks and returns the created workflow
+func (wf Workflow) CreateWorkflow(w http.ResponseWriter, r *http.Request) {
+	// Initialize context
+	ctx := context.Background()
+
+	var workflow model.Workflow
+	if !readJSON(w, r, &workflow) {
+		return
+	}
+
+	resp, err := wf.workflowsService.WorkflowsCreate(ctx, workflow)
+	if err != nil {
+		writeError(w, err)
+
+		return
+	}
+
+	// the nodes are run in the background
+	writeCreated(w, http.StatusAccepted, "/workflows/"+resp.ID, resp)
+}
+
+// GetWorkflow handles incoming get HTTP requests, and returns the graph of the workflow along with the status and
+// task details of its nodes.
+func (wf Workflow) GetWorkflow(w http.ResponseWriter, r *http.Request) {
+	// Initialize context
+	ctx := context.Background()
+
+	workflowID := mux.Vars(r)["workflowID"]
+	if workflowID == "" {
+		writeProblem(w, http.StatusBadRequest, codeMissingParameter, "Missing value for the parameter: workflowID", nil)
+
+		return
+	}
+
+	resp, err := wf.workflowsService.WorkflowsGet(ctx, workflowID)
+	if err != nil {
+		writeError(w, err)
+
+		return
+	}
+
+	writeJSON(w, resp)
+}
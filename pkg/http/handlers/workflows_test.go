@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/axxonsoft-assignment/pkg/model"
+	"github.com/axxonsoft-assignment/pkg/service"
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkflow_CreateWorkflow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	workflowsServiceMock := service.NewMockWorkflows(ctrl)
+
+	testCases := []struct {
+		description string
+		reqBody     string
+		mockCalls   []*gomock.Call
+		expCode     int
+	}{
+		{
+			description: "Positive case: valid request",
+			reqBody:     `{"nodes":[{"id":"a","task":{"method":"GET","url":"https://www.getyourtasks.com/a"}}]}`,
+			mockCalls: []*gomock.Call{
+				workflowsServiceMock.EXPECT().WorkflowsCreate(gomock.Any(), gomock.Any()).
+					Return(&model.Workflow{ID: "workflow-1", Status: model.New}, nil),
+			},
+			expCode: http.StatusAccepted,
+		},
+		{
+			description: "Negative case: error from service layer",
+			reqBody:     `{"nodes":[]}`,
+			mockCalls: []*gomock.Call{
+				workflowsServiceMock.EXPECT().WorkflowsCreate(gomock.Any(), gomock.Any()).
+					Return(nil, &service.Error{Kind: service.KindValidation, Err: errors.New("Invalid request: nodes cannot be empty")}),
+			},
+			expCode: http.StatusBadRequest,
+		},
+		{
+			description: "Negative case: invalid request body",
+			reqBody:     `{`,
+			expCode:     http.StatusBadRequest,
+		},
+	}
+
+	handler := NewWorkflows(workflowsServiceMock)
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/workflows", strings.NewReader(tc.reqBody))
+			w := httptest.NewRecorder()
+
+			handler.CreateWorkflow(w, r)
+
+			assert.Equal(t, tc.expCode, w.Code)
+		})
+	}
+}
+
+func TestWorkflow_GetWorkflow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	workflowsServiceMock := service.NewMockWorkflows(ctrl)
+	workflowsServiceMock.EXPECT().WorkflowsGet(gomock.Any(), "workflow-1").
+		Return(&model.Workflow{ID: "workflow-1", Status: model.InProcess}, nil)
+
+	handler := NewWorkflows(workflowsServiceMock)
+
+	r := httptest.NewRequest(http.MethodGet, "/workflows/workflow-1", nil)
+	r = mux.SetURLVars(r, map[string]string{"workflowID": "workflow-1"})
+	w := httptest.NewRecorder()
+
+	handler.GetWorkflow(w, r)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `{"id":"workflow-1","status":"in_process","nodes":null}`, w.Body.String())
+}
@@ -7,7 +7,41 @@ import (
 	"github.com/gorilla/mux"
 )
 
-func New(router *mux.Router, handler handlers.Tasks) {
+func New(router *mux.Router, handler handlers.Tasks, schedulesHandler handlers.Schedules, rateLimitsHandler handlers.RateLimits,
+	breakersHandler handlers.Breakers, templatesHandler handlers.Templates, chainsHandler handlers.Chains, workflowsHandler handlers.Workflows) {
 	router.HandleFunc("/task", handler.CreateTask).Methods(http.MethodPost)
 	router.HandleFunc("/task/{taskID}", handler.GetTask).Methods(http.MethodGet)
+	router.HandleFunc("/task/{taskID}/body", handler.GetTaskBody).Methods(http.MethodGet)
+	router.HandleFunc("/task/from-template/{name}", templatesHandler.CreateTaskFromTemplate).Methods(http.MethodPost)
+
+	router.HandleFunc("/schedules", schedulesHandler.CreateSchedule).Methods(http.MethodPost)
+	router.HandleFunc("/schedules", schedulesHandler.ListSchedules).Methods(http.MethodGet)
+	router.HandleFunc("/schedules/{scheduleID}", schedulesHandler.GetSchedule).Methods(http.MethodGet)
+	router.HandleFunc("/schedules/{scheduleID}", schedulesHandler.UpdateSchedule).Methods(http.MethodPut)
+	router.HandleFunc("/schedules/{scheduleID}", schedulesHandler.DeleteSchedule).Methods(http.MethodDelete)
+	router.HandleFunc("/schedules/{scheduleID}/pause", schedulesHandler.PauseSchedule).Methods(http.MethodPost)
+	router.HandleFunc("/schedules/{scheduleID}/resume", schedulesHandler.ResumeSchedule).Methods(http.MethodPost)
+	router.HandleFunc("/schedules/{scheduleID}/history", schedulesHandler.GetScheduleHistory).Methods(http.MethodGet)
+
+	router.HandleFunc("/chains", chainsHandler.CreateChain).Methods(http.MethodPost)
+	router.HandleFunc("/chains/{chainID}", chainsHandler.GetChain).Methods(http.MethodGet)
+
+	router.HandleFunc("/workflows", workflowsHandler.CreateWorkflow).Methods(http.MethodPost)
+	router.HandleFunc("/workflows/{workflowID}", workflowsHandler.GetWorkflow).Methods(http.MethodGet)
+
+	router.HandleFunc("/templates", templatesHandler.ListTemplates).Methods(http.MethodGet)
+	router.HandleFunc("/templates/{name}", templatesHandler.GetTemplate).Methods(http.MethodGet)
+	router.HandleFunc("/templates/{name}", templatesHandler.SetTemplate).Methods(http.MethodPut)
+	router.HandleFunc("/templates/{name}", templatesHandler.DeleteTemplate).Methods(http.MethodDelete)
+
+	router.HandleFunc("/ratelimits", rateLimitsHandler.ListRateLimits).Methods(http.MethodGet)
+	router.HandleFunc("/ratelimits/{host}", rateLimitsHandler.GetRateLimit).Methods(http.MethodGet)
+	router.HandleFunc("/ratelimits/{host}", rateLimitsHandler.SetRateLimit).Methods(http.MethodPut)
+	router.HandleFunc("/ratelimits/{host}", rateLimitsHandler.DeleteRateLimit).Methods(http.MethodDelete)
+
+	router.HandleFunc("/admin/breakers", breakersHandler.ListBreakers).Methods(http.MethodGet)
+	router.HandleFunc("/admin/breakers/{host}", breakersHandler.GetBreaker).Methods(http.MethodGet)
+	router.HandleFunc("/admin/breakers/{host}/reset", breakersHandler.ResetBreaker).Methods(http.MethodPost)
+
+	router.HandleFunc("/openapi.json", handlers.OpenAPI).Methods(http.MethodGet)
 }
@@ -0,0 +1,266 @@
+package routes
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/axxonsoft-assignment/pkg/http/handlers"
+	"github.com/axxonsoft-assignment/pkg/model"
+	"github.com/axxonsoft-assignment/pkg/openapi"
+	"github.com/axxonsoft-assignment/pkg/service"
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+type nopSeekCloser struct {
+	*strings.Reader
+}
+
+func (nopSeekCloser) Close() error {
+	return nil
+}
+
+// newRouter gives the router of the service, its services answering every call with a sample of their model types
+func newRouter(ctrl *gomock.Controller) *mux.Router {
+	now := time.Now()
+	statusCode := http.StatusOK
+	length := int64(17)
+
+	taskObj := &model.TasksObject{ID: "42", Status: model.Done, HTTPStatusCode: &statusCode,
+		Headers: http.Header{"Content-Type": {"application/json"}}, Length: &length, WireLength: &length,
+		Outputs: map[string]interface{}{"token": "abc"}, Blob: &model.Blob{Key: "tasks/42", Size: 17},
+		Attempts: []model.PollAttempt{{At: now, HTTPStatusCode: &statusCode, Matched: true}},
+		Error:    &model.TaskError{Code: model.ErrCodeCallFailed, Message: "timeout"}}
+	task := model.Task{Method: "GET", URL: "https://www.getyourtasks.com/health",
+		Headers: map[string]interface{}{model.ContentType: "application/json"}}
+	schedule := &model.Schedule{ID: "s1", Task: task, Cron: "*/5 * * * *", LastRunAt: &now, NextRunAt: &now}
+
+	tasksMock := service.NewMockTasks(ctrl)
+	tasksMock.EXPECT().TasksCreate(gomock.Any(), gomock.Any()).Return(&model.TasksResponse{ID: "42"}, nil).AnyTimes()
+	tasksMock.EXPECT().TasksGet(gomock.Any(), gomock.Any()).Return(taskObj, nil).AnyTimes()
+	tasksMock.EXPECT().TasksBody(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(interface{}, interface{}) (*model.Blob, interface{}, error) {
+			return &model.Blob{Key: "tasks/42", Size: 17, ContentType: "application/octet-stream"},
+				nopSeekCloser{strings.NewReader(`{"items": [1, 2]}`)}, nil
+		}).AnyTimes()
+
+	schedulesMock := service.NewMockSchedules(ctrl)
+	schedulesMock.EXPECT().SchedulesCreate(gomock.Any(), gomock.Any()).Return(schedule, nil).AnyTimes()
+	schedulesMock.EXPECT().SchedulesGet(gomock.Any(), gomock.Any()).Return(schedule, nil).AnyTimes()
+	schedulesMock.EXPECT().SchedulesList(gomock.Any()).Return([]*model.Schedule{schedule}, nil).AnyTimes()
+	schedulesMock.EXPECT().SchedulesUpdate(gomock.Any(), gomock.Any(), gomock.Any()).Return(schedule, nil).AnyTimes()
+	schedulesMock.EXPECT().SchedulesDelete(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	schedulesMock.EXPECT().SchedulesPause(gomock.Any(), gomock.Any()).Return(schedule, nil).AnyTimes()
+	schedulesMock.EXPECT().SchedulesResume(gomock.Any(), gomock.Any()).Return(schedule, nil).AnyTimes()
+	schedulesMock.EXPECT().SchedulesHistory(gomock.Any(), gomock.Any()).
+		Return([]*model.ScheduleRun{{TaskID: "42", ScheduledAt: now, CreatedAt: now}}, nil).AnyTimes()
+
+	rateLimit := &model.RateLimit{Host: "api.partner.com", Rate: 5, Burst: 10}
+
+	rateLimitsMock := service.NewMockRateLimits(ctrl)
+	rateLimitsMock.EXPECT().RateLimitsSet(gomock.Any(), gomock.Any(), gomock.Any()).Return(rateLimit, nil).AnyTimes()
+	rateLimitsMock.EXPECT().RateLimitsGet(gomock.Any(), gomock.Any()).Return(rateLimit, nil).AnyTimes()
+	rateLimitsMock.EXPECT().RateLimitsList(gomock.Any()).Return([]*model.RateLimit{rateLimit}, nil).AnyTimes()
+	rateLimitsMock.EXPECT().RateLimitsDelete(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	circuit := model.CircuitState{Host: "api.partner.com", State: "open", OpenedAt: &now, RetryAt: &now}
+
+	breakersMock := service.NewMockBreakers(ctrl)
+	breakersMock.EXPECT().BreakersList(gomock.Any()).Return([]model.CircuitState{circuit}).AnyTimes()
+	breakersMock.EXPECT().BreakersGet(gomock.Any(), gomock.Any()).Return(circuit).AnyTimes()
+	breakersMock.EXPECT().BreakersReset(gomock.Any(), gomock.Any()).Return(circuit).AnyTimes()
+
+	tmpl := &model.TaskTemplate{Name: "health", Task: task}
+
+	templatesMock := service.NewMockTemplates(ctrl)
+	templatesMock.EXPECT().TemplatesSet(gomock.Any(), gomock.Any(), gomock.Any()).Return(tmpl, nil).AnyTimes()
+	templatesMock.EXPECT().TemplatesGet(gomock.Any(), gomock.Any()).Return(tmpl, nil).AnyTimes()
+	templatesMock.EXPECT().TemplatesList(gomock.Any()).Return([]*model.TaskTemplate{tmpl}, nil).AnyTimes()
+	templatesMock.EXPECT().TemplatesDelete(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	templatesMock.EXPECT().TemplatesCreateTask(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(&model.TasksResponse{ID: "42"}, nil).AnyTimes()
+
+	chain := &model.Chain{ID: "c1", Status: model.Done, Steps: []model.ChainStep{{Name: "login", Task: task,
+		Extract: map[string]model.ExtractRule{"token": {From: model.ExtractFromBody, Path: "$.token"}}, TaskID: "42", Result: taskObj}}}
+
+	chainsMock := service.NewMockChains(ctrl)
+	chainsMock.EXPECT().ChainsCreate(gomock.Any(), gomock.Any()).Return(chain, nil).AnyTimes()
+	chainsMock.EXPECT().ChainsGet(gomock.Any(), gomock.Any()).Return(chain, nil).AnyTimes()
+
+	workflow := &model.Workflow{ID: "w1", Status: model.Done, Nodes: []model.WorkflowNode{{ID: "a", Task: task,
+		DependsOn: []model.Dependency{{Node: "b"}}, Status: model.Done, TaskID: "42", Result: taskObj}}}
+
+	workflowsMock := service.NewMockWorkflows(ctrl)
+	workflowsMock.EXPECT().WorkflowsCreate(gomock.Any(), gomock.Any()).Return(workflow, nil).AnyTimes()
+	workflowsMock.EXPECT().WorkflowsGet(gomock.Any(), gomock.Any()).Return(workflow, nil).AnyTimes()
+
+	router := mux.NewRouter()
+	New(router, handlers.New(tasksMock), handlers.NewSchedules(schedulesMock), handlers.NewRateLimits(rateLimitsMock),
+		handlers.NewBreakers(breakersMock), handlers.NewTemplates(templatesMock), handlers.NewChains(chainsMock),
+		handlers.NewWorkflows(workflowsMock))
+
+	return router
+}
+
+func TestRoutes_OpenAPIPaths(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var registered []string
+
+	err := newRouter(ctrl).Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		path, err := route.GetPathTemplate()
+		if err != nil {
+			return err
+		}
+
+		methods, err := route.GetMethods()
+		if err != nil {
+			return err
+		}
+
+		for _, method := range methods {
+			registered = append(registered, method+" "+path)
+		}
+
+		return nil
+	})
+	assert.Nil(t, err)
+
+	var documented []string
+
+	for path, item := range openapi.New().Paths {
+		for method := range item {
+			documented = append(documented, strings.ToUpper(method)+" "+path)
+		}
+	}
+
+	sort.Strings(registered)
+	sort.Strings(documented)
+
+	assert.Equal(t, registered, documented)
+}
+
+func TestRoutes_OpenAPIResponses(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	router := newRouter(ctrl)
+	doc := openapi.New()
+
+	for path, item := range doc.Paths {
+		for method, operation := range item {
+			path, method, operation := path, strings.ToUpper(method), operation
+
+			t.Run(method+" "+path, func(t *testing.T) {
+				target := path
+				for _, param := range operation.Parameters {
+					target = strings.ReplaceAll(target, "{"+param.Name+"}", "x")
+				}
+
+				body := ""
+				if operation.RequestBody != nil {
+					body = `{}`
+				}
+
+				r := httptest.NewRequest(method, target, strings.NewReader(body))
+				w := httptest.NewRecorder()
+
+				router.ServeHTTP(w, r)
+
+				response, ok := operation.Responses[strconv.Itoa(w.Code)]
+				if !assert.True(t, ok, "status %d is not documented", w.Code) {
+					return
+				}
+
+				if response.Content == nil {
+					assert.Empty(t, w.Body.String())
+
+					return
+				}
+
+				contentType := w.Header().Get("Content-Type")
+				if !assert.Contains(t, response.Content, contentType) {
+					return
+				}
+
+				schema := response.Content[contentType].Schema
+				if schema.Format == "binary" {
+					return
+				}
+
+				var value interface{}
+				assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &value))
+
+				for _, violation := range conform(doc, schema, value, "") {
+					t.Error(violation)
+				}
+			})
+		}
+	}
+}
+
+// conform gives the places where the decoded JSON value does not conform to the schema, null conforming to any schema
+func conform(doc *openapi.Document, schema *openapi.Schema, value interface{}, pointer string) []string {
+	if schema.Ref != "" {
+		return conform(doc, doc.Components.Schemas[strings.TrimPrefix(schema.Ref, "#/components/schemas/")], value, pointer)
+	}
+
+	if value == nil || schema.Type == "" {
+		return nil
+	}
+
+	var violations []string
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if schema.Type != "object" {
+			return []string{fmt.Sprintf("%s: an object is not a %s", pointer, schema.Type)}
+		}
+
+		for key, item := range v {
+			itemSchema := schema.AdditionalProperties
+			if schema.Properties != nil {
+				itemSchema = schema.Properties[key]
+			}
+
+			if itemSchema == nil {
+				violations = append(violations, fmt.Sprintf("%s/%s: undocumented property", pointer, key))
+
+				continue
+			}
+
+			violations = append(violations, conform(doc, itemSchema, item, pointer+"/"+key)...)
+		}
+	case []interface{}:
+		if schema.Type != "array" {
+			return []string{fmt.Sprintf("%s: an array is not a %s", pointer, schema.Type)}
+		}
+
+		for i, item := range v {
+			violations = append(violations, conform(doc, schema.Items, item, pointer+"/"+strconv.Itoa(i))...)
+		}
+	case string:
+		if schema.Type != "string" {
+			return []string{fmt.Sprintf("%s: a string is not a %s", pointer, schema.Type)}
+		}
+	case float64:
+		if schema.Type != "number" && schema.Type != "integer" {
+			return []string{fmt.Sprintf("%s: a number is not a %s", pointer, schema.Type)}
+		}
+	case bool:
+		if schema.Type != "boolean" {
+			return []string{fmt.Sprintf("%s: a boolean is not a %s", pointer, schema.Type)}
+		}
+	}
+
+	return violations
+}
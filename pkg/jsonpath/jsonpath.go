@@ -0,0 +1,131 @@
+// Package jsonpath evaluates the subset of JSONPath selecting a single value of a decoded JSON document:
+// the root $ followed by .name, ['name'] and [index] segments, a negative index counting from the end.
+package jsonpath
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrNotFound is returned when the document has no value at the path
+var ErrNotFound = errors.New("no value at path")
+
+type segment struct {
+	name  string
+	index int
+	isIdx bool
+}
+
+// Path is a parsed JSONPath expression
+type Path struct {
+	raw      string
+	segments []segment
+}
+
+// Parse parses the expression, e.g. $.data.items[0]['access_token']
+func Parse(expr string) (*Path, error) {
+	if !strings.HasPrefix(expr, "$") {
+		return nil, errors.New("Invalid JSONPath " + expr + ": should start with $")
+	}
+
+	p := &Path{raw: expr}
+	rest := expr[1:]
+
+	for rest != "" {
+		switch rest[0] {
+		case '.':
+			end := strings.IndexAny(rest[1:], ".[")
+			if end < 0 {
+				end = len(rest) - 1
+			}
+
+			name := rest[1 : end+1]
+			if name == "" {
+				return nil, errors.New("Invalid JSONPath " + expr + ": empty name")
+			}
+
+			p.segments = append(p.segments, segment{name: name})
+			rest = rest[end+1:]
+		case '[':
+			end := strings.Index(rest, "]")
+			if end < 0 {
+				return nil, errors.New("Invalid JSONPath " + expr + ": missing ]")
+			}
+
+			inner := rest[1:end]
+
+			if len(inner) >= 2 && (inner[0] == '\'' || inner[0] == '"') && inner[len(inner)-1] == inner[0] {
+				p.segments = append(p.segments, segment{name: inner[1 : len(inner)-1]})
+			} else {
+				index, err := strconv.Atoi(inner)
+				if err != nil {
+					return nil, errors.New("Invalid JSONPath " + expr + ": " + inner + " is neither an index nor a quoted name")
+				}
+
+				p.segments = append(p.segments, segment{index: index, isIdx: true})
+			}
+
+			rest = rest[end+1:]
+		default:
+			return nil, errors.New("Invalid JSONPath " + expr + ": unexpected " + string(rest[0]))
+		}
+	}
+
+	return p, nil
+}
+
+// String gives the expression the path was parsed from
+func (p *Path) String() string {
+	return p.raw
+}
+
+// Get gives the value of the document at the path
+func (p *Path) Get(doc interface{}) (interface{}, error) {
+	value := doc
+
+	for _, seg := range p.segments {
+		if seg.isIdx {
+			items, ok := value.([]interface{})
+			if !ok {
+				return nil, fmt.Errorf("%w %s: not an array", ErrNotFound, p.raw)
+			}
+
+			index := seg.index
+			if index < 0 {
+				index += len(items)
+			}
+
+			if index < 0 || index >= len(items) {
+				return nil, fmt.Errorf("%w %s: index %d out of range", ErrNotFound, p.raw, seg.index)
+			}
+
+			value = items[index]
+
+			continue
+		}
+
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%w %s: not an object", ErrNotFound, p.raw)
+		}
+
+		value, ok = object[seg.name]
+		if !ok {
+			return nil, fmt.Errorf("%w %s: no member %s", ErrNotFound, p.raw, seg.name)
+		}
+	}
+
+	return value, nil
+}
+
+// Get parses the expression and gives the value of the document at it
+func Get(doc interface{}, expr string) (interface{}, error) {
+	p, err := Parse(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.Get(doc)
+}
@@ -0,0 +1,82 @@
+package jsonpath
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSONPath_Get(t *testing.T) {
+	var doc interface{}
+	_ = json.Unmarshal([]byte(`{"data": {"items": [{"id": 1}, {"id": 2}], "access.token": "abc"}, "count": 2}`), &doc)
+
+	tcs := []struct {
+		description string
+		expr        string
+		expValue    interface{}
+		expErr      string
+	}{
+		{
+			description: "Positive case: root",
+			expr:        "$",
+			expValue:    doc,
+		},
+		{
+			description: "Positive case: nested member and index",
+			expr:        "$.data.items[1].id",
+			expValue:    2.0,
+		},
+		{
+			description: "Positive case: negative index and quoted name",
+			expr:        "$['data'].items[-1]['id']",
+			expValue:    2.0,
+		},
+		{
+			description: "Positive case: quoted name with a dot",
+			expr:        `$.data["access.token"]`,
+			expValue:    "abc",
+		},
+		{
+			description: "Negative case: missing member",
+			expr:        "$.data.next",
+			expErr:      "no value at path $.data.next: no member next",
+		},
+		{
+			description: "Negative case: index out of range",
+			expr:        "$.data.items[2]",
+			expErr:      "no value at path $.data.items[2]: index 2 out of range",
+		},
+		{
+			description: "Negative case: invalid expression",
+			expr:        "data.items",
+			expErr:      "Invalid JSONPath data.items: should start with $",
+		},
+		{
+			description: "Negative case: unclosed bracket",
+			expr:        "$.items[0",
+			expErr:      "Invalid JSONPath $.items[0: missing ]",
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			value, err := Get(doc, tc.expr)
+
+			if tc.expErr != "" {
+				assert.EqualError(t, err, tc.expErr)
+
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expValue, value)
+		})
+	}
+
+	_, err := Get(doc, "$.count.value")
+	assert.True(t, errors.Is(err, ErrNotFound))
+}
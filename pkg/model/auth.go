@@ -0,0 +1,72 @@
+package model
+
+import (
+	"net/url"
+	"time"
+)
+
+// Auth represents the credentials applied to the outbound call of a task, instead of baking them into raw headers
+type Auth struct {
+	// Type is one of [basic, bearer, apiKey, oauth2]
+	Type string `json:"type"`
+
+	// Username and Password of the basic scheme
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+
+	// Token of the bearer scheme
+	Token string `json:"token,omitempty"`
+
+	// Name and Value of the API key, sent in a header or a query parameter as per In, defaults to header
+	Name  string `json:"name,omitempty"`
+	Value string `json:"value,omitempty"`
+	In    string `json:"in,omitempty"`
+
+	// TokenURL, ClientID, ClientSecret and Scopes of the OAuth2 client credentials grant
+	TokenURL     string   `json:"tokenUrl,omitempty"`
+	ClientID     string   `json:"clientId,omitempty"`
+	ClientSecret string   `json:"clientSecret,omitempty"`
+	Scopes       []string `json:"scopes,omitempty"`
+}
+
+// OAuthToken represents an access token issued by an OAuth2 token endpoint, cached till it expires
+type OAuthToken struct {
+	AccessToken string    `json:"accessToken"`
+	TokenType   string    `json:"tokenType"`
+	ExpiresAt   time.Time `json:"expiresAt"`
+}
+
+func validateAuth(auth *Auth, v *violations) {
+	switch auth.Type {
+	case AuthBasic:
+		if auth.Username == "" {
+			v.add("/auth/username", RuleRequired, "Invalid request: auth username cannot be empty")
+		}
+	case AuthBearer:
+		if auth.Token == "" {
+			v.add("/auth/token", RuleRequired, "Invalid request: auth token cannot be empty")
+		}
+	case AuthAPIKey:
+		if auth.Name == "" {
+			v.add("/auth/name", RuleRequired, "Invalid request: auth name and value cannot be empty")
+		} else if auth.Value == "" {
+			v.add("/auth/value", RuleRequired, "Invalid request: auth name and value cannot be empty")
+		}
+
+		if auth.In != "" && auth.In != APIKeyInHeader && auth.In != APIKeyInQuery {
+			v.add("/auth/in", RuleEnum, "Invalid request: auth in should be one of [header, query]")
+		}
+	case AuthOAuth2:
+		if auth.ClientID == "" {
+			v.add("/auth/clientId", RuleRequired, "Invalid request: auth clientId and clientSecret cannot be empty")
+		} else if auth.ClientSecret == "" {
+			v.add("/auth/clientSecret", RuleRequired, "Invalid request: auth clientId and clientSecret cannot be empty")
+		}
+
+		if tokenURL, err := url.Parse(auth.TokenURL); err != nil || !isValidScheme(tokenURL.Scheme) || tokenURL.Host == "" {
+			v.add("/auth/tokenUrl", RuleFormat, "Invalid request: auth tokenUrl should be an absolute http or https url")
+		}
+	default:
+		v.add("/auth/type", RuleEnum, "Invalid request: auth type should be one of [basic, bearer, apiKey, oauth2]")
+	}
+}
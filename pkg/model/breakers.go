@@ -0,0 +1,14 @@
+package model
+
+import "time"
+
+// CircuitState represents the state of the circuit breaker of a target host
+type CircuitState struct {
+	Host                string     `json:"host"`
+	State               string     `json:"state"`
+	ConsecutiveFailures int        `json:"consecutiveFailures"`
+	Requests            int        `json:"requests"`
+	Failures            int        `json:"failures"`
+	OpenedAt            *time.Time `json:"openedAt,omitempty"`
+	RetryAt             *time.Time `json:"retryAt,omitempty"`
+}
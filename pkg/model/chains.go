@@ -0,0 +1,53 @@
+package model
+
+import "strconv"
+
+// MaxChainSteps caps the number of steps of a chain
+const MaxChainSteps = 20
+
+// Chain represents tasks run one after the other, each step's task being rendered as a template with the values
+// extracted from the responses of the previous steps, e.g. a login step's token in {{.token}}
+type Chain struct {
+	ID     string      `json:"id,omitempty"`
+	Status string      `json:"status,omitempty"`
+	Steps  []ChainStep `json:"steps"`
+	Error  *TaskError  `json:"error,omitempty"`
+}
+
+// ChainStep represents a step of a chain along with the task it created. The extracted values are not stored,
+// as they are often credentials.
+type ChainStep struct {
+	Name    string                 `json:"name,omitempty"`
+	Task    Task                   `json:"task"`
+	Extract map[string]ExtractRule `json:"extract,omitempty"`
+	TaskID  string                 `json:"taskId,omitempty"`
+	Result  *TasksObject           `json:"result,omitempty"`
+}
+
+// ValidateChain checks the number of steps, the syntax of their placeholders and their extract rules, the rendered
+// tasks are validated as any other task when their step is run
+func ValidateChain(chain Chain) error {
+	var v violations
+
+	if len(chain.Steps) == 0 {
+		v.add("/steps", RuleRequired, "Invalid request: steps cannot be empty")
+	}
+
+	if len(chain.Steps) > MaxChainSteps {
+		v.add("/steps", RuleRange, "Invalid request: a chain cannot have more than "+strconv.Itoa(MaxChainSteps)+" steps")
+	}
+
+	for i, step := range chain.Steps {
+		pointer := "/steps/" + strconv.Itoa(i)
+		prefix := "Invalid step " + strconv.Itoa(i) + ": "
+
+		v.nest(pointer, prefix, ValidateTemplate(TaskTemplate{Name: "step", Task: step.Task}))
+
+		var extract violations
+
+		validateExtract(step.Extract, &extract)
+		v.nest(pointer, prefix, extract.err())
+	}
+
+	return v.err()
+}
@@ -0,0 +1,68 @@
+package model
+
+import (
+	"errors"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// ConcurrencyLimit represents the maximum number of in-flight calls to every host matching the pattern
+type ConcurrencyLimit struct {
+	Pattern string `json:"pattern"`
+	Limit   int    `json:"limit"`
+}
+
+// ParseConcurrencyLimits parses comma separated pattern=limit pairs, e.g. "*.partner.com=5,api.other.com=2"
+func ParseConcurrencyLimits(raw string) ([]ConcurrencyLimit, error) {
+	var limits []ConcurrencyLimit
+
+	for _, pair := range strings.Split(raw, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		pattern, value, found := strings.Cut(pair, "=")
+		if !found {
+			return nil, errors.New("Invalid concurrency limit: expected pattern=limit, found " + pair)
+		}
+
+		pattern = strings.ToLower(strings.TrimSpace(pattern))
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, errors.New("Invalid concurrency limit: malformed host pattern " + pattern)
+		}
+
+		limit, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || limit < 1 {
+			return nil, errors.New("Invalid concurrency limit: limit of " + pattern + " should be a positive number")
+		}
+
+		limits = append(limits, ConcurrencyLimit{Pattern: pattern, Limit: limit})
+	}
+
+	return limits, nil
+}
+
+// MatchConcurrencyLimit finds the limit applicable to the host, an exact host match wins over a glob,
+// and among globs the longest pattern wins.
+func MatchConcurrencyLimit(limits []ConcurrencyLimit, host string) (ConcurrencyLimit, bool) {
+	var (
+		match ConcurrencyLimit
+		found bool
+	)
+
+	host = strings.ToLower(host)
+
+	for _, limit := range limits {
+		if limit.Pattern == host {
+			return limit, true
+		}
+
+		if ok, _ := path.Match(limit.Pattern, host); ok && len(limit.Pattern) > len(match.Pattern) {
+			match, found = limit, true
+		}
+	}
+
+	return match, found
+}
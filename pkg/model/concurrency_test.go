@@ -0,0 +1,75 @@
+package model
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConcurrency_ParseConcurrencyLimits(t *testing.T) {
+	tcs := []struct {
+		description string
+		raw         string
+		resp        []ConcurrencyLimit
+		expErr      error
+	}{
+		{
+			description: "Positive case: valid limits",
+			raw:         "*.Partner.com=5, api.other.com=2",
+			resp:        []ConcurrencyLimit{{Pattern: "*.partner.com", Limit: 5}, {Pattern: "api.other.com", Limit: 2}},
+		},
+		{
+			description: "Positive case: no limits",
+		},
+		{
+			description: "Negative case: missing limit",
+			raw:         "*.partner.com",
+			expErr:      errors.New("Invalid concurrency limit: expected pattern=limit, found *.partner.com"),
+		},
+		{
+			description: "Negative case: invalid limit",
+			raw:         "*.partner.com=0",
+			expErr:      errors.New("Invalid concurrency limit: limit of *.partner.com should be a positive number"),
+		},
+		{
+			description: "Negative case: malformed pattern",
+			raw:         "[a.partner.com=1",
+			expErr:      errors.New("Invalid concurrency limit: malformed host pattern [a.partner.com"),
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			resp, err := ParseConcurrencyLimits(tc.raw)
+
+			assert.Equal(t, tc.expErr, err)
+			assert.Equal(t, tc.resp, resp)
+		})
+	}
+}
+
+func TestConcurrency_MatchConcurrencyLimit(t *testing.T) {
+	limits := []ConcurrencyLimit{
+		{Pattern: "*", Limit: 10},
+		{Pattern: "*.partner.com", Limit: 5},
+		{Pattern: "api.partner.com", Limit: 2},
+	}
+
+	limit, ok := MatchConcurrencyLimit(limits, "API.partner.com")
+	assert.True(t, ok)
+	assert.Equal(t, 2, limit.Limit)
+
+	limit, ok = MatchConcurrencyLimit(limits, "eu.partner.com")
+	assert.True(t, ok)
+	assert.Equal(t, 5, limit.Limit)
+
+	limit, ok = MatchConcurrencyLimit(limits, "example.com")
+	assert.True(t, ok)
+	assert.Equal(t, 10, limit.Limit)
+
+	_, ok = MatchConcurrencyLimit(limits[1:], "example.com")
+	assert.False(t, ok)
+}
@@ -4,8 +4,85 @@ const (
 	// statuses
 	New       = "new"
 	InProcess = "in_process"
+	Throttled = "throttled"
+	Queued    = "queued"
 	Done      = "done"
 	Error     = "error"
+	Skipped   = "skipped"
+	Polling   = "polling"
+
+	// missed run policies of a schedule
+	MissedRunSkip    = "skip"
+	MissedRunCatchUp = "catch_up"
+
+	// circuit breaker states
+	CircuitClosed   = "closed"
+	CircuitOpen     = "open"
+	CircuitHalfOpen = "half_open"
+
+	// redirect policies of a task
+	RedirectsNone       = "none"
+	RedirectsSameHost   = "same-host"
+	RedirectsAny        = "any"
+	DefaultMaxRedirects = 10
+
+	// policy rule actions
+	PolicyAllow = "allow"
+	PolicyDeny  = "deny"
+
+	// auth schemes of a task
+	AuthBasic      = "basic"
+	AuthBearer     = "bearer"
+	AuthAPIKey     = "apiKey"
+	AuthOAuth2     = "oauth2"
+	APIKeyInHeader = "header"
+	APIKeyInQuery  = "query"
+
+	// signing types of a task
+	SigningHMAC          = "hmac"
+	SigningSigV4         = "sigv4"
+	DefaultSigningScheme = "default"
+
+	// sources of the values extracted from a response
+	ExtractFromBody   = "body"
+	ExtractFromHeader = "header"
+	ExtractFromRegex  = "regex"
+	ExtractFromStatus = "status"
+
+	// conditions of a workflow node's dependency
+	DependsOnSuccess = "success"
+	DependsOnFailure = "failure"
+	DependsOnAlways  = "always"
+
+	// next page sources of a paginated task
+	PaginateFromLink   = "link"
+	PaginateFromCursor = "cursor"
+
+	// aggregate policies of a fan-out task
+	FanOutAll    = "all"
+	FanOutAny    = "any"
+	FanOutQuorum = "quorum"
+
+	// error codes of a failed task
+	ErrCodeInvalidRequest     = "invalid_request"
+	ErrCodeRateLimit          = "rate_limit_error"
+	ErrCodeConcurrency        = "concurrency_limit_error"
+	ErrCodeCircuitOpen        = "circuit_open"
+	ErrCodeCallFailed         = "call_failed"
+	ErrCodeProxy              = "proxy_error"
+	ErrCodeBlockedDestination = "blocked_destination"
+	ErrCodePolicyDenied       = "policy_denied"
+	ErrCodeReadFailed         = "read_failed"
+	ErrCodeResponseTooLarge   = "response_too_large"
+	ErrCodeAuthFailed         = "auth_failed"
+	ErrCodeSecret             = "secret_error"
+	ErrCodeSigningFailed      = "signing_failed"
+	ErrCodeStepFailed         = "step_failed"
+	ErrCodeExtractFailed      = "extract_failed"
+	ErrCodeNodeFailed         = "node_failed"
+	ErrCodeFanOutFailed       = "fan_out_failed"
+	ErrCodePollTimeout        = "poll_timeout"
+	ErrCodePaginationFailed   = "pagination_failed"
 
 	ContentType = "Content-Type"
 )
@@ -0,0 +1,68 @@
+package model
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/axxonsoft-assignment/pkg/jsonpath"
+)
+
+const (
+	// MaxExtractRules caps the number of values extracted from a response
+	MaxExtractRules = 20
+	// MaxOutputBytes caps the size of an extracted value, as JSON
+	MaxOutputBytes = 4096
+)
+
+// outputNamePattern keeps the names of the extracted values usable as template placeholders, e.g. {{.token}}
+var outputNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// ExtractRule represents a value taken out of the response of a task, From one of [body, header, regex, status]
+// with the Path being the JSONPath of the body, the name of the header or the regular expression matched against
+// the body, whose first group is the value if it has any
+type ExtractRule struct {
+	From string `json:"from"`
+	Path string `json:"path,omitempty"`
+}
+
+func validateExtract(rules map[string]ExtractRule, v *violations) {
+	if len(rules) > MaxExtractRules {
+		v.add("/extract", RuleRange, "Invalid request: extract cannot have more than "+strconv.Itoa(MaxExtractRules)+" values")
+	}
+
+	// the rules are checked in the order of their names, for the violations to be reported in a stable order
+	names := make([]string, 0, len(rules))
+	for name := range rules {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		rule := rules[name]
+		pointer := "/extract/" + PointerToken(name)
+
+		if !outputNamePattern.MatchString(name) {
+			v.add(pointer, RuleFormat, "Invalid request: extract name "+name+" should be a letter or '_' followed by letters, digits or '_'")
+		}
+
+		switch rule.From {
+		case ExtractFromBody:
+			if _, err := jsonpath.Parse(rule.Path); err != nil {
+				v.add(pointer+"/path", RuleFormat, "Invalid request: extract "+name+": "+err.Error())
+			}
+		case ExtractFromHeader:
+			if rule.Path == "" {
+				v.add(pointer+"/path", RuleRequired, "Invalid request: extract "+name+": path cannot be empty")
+			}
+		case ExtractFromRegex:
+			if _, err := regexp.Compile(rule.Path); err != nil || rule.Path == "" {
+				v.add(pointer+"/path", RuleFormat, "Invalid request: extract "+name+": path should be a valid regular expression")
+			}
+		case ExtractFromStatus:
+		default:
+			v.add(pointer+"/from", RuleEnum, "Invalid request: extract "+name+": from should be one of [body, header, regex, status]")
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtract_ValidateRequestBody(t *testing.T) {
+	tcs := []struct {
+		description string
+		extract     map[string]ExtractRule
+		expErr      error
+	}{
+		{
+			description: "Positive case: every source",
+			extract: map[string]ExtractRule{
+				"id":        {From: ExtractFromBody, Path: "$.data.id"},
+				"requestId": {From: ExtractFromHeader, Path: "X-Request-Id"},
+				"version":   {From: ExtractFromRegex, Path: `version (\d+)`},
+				"status":    {From: ExtractFromStatus},
+			},
+		},
+		{
+			description: "Negative case: invalid name",
+			extract:     map[string]ExtractRule{"request-id": {From: ExtractFromHeader, Path: "X-Request-Id"}},
+			expErr:      invalid("/extract/request-id", RuleFormat, "Invalid request: extract name request-id should be a letter or '_' followed by letters, digits or '_'"),
+		},
+		{
+			description: "Negative case: invalid regex",
+			extract:     map[string]ExtractRule{"version": {From: ExtractFromRegex, Path: `version (\d+`}},
+			expErr:      invalid("/extract/version/path", RuleFormat, "Invalid request: extract version: path should be a valid regular expression"),
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, tc.expErr, ValidateRequestBody(Task{Method: "GET", URL: "https://partner.com", Extract: tc.extract}))
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package model
+
+import (
+	"strconv"
+	"strings"
+)
+
+// MaxFanOutTargets caps the number of targets of a fan-out task
+const MaxFanOutTargets = 20
+
+// fanOutHostPlaceholder is replaced by each of the hosts of a fan-out task
+const fanOutHostPlaceholder = "{{.host}}"
+
+// FanOut makes the same call to several targets in parallel, either the URLs or the task's url rendered for each of
+// the Hosts in place of its {{.host}} placeholder. Policy is one of [all, any, quorum], defaults to all.
+type FanOut struct {
+	URLs   []string `json:"urls,omitempty"`
+	Hosts  []string `json:"hosts,omitempty"`
+	Policy string   `json:"policy,omitempty"`
+	// Quorum is the number of targets that should succeed with the quorum policy, defaults to a majority
+	Quorum int `json:"quorum,omitempty"`
+}
+
+// FanOutTarget represents a target of a fan-out task along with the task created for it
+type FanOutTarget struct {
+	URL    string       `json:"url"`
+	TaskID string       `json:"taskId,omitempty"`
+	Result *TasksObject `json:"result,omitempty"`
+}
+
+// Aggregate represents the outcome of a fan-out task, which is done once Succeeded reaches Required
+type Aggregate struct {
+	Policy    string `json:"policy"`
+	Required  int    `json:"required"`
+	Succeeded int    `json:"succeeded"`
+	Failed    int    `json:"failed"`
+}
+
+// Targets gives a task for each target of the fan-out task
+func (t Task) Targets() []Task {
+	fanOut := t.FanOut
+	t.FanOut = nil
+
+	targets := make([]Task, 0, len(fanOut.URLs)+len(fanOut.Hosts))
+
+	for _, rawURL := range fanOut.URLs {
+		target := t
+		target.URL = rawURL
+		targets = append(targets, target)
+	}
+
+	// only the {{.host}} placeholder is replaced, the rest of the task is sent as is even if it looks like a template
+	for _, host := range fanOut.Hosts {
+		host := host
+
+		target, _ := TaskTemplate{Task: t}.render(func(_ string, _ string, text string) (string, error) {
+			return strings.ReplaceAll(text, fanOutHostPlaceholder, host), nil
+		})
+
+		targets = append(targets, target)
+	}
+
+	return targets
+}
+
+// Required gives the number of the targets that should succeed as per the policy
+func (f FanOut) Required() int {
+	targets := len(f.URLs) + len(f.Hosts)
+
+	switch f.Policy {
+	case FanOutAny:
+		return 1
+	case FanOutQuorum:
+		if f.Quorum > 0 {
+			return f.Quorum
+		}
+
+		return targets/2 + 1
+	default:
+		return targets
+	}
+}
+
+func validateFanOut(task Task, v *violations) {
+	fanOut := task.FanOut
+	targets := len(fanOut.URLs) + len(fanOut.Hosts)
+
+	if len(fanOut.URLs) > 0 && len(fanOut.Hosts) > 0 {
+		v.add("/fanOut", RuleForbidden, "Invalid request: fanOut should have either urls or hosts")
+	}
+
+	if targets == 0 {
+		v.add("/fanOut", RuleRequired, "Invalid request: fanOut urls or hosts cannot be empty")
+	}
+
+	if targets > MaxFanOutTargets {
+		v.add("/fanOut", RuleRange, "Invalid request: fanOut cannot have more than "+strconv.Itoa(MaxFanOutTargets)+" targets")
+	}
+
+	if len(fanOut.URLs) > 0 && task.URL != "" {
+		v.add("/url", RuleForbidden, "Invalid request: url should be empty with fanOut urls")
+	}
+
+	if len(fanOut.Hosts) > 0 && !strings.Contains(task.URL, fanOutHostPlaceholder) {
+		v.add("/url", RuleFormat, "Invalid request: url should hold the {{.host}} placeholder with fanOut hosts")
+	}
+
+	switch fanOut.Policy {
+	case "", FanOutAll, FanOutAny, FanOutQuorum:
+	default:
+		v.add("/fanOut/policy", RuleEnum, "Invalid request: fanOut policy should be one of [all, any, quorum]")
+	}
+
+	if fanOut.Quorum < 0 || fanOut.Quorum > targets || (fanOut.Quorum > 0 && fanOut.Policy != FanOutQuorum) {
+		v.add("/fanOut/quorum", RuleRange, "Invalid request: fanOut quorum should be between 1 and the number of targets, with the quorum policy")
+	}
+
+	// the targets only differ by their url, a violation of any other field is reported once for all of them
+	for i, target := range task.Targets() {
+		var targetViolations violations
+
+		validateTask(target, &targetViolations)
+
+		for _, violation := range targetViolations {
+			if violation.Pointer != "/url" {
+				if !v.has(violation) {
+					v.add(violation.Pointer, violation.Rule, violation.Message)
+				}
+
+				continue
+			}
+
+			pointer := "/fanOut/urls/" + strconv.Itoa(i)
+			if i >= len(fanOut.URLs) {
+				pointer = "/fanOut/hosts/" + strconv.Itoa(i-len(fanOut.URLs))
+			}
+
+			v.add(pointer, violation.Rule, "Invalid fanOut target "+strconv.Itoa(i)+": "+violation.Message)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFanOut_ValidateRequestBody(t *testing.T) {
+	tcs := []struct {
+		description string
+		task        Task
+		expErr      error
+	}{
+		{
+			description: "Positive case: urls",
+			task: Task{Method: "GET", FanOut: &FanOut{URLs: []string{"https://eu.partner.com/health", "https://us.partner.com/health"},
+				Policy: FanOutQuorum, Quorum: 1}},
+		},
+		{
+			description: "Positive case: hosts",
+			task:        Task{Method: "GET", URL: "https://{{.host}}/health", FanOut: &FanOut{Hosts: []string{"eu.partner.com", "us.partner.com"}}},
+		},
+		{
+			description: "Negative case: no targets",
+			task:        Task{Method: "GET", FanOut: &FanOut{}},
+			expErr:      invalid("/fanOut", RuleRequired, "Invalid request: fanOut urls or hosts cannot be empty"),
+		},
+		{
+			description: "Negative case: url along with urls",
+			task:        Task{Method: "GET", URL: "https://partner.com", FanOut: &FanOut{URLs: []string{"https://eu.partner.com"}}},
+			expErr:      invalid("/url", RuleForbidden, "Invalid request: url should be empty with fanOut urls"),
+		},
+		{
+			description: "Negative case: hosts without placeholder",
+			task:        Task{Method: "GET", URL: "https://partner.com", FanOut: &FanOut{Hosts: []string{"eu.partner.com"}}},
+			expErr:      invalid("/url", RuleFormat, "Invalid request: url should hold the {{.host}} placeholder with fanOut hosts"),
+		},
+		{
+			description: "Negative case: invalid policy",
+			task:        Task{Method: "GET", FanOut: &FanOut{URLs: []string{"https://eu.partner.com"}, Policy: "most"}},
+			expErr:      invalid("/fanOut/policy", RuleEnum, "Invalid request: fanOut policy should be one of [all, any, quorum]"),
+		},
+		{
+			description: "Negative case: quorum above the number of targets",
+			task:        Task{Method: "GET", FanOut: &FanOut{URLs: []string{"https://eu.partner.com"}, Policy: FanOutQuorum, Quorum: 2}},
+			expErr:      invalid("/fanOut/quorum", RuleRange, "Invalid request: fanOut quorum should be between 1 and the number of targets, with the quorum policy"),
+		},
+		{
+			description: "Negative case: invalid target",
+			task:        Task{Method: "GET", FanOut: &FanOut{URLs: []string{"https://eu.partner.com", "ftp://us.partner.com"}}},
+			expErr:      invalid("/fanOut/urls/1", RuleEnum, "Invalid fanOut target 1: Invalid URL: only the following schemes are supported: [http, https]"),
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, tc.expErr, ValidateRequestBody(tc.task))
+		})
+	}
+}
+
+func TestFanOut_Targets(t *testing.T) {
+	task := Task{Method: "GET", URL: "https://{{.host}}/health", Headers: map[string]interface{}{"X-Region": "{{.host}}"},
+		FanOut: &FanOut{Hosts: []string{"eu.partner.com", "us.partner.com"}}}
+
+	targets := task.Targets()
+	assert.Equal(t, []Task{
+		{Method: "GET", URL: "https://eu.partner.com/health", Headers: map[string]interface{}{"X-Region": "eu.partner.com"}},
+		{Method: "GET", URL: "https://us.partner.com/health", Headers: map[string]interface{}{"X-Region": "us.partner.com"}},
+	}, targets)
+
+	// anything else that looks like a template is left as is
+	task = Task{Method: "POST", URL: "https://{{.host}}/render", Headers: map[string]interface{}{ContentType: "application/json"},
+		Data:   map[string]interface{}{"template": "Hello {{.name}}", "items": []interface{}{"{{ range .items }}", "{{.host}}"}},
+		FanOut: &FanOut{Hosts: []string{"eu.partner.com"}}}
+
+	targets = task.Targets()
+	assert.Equal(t, []Task{
+		{Method: "POST", URL: "https://eu.partner.com/render", Headers: map[string]interface{}{ContentType: "application/json"},
+			Data: map[string]interface{}{"template": "Hello {{.name}}", "items": []interface{}{"{{ range .items }}", "eu.partner.com"}}},
+	}, targets)
+	assert.Equal(t, "Hello {{.name}}", task.Data["template"])
+	assert.Equal(t, "{{.host}}", task.Data["items"].([]interface{})[1])
+
+	assert.Nil(t, ValidateRequestBody(task))
+
+	assert.Equal(t, 2, FanOut{URLs: []string{"a", "b"}}.Required())
+	assert.Equal(t, 1, FanOut{URLs: []string{"a", "b"}, Policy: FanOutAny}.Required())
+	assert.Equal(t, 2, FanOut{URLs: []string{"a", "b", "c"}, Policy: FanOutQuorum}.Required())
+	assert.Equal(t, 3, FanOut{URLs: []string{"a", "b", "c"}, Policy: FanOutQuorum, Quorum: 3}.Required())
+}
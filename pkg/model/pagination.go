@@ -0,0 +1,95 @@
+package model
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/axxonsoft-assignment/pkg/jsonpath"
+)
+
+const (
+	defaultMaxPages = 10
+	maxMaxPages     = 100
+	defaultMaxBytes = 10 << 20
+	maxMaxBytes     = 100 << 20
+)
+
+// Paginate follows the next pages of a GET task, From one of [link, cursor]. With link the next page is the
+// rel="next" url of the Link header. With cursor it is selected by the JSONPath of the body, the value being
+// set as the Param query parameter of the url, or being the url of the next page itself if Param is not set.
+type Paginate struct {
+	From  string `json:"from"`
+	Path  string `json:"path,omitempty"`
+	Param string `json:"param,omitempty"`
+	// MaxPages caps the number of pages collected, defaults to 10
+	MaxPages int `json:"maxPages,omitempty"`
+	// MaxBytes stops the collection once the pages reach this size, defaults to 10MiB
+	MaxBytes int64 `json:"maxBytes,omitempty"`
+}
+
+// Pagination represents the pages collected by a task, Truncated if a next page was left once a limit was reached
+type Pagination struct {
+	PageCount int          `json:"pageCount"`
+	Truncated bool         `json:"truncated"`
+	Pages     []PageResult `json:"pages"`
+}
+
+// PageResult represents the response of a page, the urls are not stored as they may hold credentials
+type PageResult struct {
+	HTTPStatusCode int `json:"httpStatusCode"`
+	Bytes          int `json:"bytes"`
+}
+
+// Limits gives the max number of pages and bytes collected, with their defaults
+func (p Paginate) Limits() (int, int64) {
+	maxPages, maxBytes := p.MaxPages, p.MaxBytes
+
+	if maxPages == 0 {
+		maxPages = defaultMaxPages
+	}
+
+	if maxBytes == 0 {
+		maxBytes = defaultMaxBytes
+	}
+
+	return maxPages, maxBytes
+}
+
+func validatePaginate(task Task, v *violations) {
+	paginate := task.Paginate
+
+	if !strings.EqualFold(task.Method, http.MethodGet) {
+		v.add("/paginate", RuleForbidden, "Invalid request: paginate is only supported for GET")
+	}
+
+	if task.Until != nil {
+		v.add("/paginate", RuleForbidden, "Invalid request: paginate cannot be combined with until")
+	}
+
+	switch paginate.From {
+	case PaginateFromLink:
+		if paginate.Path != "" {
+			v.add("/paginate/path", RuleForbidden, "Invalid request: paginate path and param are only supported with cursor")
+		}
+
+		if paginate.Param != "" {
+			v.add("/paginate/param", RuleForbidden, "Invalid request: paginate path and param are only supported with cursor")
+		}
+	case PaginateFromCursor:
+		if paginate.Path == "" {
+			v.add("/paginate/path", RuleRequired, "Invalid request: paginate path cannot be empty with cursor")
+		} else if _, err := jsonpath.Parse(paginate.Path); err != nil {
+			v.add("/paginate/path", RuleFormat, "Invalid request: paginate "+err.Error())
+		}
+	default:
+		v.add("/paginate/from", RuleEnum, "Invalid request: paginate from should be one of [link, cursor]")
+	}
+
+	if paginate.MaxPages < 0 || paginate.MaxPages > maxMaxPages {
+		v.add("/paginate/maxPages", RuleRange, "Invalid request: paginate maxPages should be between 1 and 100")
+	}
+
+	if paginate.MaxBytes < 0 || paginate.MaxBytes > maxMaxBytes {
+		v.add("/paginate/maxBytes", RuleRange, "Invalid request: paginate maxBytes should be between 1 and 104857600")
+	}
+}
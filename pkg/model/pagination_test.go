@@ -0,0 +1,53 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPagination_ValidateRequestBody(t *testing.T) {
+	tcs := []struct {
+		description string
+		task        Task
+		expErr      error
+	}{
+		{
+			description: "Positive case: Link header",
+			task:        Task{Method: "GET", URL: "https://api.partner.com/items", Paginate: &Paginate{From: PaginateFromLink, MaxPages: 50}},
+		},
+		{
+			description: "Positive case: cursor",
+			task: Task{Method: "GET", URL: "https://api.partner.com/items",
+				Paginate: &Paginate{From: PaginateFromCursor, Path: "$.meta.next", Param: "after"}},
+		},
+		{
+			description: "Negative case: not a GET",
+			task:        Task{Method: "DELETE", URL: "https://api.partner.com/items", Paginate: &Paginate{From: PaginateFromLink}},
+			expErr:      invalid("/paginate", RuleForbidden, "Invalid request: paginate is only supported for GET"),
+		},
+		{
+			description: "Negative case: invalid source",
+			task:        Task{Method: "GET", URL: "https://api.partner.com/items", Paginate: &Paginate{From: "offset"}},
+			expErr:      invalid("/paginate/from", RuleEnum, "Invalid request: paginate from should be one of [link, cursor]"),
+		},
+		{
+			description: "Negative case: cursor without path",
+			task:        Task{Method: "GET", URL: "https://api.partner.com/items", Paginate: &Paginate{From: PaginateFromCursor}},
+			expErr:      invalid("/paginate/path", RuleRequired, "Invalid request: paginate path cannot be empty with cursor"),
+		},
+		{
+			description: "Negative case: too many pages",
+			task:        Task{Method: "GET", URL: "https://api.partner.com/items", Paginate: &Paginate{From: PaginateFromLink, MaxPages: 500}},
+			expErr:      invalid("/paginate/maxPages", RuleRange, "Invalid request: paginate maxPages should be between 1 and 100"),
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, tc.expErr, ValidateRequestBody(tc.task))
+		})
+	}
+}
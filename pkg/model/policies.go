@@ -0,0 +1,18 @@
+package model
+
+// Policy represents the rules restricting the destinations the tasks may target
+type Policy struct {
+	DefaultAction string       `json:"defaultAction"`
+	Rules         []PolicyRule `json:"rules"`
+}
+
+// PolicyRule allows or denies the calls matching all of its non-empty conditions, higher priority rules are evaluated first
+type PolicyRule struct {
+	Name         string   `json:"name"`
+	Action       string   `json:"action"`
+	Priority     int      `json:"priority"`
+	Hosts        []string `json:"hosts,omitempty"`
+	Ports        []int    `json:"ports,omitempty"`
+	Methods      []string `json:"methods,omitempty"`
+	PathPrefixes []string `json:"pathPrefixes,omitempty"`
+}
@@ -0,0 +1,121 @@
+package model
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/axxonsoft-assignment/pkg/jsonpath"
+)
+
+const (
+	// MaxPollAttempts caps the number of attempts stored with a polling task, the latest ones are kept
+	MaxPollAttempts = 100
+
+	defaultPollInterval = 5 * time.Second
+	minPollInterval     = time.Second
+	defaultPollDeadline = 5 * time.Minute
+	maxPollDeadline     = 24 * time.Hour
+	maxPollBackoff      = 10
+)
+
+// Until repeats the call of a task till its response has one of the Status codes and, if Path is set, a value at the
+// JSONPath of its body equal to Equals (any value if not set), or till the Deadline passes. The wait between the
+// attempts starts at Interval and is multiplied by Backoff after each attempt, up to MaxInterval.
+type Until struct {
+	Status      []int       `json:"status,omitempty"`
+	Path        string      `json:"path,omitempty"`
+	Equals      interface{} `json:"equals,omitempty"`
+	Interval    string      `json:"interval,omitempty"`
+	Backoff     float64     `json:"backoff,omitempty"`
+	MaxInterval string      `json:"maxInterval,omitempty"`
+	Deadline    string      `json:"deadline,omitempty"`
+}
+
+// PollAttempt represents an attempt of a polling task, with the status code of its response if it got one
+type PollAttempt struct {
+	At             time.Time  `json:"at"`
+	HTTPStatusCode *int       `json:"httpStatusCode,omitempty"`
+	Matched        bool       `json:"matched"`
+	Error          *TaskError `json:"error,omitempty"`
+}
+
+// Durations gives the interval, max interval and deadline of the polling, defaulting to 5 seconds, no max and
+// 5 minutes respectively
+func (u Until) Durations() (time.Duration, time.Duration, time.Duration, error) {
+	interval, err := parseDuration(u.Interval, defaultPollInterval)
+	if err != nil {
+		return 0, 0, 0, errors.New("Invalid request: until interval " + err.Error())
+	}
+
+	maxInterval, err := parseDuration(u.MaxInterval, 0)
+	if err != nil {
+		return 0, 0, 0, errors.New("Invalid request: until maxInterval " + err.Error())
+	}
+
+	deadline, err := parseDuration(u.Deadline, defaultPollDeadline)
+	if err != nil {
+		return 0, 0, 0, errors.New("Invalid request: until deadline " + err.Error())
+	}
+
+	return interval, maxInterval, deadline, nil
+}
+
+func validateUntil(until *Until, v *violations) {
+	if len(until.Status) == 0 && until.Path == "" {
+		v.add("/until", RuleRequired, "Invalid request: until should have a status or a path")
+	}
+
+	for i, status := range until.Status {
+		if status < 100 || status > 599 {
+			v.add("/until/status/"+strconv.Itoa(i), RuleRange, "Invalid request: until status should be between 100 and 599")
+		}
+	}
+
+	if until.Path != "" {
+		if _, err := jsonpath.Parse(until.Path); err != nil {
+			v.add("/until/path", RuleFormat, "Invalid request: until "+err.Error())
+		}
+	} else if until.Equals != nil {
+		v.add("/until/path", RuleRequired, "Invalid request: until equals needs a path")
+	}
+
+	// the durations are parsed one by one rather than with Durations, so that each of them is reported
+	interval, intervalErr := parseDuration(until.Interval, defaultPollInterval)
+	if intervalErr != nil {
+		v.add("/until/interval", RuleFormat, "Invalid request: until interval "+intervalErr.Error())
+	} else if interval < minPollInterval {
+		v.add("/until/interval", RuleRange, "Invalid request: until interval should be at least 1s")
+	}
+
+	maxInterval, err := parseDuration(until.MaxInterval, 0)
+	if err != nil {
+		v.add("/until/maxInterval", RuleFormat, "Invalid request: until maxInterval "+err.Error())
+	} else if intervalErr == nil && maxInterval != 0 && maxInterval < interval {
+		v.add("/until/maxInterval", RuleRange, "Invalid request: until maxInterval should be at least the interval")
+	}
+
+	deadline, err := parseDuration(until.Deadline, defaultPollDeadline)
+	if err != nil {
+		v.add("/until/deadline", RuleFormat, "Invalid request: until deadline "+err.Error())
+	} else if deadline > maxPollDeadline {
+		v.add("/until/deadline", RuleRange, "Invalid request: until deadline should be at most 24h")
+	}
+
+	if until.Backoff != 0 && (until.Backoff < 1 || until.Backoff > maxPollBackoff) {
+		v.add("/until/backoff", RuleRange, "Invalid request: until backoff should be between 1 and 10")
+	}
+}
+
+func parseDuration(raw string, fallback time.Duration) (time.Duration, error) {
+	if raw == "" {
+		return fallback, nil
+	}
+
+	duration, err := time.ParseDuration(raw)
+	if err != nil || duration <= 0 {
+		return 0, errors.New("should be a positive duration, e.g. 5s")
+	}
+
+	return duration, nil
+}
@@ -0,0 +1,55 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPolling_ValidateRequestBody(t *testing.T) {
+	tcs := []struct {
+		description string
+		until       Until
+		expErr      error
+	}{
+		{
+			description: "Positive case: path condition with backoff",
+			until:       Until{Path: "$.state", Equals: "done", Interval: "2s", Backoff: 2, MaxInterval: "30s", Deadline: "10m"},
+		},
+		{
+			description: "Negative case: no condition",
+			until:       Until{Interval: "2s"},
+			expErr:      invalid("/until", RuleRequired, "Invalid request: until should have a status or a path"),
+		},
+		{
+			description: "Negative case: equals without path",
+			until:       Until{Status: []int{200}, Equals: "done"},
+			expErr:      invalid("/until/path", RuleRequired, "Invalid request: until equals needs a path"),
+		},
+		{
+			description: "Negative case: interval too short",
+			until:       Until{Status: []int{200}, Interval: "100ms"},
+			expErr:      invalid("/until/interval", RuleRange, "Invalid request: until interval should be at least 1s"),
+		},
+		{
+			description: "Negative case: invalid deadline",
+			until:       Until{Status: []int{200}, Deadline: "soon"},
+			expErr:      invalid("/until/deadline", RuleFormat, "Invalid request: until deadline should be a positive duration, e.g. 5s"),
+		},
+		{
+			description: "Negative case: invalid backoff",
+			until:       Until{Status: []int{200}, Backoff: 0.5},
+			expErr:      invalid("/until/backoff", RuleRange, "Invalid request: until backoff should be between 1 and 10"),
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			until := tc.until
+
+			assert.Equal(t, tc.expErr, ValidateRequestBody(Task{Method: "GET", URL: "https://partner.com/jobs/1", Until: &until}))
+		})
+	}
+}
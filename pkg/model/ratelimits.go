@@ -0,0 +1,32 @@
+package model
+
+import "strings"
+
+// DefaultRateLimitHost is the host of the rate limit applied to every host without a limit of its own
+const DefaultRateLimitHost = "*"
+
+// RateLimit represents the token bucket limiting the outbound calls to a target host
+type RateLimit struct {
+	Host  string  `json:"host"`
+	Rate  float64 `json:"rate"`
+	Burst int     `json:"burst"`
+}
+
+// ValidateRateLimit provides basic validations on the rate limit like validating the rate and burst
+func ValidateRateLimit(limit RateLimit) error {
+	var v violations
+
+	if strings.TrimSpace(limit.Host) == "" {
+		v.add("/host", RuleRequired, "Invalid request: host cannot be empty")
+	}
+
+	if limit.Rate <= 0 {
+		v.add("/rate", RuleRange, "Invalid request: rate should be a positive number of requests per second")
+	}
+
+	if limit.Burst < 1 {
+		v.add("/burst", RuleRange, "Invalid request: burst should be at least 1")
+	}
+
+	return v.err()
+}
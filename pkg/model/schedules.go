@@ -0,0 +1,103 @@
+package model
+
+import (
+	"errors"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// Schedule represents a recurring task, a new task is created from the Task template on every firing of the cron expression
+type Schedule struct {
+	ID              string     `json:"id,omitempty"`
+	Task            Task       `json:"task"`
+	Cron            string     `json:"cron"`
+	Timezone        string     `json:"timezone,omitempty"`
+	MissedRunPolicy string     `json:"missedRunPolicy,omitempty"`
+	Paused          bool       `json:"paused"`
+	LastRunAt       *time.Time `json:"lastRunAt,omitempty"`
+	NextRunAt       *time.Time `json:"nextRunAt,omitempty"`
+}
+
+// ScheduleRun represents a single firing of a schedule and the task created by it
+type ScheduleRun struct {
+	TaskID      string    `json:"taskId"`
+	ScheduledAt time.Time `json:"scheduledAt"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+// ValidateSchedule provides basic validations on the schedule like validating the cron expression, timezone and the
+// task template. All the violations are collected, the error being a ValidationError.
+func ValidateSchedule(schedule Schedule) error {
+	var v violations
+
+	if schedule.Cron == "" {
+		v.add("/cron", RuleRequired, "Invalid request: cron cannot be empty")
+	} else if _, err := parseCron(schedule.Cron); err != nil {
+		v.add("/cron", RuleFormat, err.Error())
+	}
+
+	if _, err := loadLocation(schedule.Timezone); err != nil {
+		v.add("/timezone", RuleEnum, err.Error())
+	}
+
+	if schedule.MissedRunPolicy != "" && schedule.MissedRunPolicy != MissedRunSkip && schedule.MissedRunPolicy != MissedRunCatchUp {
+		v.add("/missedRunPolicy", RuleEnum, "Invalid request: only the following missed run policies are supported: [skip, catch_up]")
+	}
+
+	v.nest("/task", "", ValidateRequestBody(schedule.Task))
+
+	return v.err()
+}
+
+// Next returns the first firing time of the schedule strictly after the given time
+func (s Schedule) Next(after time.Time) (time.Time, error) {
+	sched, err := s.parse()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return sched.Next(after), nil
+}
+
+func (s Schedule) parse() (cron.Schedule, error) {
+	location, err := loadLocation(s.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	sched, err := parseCron(s.Cron)
+	if err != nil {
+		return nil, err
+	}
+
+	// evaluate the expression in the schedule's timezone
+	if specSchedule, ok := sched.(*cron.SpecSchedule); ok {
+		specSchedule.Location = location
+	}
+
+	return sched, nil
+}
+
+// loadLocation gives the location of the timezone, UTC when it is empty
+func loadLocation(timezone string) (*time.Location, error) {
+	if timezone == "" {
+		return time.UTC, nil
+	}
+
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, errors.New("Invalid request: unknown timezone " + timezone)
+	}
+
+	return location, nil
+}
+
+func parseCron(expression string) (cron.Schedule, error) {
+	sched, err := cron.ParseStandard(expression)
+	if err != nil {
+		return nil, errors.New("Invalid cron expression: " + err.Error())
+	}
+
+	return sched, nil
+}
@@ -0,0 +1,71 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchedules_ValidateSchedule(t *testing.T) {
+	task := Task{Method: "GET", URL: "https://www.getyourtasks.com/health"}
+
+	tcs := []struct {
+		description string
+		req         Schedule
+		expErr      error
+	}{
+		{
+			description: "Positive case: valid schedule",
+			req:         Schedule{Task: task, Cron: "*/5 * * * *", Timezone: "Europe/Berlin", MissedRunPolicy: MissedRunCatchUp},
+		},
+		{
+			description: "Negative case: empty cron",
+			req:         Schedule{Task: task},
+			expErr:      invalid("/cron", RuleRequired, "Invalid request: cron cannot be empty"),
+		},
+		{
+			description: "Negative case: invalid cron",
+			req:         Schedule{Task: task, Cron: "every minute"},
+			expErr:      invalid("/cron", RuleFormat, "Invalid cron expression: expected exactly 5 fields, found 2: [every minute]"),
+		},
+		{
+			description: "Negative case: unknown timezone",
+			req:         Schedule{Task: task, Cron: "* * * * *", Timezone: "Mars/Olympus"},
+			expErr:      invalid("/timezone", RuleEnum, "Invalid request: unknown timezone Mars/Olympus"),
+		},
+		{
+			description: "Negative case: invalid missed run policy",
+			req:         Schedule{Task: task, Cron: "* * * * *", MissedRunPolicy: "retry"},
+			expErr:      invalid("/missedRunPolicy", RuleEnum, "Invalid request: only the following missed run policies are supported: [skip, catch_up]"),
+		},
+		{
+			description: "Negative case: invalid task template",
+			req:         Schedule{Task: Task{Method: "GET"}, Cron: "* * * * *"},
+			expErr:      invalid("/task/url", RuleRequired, "Invalid request: url cannot be empty"),
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			err := ValidateSchedule(tc.req)
+
+			assert.Equal(t, tc.expErr, err)
+		})
+	}
+}
+
+func TestSchedules_Next(t *testing.T) {
+	after := time.Date(2023, 11, 20, 10, 30, 0, 0, time.UTC)
+
+	next, err := Schedule{Cron: "0 9 * * *"}.Next(after)
+	assert.Nil(t, err)
+	assert.True(t, next.Equal(time.Date(2023, 11, 21, 9, 0, 0, 0, time.UTC)))
+
+	// 9 AM in Kolkata is 3:30 AM UTC
+	next, err = Schedule{Cron: "0 9 * * *", Timezone: "Asia/Kolkata"}.Next(after)
+	assert.Nil(t, err)
+	assert.True(t, next.Equal(time.Date(2023, 11, 21, 3, 30, 0, 0, time.UTC)))
+}
@@ -0,0 +1,62 @@
+package model
+
+// Signing represents the signature computed over the outbound call of a task, right before it is made
+type Signing struct {
+	// Type is one of [hmac, sigv4]
+	Type string `json:"type"`
+
+	// Scheme names the HMAC scheme, defaults to "default"; KeyID and Secret are the key of the signature
+	Scheme string `json:"scheme,omitempty"`
+	KeyID  string `json:"keyId,omitempty"`
+	Secret string `json:"secret,omitempty"`
+
+	// AccessKeyID, SecretAccessKey and the optional SessionToken are the AWS credentials of the sigv4 signature
+	// scoped to the Region and Service, e.g. us-east-1 and s3
+	AccessKeyID     string `json:"accessKeyId,omitempty"`
+	SecretAccessKey string `json:"secretAccessKey,omitempty"`
+	SessionToken    string `json:"sessionToken,omitempty"`
+	Region          string `json:"region,omitempty"`
+	Service         string `json:"service,omitempty"`
+}
+
+// HMACScheme represents how an HMAC signature is computed and sent, the schemes are configured by name
+type HMACScheme struct {
+	// Algorithm is one of [sha1, sha256, sha512], defaults to sha256
+	Algorithm string `json:"algorithm,omitempty"`
+	// Components are joined by the Separator (defaults to a new line) into the signed string, each being one of
+	// [method, host, path, query, body, bodySha256, timestamp, keyId] or header:<name>
+	Components []string `json:"components,omitempty"`
+	Separator  string   `json:"separator,omitempty"`
+	// Header carries the signature (defaults to X-Signature), with the Prefix and the Encoding (hex or base64, defaults to hex)
+	Header   string `json:"header,omitempty"`
+	Prefix   string `json:"prefix,omitempty"`
+	Encoding string `json:"encoding,omitempty"`
+	// TimestampHeader carries the timestamp (defaults to X-Timestamp) in the TimestampFormat, one of [unix, unixMillis, rfc3339]
+	TimestampHeader string `json:"timestampHeader,omitempty"`
+	TimestampFormat string `json:"timestampFormat,omitempty"`
+	// KeyIDHeader carries the key id of the task if set
+	KeyIDHeader string `json:"keyIdHeader,omitempty"`
+}
+
+func validateSigning(signing *Signing, v *violations) {
+	switch signing.Type {
+	case SigningHMAC:
+		if signing.Secret == "" {
+			v.add("/signing/secret", RuleRequired, "Invalid request: signing secret cannot be empty")
+		}
+	case SigningSigV4:
+		if signing.AccessKeyID == "" {
+			v.add("/signing/accessKeyId", RuleRequired, "Invalid request: signing accessKeyId and secretAccessKey cannot be empty")
+		} else if signing.SecretAccessKey == "" {
+			v.add("/signing/secretAccessKey", RuleRequired, "Invalid request: signing accessKeyId and secretAccessKey cannot be empty")
+		}
+
+		if signing.Region == "" {
+			v.add("/signing/region", RuleRequired, "Invalid request: signing region and service cannot be empty")
+		} else if signing.Service == "" {
+			v.add("/signing/service", RuleRequired, "Invalid request: signing region and service cannot be empty")
+		}
+	default:
+		v.add("/signing/type", RuleEnum, "Invalid request: signing type should be one of [hmac, sigv4]")
+	}
+}
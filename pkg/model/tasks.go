@@ -4,16 +4,53 @@ import (
 	"errors"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
+
+	"github.com/axxonsoft-assignment/pkg/secrets"
 )
 
 // TasksObject represents the structure for returning the task details
 type TasksObject struct {
-	ID             string      `json:"id,omitempty"`
-	Status         string      `json:"status,omitempty"`
-	HTTPStatusCode *int        `json:"httpStatusCode,omitempty"`
-	Headers        http.Header `json:"headers,omitempty"`
-	Length         *int64      `json:"length,omitempty"`
+	ID             string                 `json:"id,omitempty"`
+	Status         string                 `json:"status,omitempty"`
+	HTTPStatusCode *int                   `json:"httpStatusCode,omitempty"`
+	Headers        http.Header            `json:"headers,omitempty"`
+	Length         *int64                 `json:"length,omitempty"`
+	WireLength     *int64                 `json:"wireLength,omitempty"`
+	ScheduleID     string                 `json:"scheduleId,omitempty"`
+	Template       string                 `json:"template,omitempty"`
+	ChainID        string                 `json:"chainId,omitempty"`
+	WorkflowID     string                 `json:"workflowId,omitempty"`
+	ParentID       string                 `json:"parentId,omitempty"`
+	Targets        []FanOutTarget         `json:"targets,omitempty"`
+	Aggregate      *Aggregate             `json:"aggregate,omitempty"`
+	Attempts       []PollAttempt          `json:"attempts,omitempty"`
+	Pagination     *Pagination            `json:"pagination,omitempty"`
+	Outputs        map[string]interface{} `json:"outputs,omitempty"`
+	Blob           *Blob                  `json:"blob,omitempty"`
+	Redirects      []Redirect             `json:"redirects,omitempty"`
+	Proxy          string                 `json:"proxy,omitempty"`
+	Error          *TaskError             `json:"error,omitempty"`
+}
+
+// Redirect represents a hop of the redirect chain followed by the outbound call
+type Redirect struct {
+	URL        string `json:"url"`
+	StatusCode int    `json:"statusCode"`
+}
+
+// Blob represents a response body too large to be held with the task, streamed to the blob store instead
+type Blob struct {
+	Key         string `json:"key"`
+	Size        int64  `json:"size"`
+	ContentType string `json:"contentType,omitempty"`
+}
+
+// TaskError represents the reason a task ended up in the error status
+type TaskError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
 }
 
 // TasksResponse represents the structure of POST response
@@ -27,79 +64,233 @@ type Task struct {
 	URL     string                 `json:"url"`
 	Headers map[string]interface{} `json:"headers"`
 	Data    map[string]interface{} `json:"data"`
+
+	// FollowRedirects is one of [none, same-host, any], defaults to any
+	FollowRedirects string `json:"followRedirects,omitempty"`
+	// MaxRedirects is the number of hops followed, defaults to 10
+	MaxRedirects *int `json:"maxRedirects,omitempty"`
+	// Proxy overrides the global outbound proxy for this task, e.g. socks5://${secret:proxy.user}:${secret:proxy.password}@proxy.partner.com:1080,
+	// its credentials can only be secret references so that they are never stored
+	Proxy string `json:"proxy,omitempty"`
+	// TLSProfile selects the named TLS profile of the outbound call, e.g. for a client certificate or a private CA
+	TLSProfile string `json:"tlsProfile,omitempty"`
+	// Auth holds the credentials applied to the outbound call
+	Auth *Auth `json:"auth,omitempty"`
+	// Signing signs the outbound call, e.g. with an HMAC or AWS SigV4
+	Signing *Signing `json:"signing,omitempty"`
+
+	// FanOut makes the call to several targets in parallel, each with a task of its own
+	FanOut *FanOut `json:"fanOut,omitempty"`
+	// Until repeats the call till its response meets a condition or a deadline passes
+	Until *Until `json:"until,omitempty"`
+	// Paginate follows the next pages of a GET task, collecting them into a JSON array
+	Paginate *Paginate `json:"paginate,omitempty"`
+	// Extract names the values taken out of the response, stored as the outputs of the task
+	Extract map[string]ExtractRule `json:"extract,omitempty"`
+
+	// ScheduleID links the task to the schedule that created it, it cannot be set from the request body
+	ScheduleID string `json:"-"`
+	// Template names the template the task was rendered from, it cannot be set from the request body
+	Template string `json:"-"`
+	// ChainID links the task to the chain whose step created it, it cannot be set from the request body
+	ChainID string `json:"-"`
+	// WorkflowID links the task to the workflow whose node created it, it cannot be set from the request body
+	WorkflowID string `json:"-"`
+	// ParentID links the task to the fan-out task it is a target of, it cannot be set from the request body
+	ParentID string `json:"-"`
+	// KeepBody keeps the response body in memory however large, for the chain step extracting from it, it cannot be
+	// set from the request body
+	KeepBody bool `json:"-"`
+	// PageURL is the url of the next page as given by the server, used as is in place of URL, it cannot be set from
+	// the request body
+	PageURL string `json:"-"`
 }
 
-// ValidateRequestBody provides basic validations on the request body like validating the method and url passed in the request body
+// ValidateRequestBody provides basic validations on the request body like validating the method and url passed in the
+// request body. All the violations are collected, the error being a ValidationError.
 func ValidateRequestBody(task Task) error {
+	var v violations
+
+	validateTask(task, &v)
+
+	return v.err()
+}
+
+func validateTask(task Task, v *violations) {
 	if task.Method == "" {
-		return errors.New("Invalid request: method cannot be empty")
+		v.add("/method", RuleRequired, "Invalid request: method cannot be empty")
+	}
+
+	// the targets of a fan-out task are validated as tasks of their own
+	if task.FanOut != nil {
+		validateFanOut(task, v)
+
+		return
 	}
 
 	// check if the method attribute's value is one of [GET, PATCH, POST, PUT, DELETE]
 	task.Method = strings.ToUpper(task.Method)
-	if task.Method != http.MethodDelete && task.Method != http.MethodGet && task.Method != http.MethodPost && task.Method != http.MethodPut && task.Method != http.MethodPatch {
-		return errors.New("Invalid request: only the following methods are supported: [GET, PATCH, POST, PUT, DELETE]")
+	supported := task.Method == http.MethodDelete || task.Method == http.MethodGet || task.Method == http.MethodPost ||
+		task.Method == http.MethodPut || task.Method == http.MethodPatch
+
+	if task.Method != "" && !supported {
+		v.add("/method", RuleEnum, "Invalid request: only the following methods are supported: [GET, PATCH, POST, PUT, DELETE]")
+	}
+
+	validateURL(task.URL, v)
+
+	// the data attribute and the headers depend on the method, they are only checked with a supported one
+	if supported {
+		// check to validate that data attribute is passed only for POST/PUT/PATCH
+		if checkMethodForBody(task.Method) && task.Data == nil {
+			v.add("/data", RuleRequired, "Invalid request: data attribute is required for POST, PUT, or PATCH requests")
+		}
+
+		if !checkMethodForBody(task.Method) && task.Data != nil {
+			v.add("/data", RuleForbidden, "Invalid request: GET/DELETE method doesn't accept data attribute")
+		}
+
+		validateHeaders(task, v)
 	}
 
-	if err := validateURL(task.URL); err != nil {
-		return err
+	validateRedirects(task, v)
+
+	if task.Auth != nil {
+		validateAuth(task.Auth, v)
+	}
+
+	if task.Signing != nil {
+		validateSigning(task.Signing, v)
 	}
 
-	// check to validate that data attribute is passed only for POST/PUT/PATCH
-	if checkMethodForBody(task.Method) && task.Data == nil {
-		return errors.New("Invalid request: data attribute is required for POST, PUT, or PATCH requests")
+	if task.Proxy != "" {
+		validateProxy(task.Proxy, v)
 	}
 
-	if !checkMethodForBody(task.Method) && task.Data != nil {
-		return errors.New("Invalid request: GET/DELETE method doesn't accept data attribute")
+	if task.Until != nil {
+		validateUntil(task.Until, v)
 	}
 
-	if err := validateHeaders(task); err != nil {
-		return err
+	if task.Paginate != nil {
+		validatePaginate(task, v)
 	}
 
-	return nil
+	validateExtract(task.Extract, v)
 }
 
-func validateURL(rawURL string) error {
+func validateURL(rawURL string, v *violations) {
 	if rawURL == "" {
-		return errors.New("Invalid request: url cannot be empty")
+		v.add("/url", RuleRequired, "Invalid request: url cannot be empty")
+
+		return
 	}
 
 	parsedURL, err := url.Parse(rawURL)
 	if err != nil {
-		return errors.New("Invalid URL in request body: " + err.Error())
+		v.add("/url", RuleFormat, "Invalid URL in request body: "+err.Error())
+
+		return
 	}
 
 	if parsedURL.Scheme == "" || !isValidScheme(parsedURL.Scheme) {
-		return errors.New("Invalid URL: only the following schemes are supported: [http, https]")
+		v.add("/url", RuleEnum, "Invalid URL: only the following schemes are supported: [http, https]")
+	}
+}
+
+func validateHeaders(task Task, v *violations) {
+	if !checkMethodForBody(task.Method) {
+		return
 	}
 
-	return nil
+	pointer := "/headers/" + ContentType
+
+	contentType, contentTypePresent := task.Headers[ContentType]
+	if !contentTypePresent {
+		v.add(pointer, RuleRequired, "Invalid Request: Content-type header is required for POST/PUT/PATCH")
+
+		return
+	}
+
+	if (task.Method == "POST" || task.Method == "PUT") && contentType != "application/json" {
+		v.add(pointer, RuleEnum, "Invalid Request: Content-type with value application/json is required for POST/PUT")
+	}
+
+	if task.Method == "PATCH" && contentType != "application/merge-patch+json" {
+		v.add(pointer, RuleEnum, "Invalid Request: Content-type with value application/merge-patch+json is required for PATCH")
+	}
+}
+
+func validateRedirects(task Task, v *violations) {
+	if task.FollowRedirects != "" && task.FollowRedirects != RedirectsNone && task.FollowRedirects != RedirectsSameHost &&
+		task.FollowRedirects != RedirectsAny {
+		v.add("/followRedirects", RuleEnum, "Invalid request: followRedirects should be one of [none, same-host, any]")
+	}
+
+	if task.MaxRedirects != nil && (*task.MaxRedirects < 0 || *task.MaxRedirects > DefaultMaxRedirects) {
+		v.add("/maxRedirects", RuleRange, "Invalid request: maxRedirects should be between 0 and 10")
+	}
 }
 
-func validateHeaders(task Task) error {
-	if task.Headers == nil && checkMethodForBody(task.Method) {
-		return errors.New("Invalid Request: Content-type header is required for POST/PUT/PATCH")
+// validateProxy checks the url of the task's proxy, whose username and password can only be secret references. The
+// references are replaced by placeholders for the url to be parsed, as their braces are not valid in its userinfo.
+func validateProxy(rawURL string, v *violations) {
+	var names []string
+
+	placeholder := func(i int) string {
+		return "secret-ref-" + strconv.Itoa(i)
 	}
 
-	if task.Headers != nil && checkMethodForBody(task.Method) {
-		contentType, contentTypePresent := task.Headers[ContentType]
+	proxyURL, err := ParseProxy(secrets.ReplaceReferences(rawURL, func(name string) string {
+		names = append(names, name)
 
-		if !contentTypePresent {
-			return errors.New("Invalid Request: Content-type header is required for POST/PUT/PATCH")
-		}
+		return placeholder(len(names) - 1)
+	}))
+	if err != nil {
+		v.add("/proxy", RuleFormat, err.Error())
 
-		if (task.Method == "POST" || task.Method == "PUT") && contentType != "application/json" {
-			return errors.New("Invalid Request: Content-type with value application/json is required for POST/PUT")
-		}
+		return
+	}
+
+	if proxyURL.User == nil {
+		return
+	}
 
-		if task.Method == "PATCH" && contentType != "application/merge-patch+json" {
-			return errors.New("Invalid Request: Content-type with value application/merge-patch+json is required for PATCH")
+	isReference := func(s string) bool {
+		for i := range names {
+			if s == placeholder(i) {
+				return true
+			}
 		}
+
+		return false
+	}
+
+	password, hasPassword := proxyURL.User.Password()
+
+	username := proxyURL.User.Username()
+
+	if (username != "" && !isReference(username)) || (hasPassword && !isReference(password)) {
+		v.add("/proxy", RuleFormat, "Invalid proxy: the username and password should be secret references, e.g. ${secret:proxy.password}")
+	}
+}
+
+// ParseProxy parses the url of an outbound proxy, only the following schemes are supported [http, https, socks5, socks5h]
+func ParseProxy(rawURL string) (*url.URL, error) {
+	// the parse error quotes the url, which may hold the proxy's credentials
+	proxyURL, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, errors.New("Invalid proxy: malformed url")
+	}
+
+	if proxyURL.Scheme != "http" && proxyURL.Scheme != "https" && proxyURL.Scheme != "socks5" && proxyURL.Scheme != "socks5h" {
+		return nil, errors.New("Invalid proxy: only the following schemes are supported: [http, https, socks5, socks5h]")
+	}
+
+	if proxyURL.Hostname() == "" {
+		return nil, errors.New("Invalid proxy: host cannot be empty")
 	}
 
-	return nil
+	return proxyURL, nil
 }
 
 func isValidScheme(scheme string) bool {
@@ -0,0 +1,157 @@
+package model
+
+import (
+	"errors"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+var templateNamePattern = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+// TaskTemplate represents a named task whose url, header values and data strings may hold Go template
+// placeholders like {{.region}}, rendered with the variables of every task created from it
+type TaskTemplate struct {
+	Name string `json:"name"`
+	Task Task   `json:"task"`
+}
+
+// TemplateVariables represents the request body creating a task from a template
+type TemplateVariables struct {
+	Variables map[string]interface{} `json:"variables"`
+}
+
+// ValidateTemplate checks the name of the template and the syntax of its placeholders, the rendered task is
+// validated as any other task. All the violations are collected, the error being a ValidationError.
+func ValidateTemplate(tmpl TaskTemplate) error {
+	var v violations
+
+	if !templateNamePattern.MatchString(tmpl.Name) {
+		v.add("/name", RuleFormat, "Invalid request: template name should only have letters, digits, '_', '.' or '-'")
+	}
+
+	if tmpl.Task.Method == "" {
+		v.add("/task/method", RuleRequired, "Invalid request: method cannot be empty")
+	}
+
+	if tmpl.Task.URL == "" {
+		v.add("/task/url", RuleRequired, "Invalid request: url cannot be empty")
+	}
+
+	// rendering with no variables only fails on the syntax, missing variables are only known at render time
+	_, _ = tmpl.render(func(field string, pointer string, text string) (string, error) {
+		if _, err := template.New(field).Parse(text); err != nil {
+			v.add("/task"+pointer, RuleFormat, "Invalid template "+field+": "+err.Error())
+		}
+
+		return text, nil
+	})
+
+	return v.err()
+}
+
+// Render gives the task of the template with its placeholders replaced by the variables, a placeholder
+// without a variable is an error
+func (t TaskTemplate) Render(variables map[string]interface{}) (Task, error) {
+	return t.render(func(field string, _ string, text string) (string, error) {
+		if !strings.Contains(text, "{{") {
+			return text, nil
+		}
+
+		tmpl, err := template.New(field).Option("missingkey=error").Parse(text)
+		if err != nil {
+			return "", errors.New("Invalid template " + field + ": " + err.Error())
+		}
+
+		var rendered strings.Builder
+
+		if err = tmpl.Execute(&rendered, variables); err != nil {
+			return "", errors.New("Invalid request: cannot render " + field + ": " + err.Error())
+		}
+
+		return rendered.String(), nil
+	})
+}
+
+// renderFunc renders the text of a field of the task, the pointer being the JSON pointer of the field in the task
+type renderFunc func(field string, pointer string, text string) (string, error)
+
+// render applies the function to the url, header values and data strings of the template's task
+func (t TaskTemplate) render(apply renderFunc) (Task, error) {
+	task := t.Task
+
+	var err error
+
+	task.URL, err = apply("url", "/url", task.URL)
+	if err != nil {
+		return Task{}, err
+	}
+
+	if task.Headers != nil {
+		headers, err := renderValue("headers", "/headers", task.Headers, apply)
+		if err != nil {
+			return Task{}, err
+		}
+
+		task.Headers = headers.(map[string]interface{})
+	}
+
+	if task.Data != nil {
+		data, err := renderValue("data", "/data", task.Data, apply)
+		if err != nil {
+			return Task{}, err
+		}
+
+		task.Data = data.(map[string]interface{})
+	}
+
+	return task, nil
+}
+
+// renderValue walks the decoded JSON value, copying its maps and slices so that the template's own are left untouched
+func renderValue(field string, pointer string, value interface{}, apply renderFunc) (interface{}, error) {
+	switch v := value.(type) {
+	case string:
+		return apply(field, pointer, v)
+	case map[string]interface{}:
+		rendered := make(map[string]interface{}, len(v))
+
+		// the keys are walked in order, for the first error of the template to be the same on every render
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			item := v[key]
+
+			renderedItem, err := renderValue(field+"."+key, pointer+"/"+PointerToken(key), item, apply)
+			if err != nil {
+				return nil, err
+			}
+
+			rendered[key] = renderedItem
+		}
+
+		return rendered, nil
+	case []interface{}:
+		rendered := make([]interface{}, len(v))
+
+		for i, item := range v {
+			renderedItem, err := renderValue(field, pointer+"/"+strconv.Itoa(i), item, apply)
+			if err != nil {
+				return nil, err
+			}
+
+			rendered[i] = renderedItem
+		}
+
+		return rendered, nil
+	default:
+		return v, nil
+	}
+}
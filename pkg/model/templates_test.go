@@ -0,0 +1,63 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTemplates_ValidateTemplate(t *testing.T) {
+	tcs := []struct {
+		description string
+		tmpl        TaskTemplate
+		expErr      error
+	}{
+		{
+			description: "Positive case: valid template",
+			tmpl: TaskTemplate{Name: "health", Task: Task{Method: "GET", URL: "https://{{.region}}.partner.com/health",
+				Headers: map[string]interface{}{"X-Tenant": "{{.tenant}}"}}},
+		},
+		{
+			description: "Negative case: invalid name",
+			tmpl:        TaskTemplate{Name: "health check", Task: Task{Method: "GET", URL: "https://partner.com"}},
+			expErr:      invalid("/name", RuleFormat, "Invalid request: template name should only have letters, digits, '_', '.' or '-'"),
+		},
+		{
+			description: "Negative case: invalid placeholder",
+			tmpl: TaskTemplate{Name: "health", Task: Task{Method: "GET", URL: "https://partner.com",
+				Headers: map[string]interface{}{"X-Tenant": "{{.tenant"}}},
+			expErr: invalid("/task/headers/X-Tenant", RuleFormat, "Invalid template headers.X-Tenant: template: headers.X-Tenant:1: unclosed action"),
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			err := ValidateTemplate(tc.tmpl)
+
+			assert.Equal(t, tc.expErr, err)
+		})
+	}
+}
+
+func TestTemplates_Render(t *testing.T) {
+	tmpl := TaskTemplate{Name: "orders", Task: Task{
+		Method:  "POST",
+		URL:     "https://{{.region}}.partner.com/orders",
+		Headers: map[string]interface{}{ContentType: "application/json", "Authorization": "Bearer ${secret:partner.token}"},
+		Data:    map[string]interface{}{"items": []interface{}{"{{.item}}"}, "count": 2.0},
+	}}
+
+	task, err := tmpl.Render(map[string]interface{}{"region": "eu", "item": "book"})
+	assert.Nil(t, err)
+	assert.Equal(t, "https://eu.partner.com/orders", task.URL)
+	assert.Equal(t, "Bearer ${secret:partner.token}", task.Headers["Authorization"])
+	assert.Equal(t, map[string]interface{}{"items": []interface{}{"book"}, "count": 2.0}, task.Data)
+
+	// the template itself keeps its placeholders
+	assert.Equal(t, []interface{}{"{{.item}}"}, tmpl.Task.Data["items"])
+
+	_, err = tmpl.Render(map[string]interface{}{"region": "eu"})
+	assert.EqualError(t, err, `Invalid request: cannot render data.items: template: data.items:1:2: executing "data.items" at <.item>: map has no entry for key "item"`)
+}
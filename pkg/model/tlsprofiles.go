@@ -0,0 +1,11 @@
+package model
+
+// TLSProfile represents the TLS settings of the outbound calls of the tasks selecting it by name
+type TLSProfile struct {
+	CertFile           string `json:"certFile,omitempty"`
+	KeyFile            string `json:"keyFile,omitempty"`
+	CAFile             string `json:"caFile,omitempty"`
+	MinVersion         string `json:"minVersion,omitempty"`
+	ServerName         string `json:"serverName,omitempty"`
+	InsecureSkipVerify bool   `json:"insecureSkipVerify,omitempty"`
+}
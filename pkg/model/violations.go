@@ -0,0 +1,112 @@
+package model
+
+import (
+	"errors"
+	"strings"
+)
+
+// Rules of the violations, telling what a field of a request fails to meet
+const (
+	// RuleRequired is a field that cannot be empty
+	RuleRequired = "required"
+	// RuleEnum is a field whose value should be one of a list
+	RuleEnum = "enum"
+	// RuleFormat is a field whose value is malformed, e.g. a url, a duration or a JSONPath
+	RuleFormat = "format"
+	// RuleRange is a number, or a number of items, out of its bounds
+	RuleRange = "range"
+	// RuleForbidden is a field that cannot be set along with the other fields of the request
+	RuleForbidden = "forbidden"
+	// RuleUnique is a value that should only appear once, e.g. the ID of a workflow node
+	RuleUnique = "unique"
+	// RuleReference is a reference to something the request does not have, e.g. an unknown workflow node
+	RuleReference = "reference"
+	// RuleCycle is a reference closing a cycle, e.g. of the dependencies of workflow nodes
+	RuleCycle = "cycle"
+	// RuleInvalid is any other problem with the field
+	RuleInvalid = "invalid"
+)
+
+// Violation represents a problem with a field of a request, the Pointer being the JSON pointer of the field in the
+// request body, e.g. /nodes/0/task/url
+type Violation struct {
+	Pointer string `json:"pointer"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// ValidationError holds all the violations found in a request, its message being the ones of its violations
+type ValidationError struct {
+	Violations []Violation
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, 0, len(e.Violations))
+	for _, violation := range e.Violations {
+		messages = append(messages, violation.Message)
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+// Problem represents the body of an error response as per RFC 7807, along with the code of the error and the
+// violations of an invalid request
+type Problem struct {
+	Type       string      `json:"type"`
+	Title      string      `json:"title"`
+	Status     int         `json:"status"`
+	Detail     string      `json:"detail"`
+	Code       string      `json:"code"`
+	Violations []Violation `json:"violations,omitempty"`
+}
+
+// violations collects the violations of a request, so that they are all reported at once
+type violations []Violation
+
+func (v *violations) add(pointer, rule, message string) {
+	*v = append(*v, Violation{Pointer: pointer, Rule: rule, Message: message})
+}
+
+// has tells if the violation was already collected
+func (v violations) has(violation Violation) bool {
+	for _, collected := range v {
+		if collected == violation {
+			return true
+		}
+	}
+
+	return false
+}
+
+// nest adds the violations of the error found in the part of the request at the pointer, with their messages
+// prefixed. An error other than a ValidationError is a violation of the part as a whole, a nil one has none.
+func (v *violations) nest(pointer, prefix string, err error) {
+	if err == nil {
+		return
+	}
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		v.add(pointer, RuleInvalid, prefix+err.Error())
+
+		return
+	}
+
+	for _, violation := range validationErr.Violations {
+		v.add(pointer+violation.Pointer, violation.Rule, prefix+violation.Message)
+	}
+}
+
+// err gives the ValidationError of the violations, nil if there is none
+func (v violations) err() error {
+	if len(v) == 0 {
+		return nil
+	}
+
+	return &ValidationError{Violations: v}
+}
+
+// PointerToken escapes the key for a JSON pointer, as per RFC 6901
+func PointerToken(key string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(key, "~", "~0"), "/", "~1")
+}
@@ -0,0 +1,74 @@
+package model
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// invalid gives the ValidationError of a single violation
+func invalid(pointer, rule, message string) error {
+	return &ValidationError{Violations: []Violation{{Pointer: pointer, Rule: rule, Message: message}}}
+}
+
+func TestViolations_ValidateRequestBody(t *testing.T) {
+	maxRedirects := 11
+
+	err := ValidateRequestBody(Task{
+		Method:       "POST",
+		URL:          "ftp://www.getyourtasks.com/task",
+		MaxRedirects: &maxRedirects,
+		Auth:         &Auth{Type: AuthBearer},
+		Extract:      map[string]ExtractRule{"token": {From: "cookie"}},
+	})
+
+	assert.Equal(t, &ValidationError{Violations: []Violation{
+		{Pointer: "/url", Rule: RuleEnum, Message: "Invalid URL: only the following schemes are supported: [http, https]"},
+		{Pointer: "/data", Rule: RuleRequired, Message: "Invalid request: data attribute is required for POST, PUT, or PATCH requests"},
+		{Pointer: "/headers/Content-Type", Rule: RuleRequired, Message: "Invalid Request: Content-type header is required for POST/PUT/PATCH"},
+		{Pointer: "/maxRedirects", Rule: RuleRange, Message: "Invalid request: maxRedirects should be between 0 and 10"},
+		{Pointer: "/auth/token", Rule: RuleRequired, Message: "Invalid request: auth token cannot be empty"},
+		{Pointer: "/extract/token/from", Rule: RuleEnum, Message: "Invalid request: extract token: from should be one of [body, header, regex, status]"},
+	}}, err)
+}
+
+func TestViolations_ValidateChain(t *testing.T) {
+	err := ValidateChain(Chain{Steps: []ChainStep{
+		{Task: Task{Method: "GET", URL: "https://www.getyourtasks.com/login"}},
+		{
+			Task:    Task{Method: "GET", URL: "https://www.getyourtasks.com/{{.token"},
+			Extract: map[string]ExtractRule{"a/b": {From: ExtractFromHeader}},
+		},
+	}})
+
+	assert.Equal(t, &ValidationError{Violations: []Violation{
+		{Pointer: "/steps/1/task/url", Rule: RuleFormat,
+			Message: "Invalid step 1: Invalid template url: template: url:1: unclosed action"},
+		{Pointer: "/steps/1/extract/a~1b", Rule: RuleFormat,
+			Message: "Invalid step 1: Invalid request: extract name a/b should be a letter or '_' followed by letters, digits or '_'"},
+		{Pointer: "/steps/1/extract/a~1b/path", Rule: RuleRequired,
+			Message: "Invalid step 1: Invalid request: extract a/b: path cannot be empty"},
+	}}, err)
+}
+
+func TestViolations_Error(t *testing.T) {
+	err := &ValidationError{Violations: []Violation{
+		{Pointer: "/method", Rule: RuleRequired, Message: "Invalid request: method cannot be empty"},
+		{Pointer: "/url", Rule: RuleRequired, Message: "Invalid request: url cannot be empty"},
+	}}
+
+	assert.Equal(t, "Invalid request: method cannot be empty; Invalid request: url cannot be empty", err.Error())
+
+	var v violations
+
+	v.nest("/task", "", err)
+	v.nest("/proxy", "", errors.New("Invalid proxy: malformed url"))
+	v.nest("/task", "", nil)
+
+	assert.Equal(t, violations{
+		{Pointer: "/task/method", Rule: RuleRequired, Message: "Invalid request: method cannot be empty"},
+		{Pointer: "/task/url", Rule: RuleRequired, Message: "Invalid request: url cannot be empty"},
+		{Pointer: "/proxy", Rule: RuleInvalid, Message: "Invalid proxy: malformed url"},
+	}, v)
+}
@@ -0,0 +1,145 @@
+package model
+
+import "strconv"
+
+// MaxWorkflowNodes caps the number of nodes of a workflow
+const MaxWorkflowNodes = 50
+
+// Workflow represents tasks run as a graph, each node's task being started once the nodes it depends on reached
+// a terminal status (done, error or skipped)
+type Workflow struct {
+	ID     string         `json:"id,omitempty"`
+	Status string         `json:"status,omitempty"`
+	Nodes  []WorkflowNode `json:"nodes"`
+	Error  *TaskError     `json:"error,omitempty"`
+}
+
+// WorkflowNode represents a node of a workflow along with the task it created. A node whose dependencies' conditions
+// are not met is skipped.
+type WorkflowNode struct {
+	ID        string       `json:"id"`
+	Task      Task         `json:"task"`
+	DependsOn []Dependency `json:"dependsOn,omitempty"`
+	Status    string       `json:"status,omitempty"`
+	TaskID    string       `json:"taskId,omitempty"`
+	Result    *TasksObject `json:"result,omitempty"`
+	Error     *TaskError   `json:"error,omitempty"`
+}
+
+// Dependency represents a node another node waits for, the Condition being one of [success, failure, always],
+// defaults to success
+type Dependency struct {
+	Node      string `json:"node"`
+	Condition string `json:"condition,omitempty"`
+}
+
+// Terminal tells if a workflow or one of its nodes reached a status it does not leave
+func Terminal(status string) bool {
+	return status == Done || status == Error || status == Skipped
+}
+
+// ValidateWorkflow checks the tasks of the nodes, their IDs and their dependencies, which cannot form a cycle
+func ValidateWorkflow(workflow Workflow) error {
+	var v violations
+
+	if len(workflow.Nodes) == 0 {
+		v.add("/nodes", RuleRequired, "Invalid request: nodes cannot be empty")
+	}
+
+	if len(workflow.Nodes) > MaxWorkflowNodes {
+		v.add("/nodes", RuleRange, "Invalid request: a workflow cannot have more than "+strconv.Itoa(MaxWorkflowNodes)+" nodes")
+	}
+
+	nodes := make(map[string]int, len(workflow.Nodes))
+
+	for i, node := range workflow.Nodes {
+		pointer := "/nodes/" + strconv.Itoa(i) + "/id"
+
+		if !templateNamePattern.MatchString(node.ID) {
+			v.add(pointer, RuleFormat, "Invalid request: node id should only have letters, digits, '_', '.' or '-'")
+		}
+
+		if _, ok := nodes[node.ID]; ok {
+			v.add(pointer, RuleUnique, "Invalid request: duplicate node id "+node.ID)
+
+			continue
+		}
+
+		nodes[node.ID] = i
+	}
+
+	for i, node := range workflow.Nodes {
+		pointer := "/nodes/" + strconv.Itoa(i)
+		prefix := "Invalid node " + node.ID + ": "
+
+		v.nest(pointer+"/task", prefix, ValidateRequestBody(node.Task))
+
+		for j, dependency := range node.DependsOn {
+			dependencyPointer := pointer + "/dependsOn/" + strconv.Itoa(j)
+
+			if _, ok := nodes[dependency.Node]; !ok {
+				v.add(dependencyPointer+"/node", RuleReference, prefix+"Invalid request: dependsOn unknown node "+dependency.Node)
+			}
+
+			switch dependency.Condition {
+			case "", DependsOnSuccess, DependsOnFailure, DependsOnAlways:
+			default:
+				v.add(dependencyPointer+"/condition", RuleEnum,
+					prefix+"Invalid request: dependsOn condition should be one of [success, failure, always]")
+			}
+		}
+	}
+
+	if node := cycleNode(workflow.Nodes); node != "" {
+		v.add("/nodes/"+strconv.Itoa(nodes[node])+"/dependsOn", RuleCycle, "Invalid request: dependsOn forms a cycle through node "+node)
+	}
+
+	return v.err()
+}
+
+// cycleNode gives a node of a dependency cycle, empty if there is none. The nodes are visited depth first, a node
+// reached again while its dependencies are still being visited closes a cycle.
+func cycleNode(nodes []WorkflowNode) string {
+	const (
+		visiting = 1
+		visited  = 2
+	)
+
+	dependencies := make(map[string][]Dependency, len(nodes))
+	for _, node := range nodes {
+		dependencies[node.ID] = node.DependsOn
+	}
+
+	state := make(map[string]int, len(nodes))
+
+	var visit func(id string) string
+
+	visit = func(id string) string {
+		switch state[id] {
+		case visiting:
+			return id
+		case visited:
+			return ""
+		}
+
+		state[id] = visiting
+
+		for _, dependency := range dependencies[id] {
+			if node := visit(dependency.Node); node != "" {
+				return node
+			}
+		}
+
+		state[id] = visited
+
+		return ""
+	}
+
+	for _, node := range nodes {
+		if id := visit(node.ID); id != "" {
+			return id
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,72 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkflows_ValidateWorkflow(t *testing.T) {
+	task := Task{Method: "GET", URL: "https://www.getyourtasks.com/task"}
+
+	tcs := []struct {
+		description string
+		workflow    Workflow
+		expErr      error
+	}{
+		{
+			description: "Positive case: valid graph",
+			workflow: Workflow{Nodes: []WorkflowNode{
+				{ID: "a", Task: task},
+				{ID: "b", Task: task, DependsOn: []Dependency{{Node: "a"}}},
+				{ID: "c", Task: task, DependsOn: []Dependency{{Node: "a", Condition: DependsOnFailure}}},
+				{ID: "d", Task: task, DependsOn: []Dependency{{Node: "b", Condition: DependsOnAlways}, {Node: "c", Condition: DependsOnAlways}}},
+			}},
+		},
+		{
+			description: "Negative case: no nodes",
+			workflow:    Workflow{},
+			expErr:      invalid("/nodes", RuleRequired, "Invalid request: nodes cannot be empty"),
+		},
+		{
+			description: "Negative case: duplicate node id",
+			workflow:    Workflow{Nodes: []WorkflowNode{{ID: "a", Task: task}, {ID: "a", Task: task}}},
+			expErr:      invalid("/nodes/1/id", RuleUnique, "Invalid request: duplicate node id a"),
+		},
+		{
+			description: "Negative case: invalid task",
+			workflow:    Workflow{Nodes: []WorkflowNode{{ID: "a", Task: Task{Method: "GET"}}}},
+			expErr:      invalid("/nodes/0/task/url", RuleRequired, "Invalid node a: Invalid request: url cannot be empty"),
+		},
+		{
+			description: "Negative case: unknown dependency",
+			workflow:    Workflow{Nodes: []WorkflowNode{{ID: "a", Task: task, DependsOn: []Dependency{{Node: "b"}}}}},
+			expErr:      invalid("/nodes/0/dependsOn/0/node", RuleReference, "Invalid node a: Invalid request: dependsOn unknown node b"),
+		},
+		{
+			description: "Negative case: invalid condition",
+			workflow: Workflow{Nodes: []WorkflowNode{
+				{ID: "a", Task: task},
+				{ID: "b", Task: task, DependsOn: []Dependency{{Node: "a", Condition: "sometimes"}}},
+			}},
+			expErr: invalid("/nodes/1/dependsOn/0/condition", RuleEnum, "Invalid node b: Invalid request: dependsOn condition should be one of [success, failure, always]"),
+		},
+		{
+			description: "Negative case: cycle",
+			workflow: Workflow{Nodes: []WorkflowNode{
+				{ID: "a", Task: task, DependsOn: []Dependency{{Node: "c"}}},
+				{ID: "b", Task: task, DependsOn: []Dependency{{Node: "a"}}},
+				{ID: "c", Task: task, DependsOn: []Dependency{{Node: "b"}}},
+			}},
+			expErr: invalid("/nodes/0/dependsOn", RuleCycle, "Invalid request: dependsOn forms a cycle through node a"),
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, tc.expErr, ValidateWorkflow(tc.workflow))
+		})
+	}
+}
@@ -0,0 +1,238 @@
+package openapi
+
+import (
+	"net/http"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/axxonsoft-assignment/pkg/model"
+)
+
+const (
+	jsonContentType    = "application/json"
+	problemContentType = "application/problem+json"
+	binaryContentType  = "application/octet-stream"
+)
+
+var pathParamPattern = regexp.MustCompile(`{([^}]+)}`)
+
+// Document represents an OpenAPI 3 document
+type Document struct {
+	OpenAPI    string              `json:"openapi"`
+	Info       Info                `json:"info"`
+	Paths      map[string]PathItem `json:"paths"`
+	Components Components          `json:"components"`
+}
+
+// Info represents the title and version of the API
+type Info struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+// PathItem represents the operations of a path, by lower case HTTP method
+type PathItem map[string]*Operation
+
+// Operation represents a route of the API
+type Operation struct {
+	OperationID string               `json:"operationId"`
+	Summary     string               `json:"summary"`
+	Parameters  []Parameter          `json:"parameters,omitempty"`
+	RequestBody *RequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*Response `json:"responses"`
+}
+
+// Parameter represents a path or header parameter of an operation
+type Parameter struct {
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required,omitempty"`
+	Schema   *Schema `json:"schema"`
+}
+
+// RequestBody represents the body of the request of an operation
+type RequestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+// MediaType represents the schema of a body of a given content type
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Response represents a response of an operation, by status code
+type Response struct {
+	Description string               `json:"description"`
+	Headers     map[string]*Header   `json:"headers,omitempty"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+// Header represents a header of a response
+type Header struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Components represents the schemas the operations refer to
+type Components struct {
+	Schemas map[string]*Schema `json:"schemas"`
+}
+
+// operation describes a route of the service, the request and response bodies being values of the model types, no
+// body being nil. Every operation answers its errors with a problem.
+type operation struct {
+	method   string
+	path     string
+	id       string
+	summary  string
+	request  interface{}
+	status   int
+	response interface{}
+	// location tells if the response gives the location of the created resource
+	location bool
+	// contentType is the content type of the response, defaults to JSON
+	contentType string
+}
+
+// operations are the routes registered by routes.New, in the same order
+var operations = []operation{
+	{method: http.MethodPost, path: "/task", id: "createTask", summary: "Creates a task, run in the background",
+		request: model.Task{}, status: http.StatusAccepted, response: model.TasksResponse{}, location: true},
+	{method: http.MethodGet, path: "/task/{taskID}", id: "getTask", summary: "Gives the status and the result of a task",
+		status: http.StatusOK, response: model.TasksObject{}},
+	{method: http.MethodGet, path: "/task/{taskID}/body", id: "getTaskBody", status: http.StatusOK, contentType: binaryContentType,
+		summary: "Downloads the response body of a task stored in the blob store, a Range header giving a part of it"},
+	{method: http.MethodPost, path: "/task/from-template/{name}", id: "createTaskFromTemplate",
+		summary: "Creates a task from a template rendered with the variables", request: model.TemplateVariables{},
+		status: http.StatusAccepted, response: model.TasksResponse{}, location: true},
+
+	{method: http.MethodPost, path: "/schedules", id: "createSchedule", summary: "Creates a schedule",
+		request: model.Schedule{}, status: http.StatusCreated, response: model.Schedule{}, location: true},
+	{method: http.MethodGet, path: "/schedules", id: "listSchedules", summary: "Lists the schedules",
+		status: http.StatusOK, response: []model.Schedule{}},
+	{method: http.MethodGet, path: "/schedules/{scheduleID}", id: "getSchedule", summary: "Gives a schedule",
+		status: http.StatusOK, response: model.Schedule{}},
+	{method: http.MethodPut, path: "/schedules/{scheduleID}", id: "updateSchedule", summary: "Updates a schedule",
+		request: model.Schedule{}, status: http.StatusOK, response: model.Schedule{}},
+	{method: http.MethodDelete, path: "/schedules/{scheduleID}", id: "deleteSchedule",
+		summary: "Deletes a schedule, the tasks it created are retained", status: http.StatusNoContent},
+	{method: http.MethodPost, path: "/schedules/{scheduleID}/pause", id: "pauseSchedule", summary: "Pauses a schedule",
+		status: http.StatusOK, response: model.Schedule{}},
+	{method: http.MethodPost, path: "/schedules/{scheduleID}/resume", id: "resumeSchedule", summary: "Resumes a paused schedule",
+		status: http.StatusOK, response: model.Schedule{}},
+	{method: http.MethodGet, path: "/schedules/{scheduleID}/history", id: "getScheduleHistory",
+		summary: "Lists the runs of a schedule", status: http.StatusOK, response: []model.ScheduleRun{}},
+
+	{method: http.MethodPost, path: "/chains", id: "createChain", summary: "Creates a chain, run in the background",
+		request: model.Chain{}, status: http.StatusAccepted, response: model.Chain{}, location: true},
+	{method: http.MethodGet, path: "/chains/{chainID}", id: "getChain", summary: "Gives the status and the steps of a chain",
+		status: http.StatusOK, response: model.Chain{}},
+
+	{method: http.MethodPost, path: "/workflows", id: "createWorkflow", summary: "Creates a workflow, run in the background",
+		request: model.Workflow{}, status: http.StatusAccepted, response: model.Workflow{}, location: true},
+	{method: http.MethodGet, path: "/workflows/{workflowID}", id: "getWorkflow",
+		summary: "Gives the status and the nodes of a workflow", status: http.StatusOK, response: model.Workflow{}},
+
+	{method: http.MethodGet, path: "/templates", id: "listTemplates", summary: "Lists the templates",
+		status: http.StatusOK, response: []model.TaskTemplate{}},
+	{method: http.MethodGet, path: "/templates/{name}", id: "getTemplate", summary: "Gives a template",
+		status: http.StatusOK, response: model.TaskTemplate{}},
+	{method: http.MethodPut, path: "/templates/{name}", id: "setTemplate", summary: "Creates or replaces a template",
+		request: model.TaskTemplate{}, status: http.StatusOK, response: model.TaskTemplate{}},
+	{method: http.MethodDelete, path: "/templates/{name}", id: "deleteTemplate", summary: "Deletes a template",
+		status: http.StatusNoContent},
+
+	{method: http.MethodGet, path: "/ratelimits", id: "listRateLimits", summary: "Lists the rate limits",
+		status: http.StatusOK, response: []model.RateLimit{}},
+	{method: http.MethodGet, path: "/ratelimits/{host}", id: "getRateLimit", summary: "Gives the rate limit of a host",
+		status: http.StatusOK, response: model.RateLimit{}},
+	{method: http.MethodPut, path: "/ratelimits/{host}", id: "setRateLimit", summary: "Creates or replaces the rate limit of a host",
+		request: model.RateLimit{}, status: http.StatusOK, response: model.RateLimit{}},
+	{method: http.MethodDelete, path: "/ratelimits/{host}", id: "deleteRateLimit", summary: "Deletes the rate limit of a host",
+		status: http.StatusNoContent},
+
+	{method: http.MethodGet, path: "/admin/breakers", id: "listBreakers",
+		summary: "Lists the circuit state of every host called so far", status: http.StatusOK, response: []model.CircuitState{}},
+	{method: http.MethodGet, path: "/admin/breakers/{host}", id: "getBreaker", summary: "Gives the circuit state of a host",
+		status: http.StatusOK, response: model.CircuitState{}},
+	{method: http.MethodPost, path: "/admin/breakers/{host}/reset", id: "resetBreaker", summary: "Closes the circuit of a host",
+		status: http.StatusOK, response: model.CircuitState{}},
+
+	{method: http.MethodGet, path: "/openapi.json", id: "getOpenAPI", summary: "Gives this OpenAPI document",
+		status: http.StatusOK, response: map[string]interface{}{}},
+}
+
+// New gives the OpenAPI document of the service, the schemas of the bodies being generated from the model types
+func New() *Document {
+	components := schemas{}
+	problem := components.of(reflect.TypeOf(model.Problem{}))
+
+	doc := &Document{
+		OpenAPI:    "3.0.3",
+		Info:       Info{Title: "axxonsoft-assignment", Version: "1.0.0"},
+		Paths:      map[string]PathItem{},
+		Components: Components{Schemas: components},
+	}
+
+	for _, op := range operations {
+		operation := &Operation{
+			OperationID: op.id,
+			Summary:     op.summary,
+			Parameters:  pathParams(op.path),
+			Responses: map[string]*Response{
+				"default": {
+					Description: "The error, as per RFC 7807",
+					Content:     map[string]MediaType{problemContentType: {Schema: problem}},
+				},
+			},
+		}
+
+		if op.request != nil {
+			operation.RequestBody = &RequestBody{
+				Required: true,
+				Content:  map[string]MediaType{jsonContentType: {Schema: components.of(reflect.TypeOf(op.request))}},
+			}
+		}
+
+		response := &Response{Description: http.StatusText(op.status)}
+
+		switch {
+		case op.contentType == binaryContentType:
+			operation.Parameters = append(operation.Parameters, Parameter{Name: "Range", In: "header", Schema: &Schema{Type: "string"}})
+			response.Content = map[string]MediaType{binaryContentType: {Schema: &Schema{Type: "string", Format: "binary"}}}
+			operation.Responses[strconv.Itoa(http.StatusPartialContent)] = &Response{
+				Description: http.StatusText(http.StatusPartialContent),
+				Content:     response.Content,
+			}
+		case op.response != nil:
+			response.Content = map[string]MediaType{jsonContentType: {Schema: components.of(reflect.TypeOf(op.response))}}
+		}
+
+		if op.location {
+			response.Headers = map[string]*Header{"Location": {Schema: &Schema{Type: "string"}}}
+		}
+
+		operation.Responses[strconv.Itoa(op.status)] = response
+
+		if doc.Paths[op.path] == nil {
+			doc.Paths[op.path] = PathItem{}
+		}
+
+		doc.Paths[op.path][strings.ToLower(op.method)] = operation
+	}
+
+	return doc
+}
+
+// pathParams gives the parameters of the placeholders of the path, e.g. {taskID}
+func pathParams(path string) []Parameter {
+	var params []Parameter
+
+	for _, match := range pathParamPattern.FindAllStringSubmatch(path, -1) {
+		params = append(params, Parameter{Name: match[1], In: "path", Required: true, Schema: &Schema{Type: "string"}})
+	}
+
+	return params
+}
@@ -0,0 +1,60 @@
+package openapi
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpenAPI_schemas(t *testing.T) {
+	type node struct {
+		ID       string            `json:"id"`
+		Children []*node           `json:"children,omitempty"`
+		Labels   map[string]string `json:"labels,omitempty"`
+		Value    interface{}       `json:"value"`
+		Weight   float64           `json:"weight"`
+		Size     int64             `json:"size"`
+		At       time.Time         `json:"at"`
+		Internal string            `json:"-"`
+		Headers  http.Header
+		hidden   bool
+	}
+
+	components := schemas{}
+
+	assert.Equal(t, &Schema{Type: "array", Items: &Schema{Ref: "#/components/schemas/node"}},
+		components.of(reflect.TypeOf([]node{})))
+
+	assert.Equal(t, schemas{"node": {Type: "object", Properties: map[string]*Schema{
+		"id":       {Type: "string"},
+		"children": {Type: "array", Items: &Schema{Ref: "#/components/schemas/node"}},
+		"labels":   {Type: "object", AdditionalProperties: &Schema{Type: "string"}},
+		"value":    {},
+		"weight":   {Type: "number"},
+		"size":     {Type: "integer", Format: "int64"},
+		"at":       {Type: "string", Format: "date-time"},
+		"Headers":  {Type: "object", AdditionalProperties: &Schema{Type: "array", Items: &Schema{Type: "string"}}},
+	}}}, components)
+}
+
+func TestOpenAPI_New(t *testing.T) {
+	doc := New()
+
+	operation := doc.Paths["/schedules/{scheduleID}/pause"]["post"]
+	assert.Equal(t, "pauseSchedule", operation.OperationID)
+	assert.Equal(t, []Parameter{{Name: "scheduleID", In: "path", Required: true, Schema: &Schema{Type: "string"}}}, operation.Parameters)
+	assert.Equal(t, &Schema{Ref: "#/components/schemas/Schedule"}, operation.Responses["200"].Content[jsonContentType].Schema)
+	assert.Equal(t, &Schema{Ref: "#/components/schemas/Problem"}, operation.Responses["default"].Content[problemContentType].Schema)
+
+	operation = doc.Paths["/task"]["post"]
+	assert.Equal(t, &Schema{Ref: "#/components/schemas/Task"}, operation.RequestBody.Content[jsonContentType].Schema)
+	assert.Contains(t, operation.Responses["202"].Headers, "Location")
+
+	// the fields left out of the JSON encoding are left out of the schema
+	assert.NotContains(t, doc.Components.Schemas["Task"].Properties, "ScheduleID")
+	assert.Contains(t, doc.Components.Schemas["Task"].Properties, "fanOut")
+	assert.Contains(t, doc.Components.Schemas, "Violation")
+}
@@ -0,0 +1,97 @@
+package openapi
+
+import (
+	"reflect"
+	"strings"
+	"time"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// Schema represents the schema of a JSON value, a Ref pointing to the schema of a struct in the components
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+}
+
+// schemas generates the schemas of the Go types from their JSON encoding, each struct being a schema of its own in
+// the components, named after its type
+type schemas map[string]*Schema
+
+// of gives the schema of the type, a reference for a struct
+func (s schemas) of(t reflect.Type) *Schema {
+	if t == timeType {
+		return &Schema{Type: "string", Format: "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		return s.of(t.Elem())
+	case reflect.Struct:
+		return s.ref(t)
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: s.of(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: s.of(t.Elem())}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	default:
+		// an interface is any JSON value
+		return &Schema{}
+	}
+}
+
+// ref registers the schema of the struct under its name, before its fields for a recursive struct to refer to itself
+func (s schemas) ref(t reflect.Type) *Schema {
+	ref := &Schema{Ref: "#/components/schemas/" + t.Name()}
+
+	if _, ok := s[t.Name()]; ok {
+		return ref
+	}
+
+	schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	s[t.Name()] = schema
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		name, ok := jsonName(field)
+		if !ok {
+			continue
+		}
+
+		schema.Properties[name] = s.of(field.Type)
+	}
+
+	return ref
+}
+
+// jsonName gives the name of the field in the JSON encoding, false for a field left out of it
+func jsonName(field reflect.StructField) (string, bool) {
+	if !field.IsExported() {
+		return "", false
+	}
+
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", false
+	}
+
+	if name, _, _ := strings.Cut(tag, ","); name != "" {
+		return name, true
+	}
+
+	return field.Name, true
+}
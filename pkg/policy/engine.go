@@ -0,0 +1,357 @@
+package policy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/netip"
+	"net/url"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/axxonsoft-assignment/pkg/model"
+)
+
+// ErrDenied is wrapped by the errors of the destinations denied by the policy
+var ErrDenied = errors.New("destination denied by policy")
+
+type engine struct {
+	mu       sync.RWMutex
+	file     string
+	interval time.Duration
+	modTime  time.Time
+	policy   model.Policy
+	// lookup resolves the host names matched against the CIDRs of the rules
+	lookup func(ctx context.Context, host string) ([]netip.Addr, error)
+}
+
+// New loads the policy file, it is re-read every interval once Watch is called. Without a file every destination is allowed.
+func New(file string, interval time.Duration) (Engine, error) {
+	e := &engine{file: file, interval: interval, policy: model.Policy{DefaultAction: model.PolicyAllow}, lookup: lookupHost}
+
+	if file == "" {
+		return e, nil
+	}
+
+	if err := e.Reload(); err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+// Evaluate checks the method and url against the rules by decreasing priority, the first matching rule decides.
+// The error names the rule which denied the destination. A host name is resolved once a rule with a CIDR is reached,
+// the CIDR being matched against the addresses it resolves to.
+func (e *engine) Evaluate(ctx context.Context, method string, rawURL string) error {
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil {
+		return errors.New("Invalid URL in request body: " + err.Error())
+	}
+
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	host := &destination{name: strings.ToLower(parsedURL.Hostname())}
+
+	for _, rule := range e.policy.Rules {
+		matched, err := e.matches(ctx, rule, strings.ToUpper(method), parsedURL, host)
+		if err != nil {
+			return fmt.Errorf("Invalid request: %w rule %s: %v", ErrDenied, rule.Name, err)
+		}
+
+		if !matched {
+			continue
+		}
+
+		if rule.Action == model.PolicyDeny {
+			return fmt.Errorf("Invalid request: %w rule %s", ErrDenied, rule.Name)
+		}
+
+		return nil
+	}
+
+	if e.policy.DefaultAction == model.PolicyDeny {
+		return fmt.Errorf("Invalid request: %w default action", ErrDenied)
+	}
+
+	return nil
+}
+
+// Reload re-reads the policy file, the current policy is kept if the file is invalid.
+func (e *engine) Reload() error {
+	info, err := os.Stat(e.file)
+	if err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(e.file)
+	if err != nil {
+		return err
+	}
+
+	policy, err := parse(data)
+	if err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.policy = policy
+	e.modTime = info.ModTime()
+
+	return nil
+}
+
+// Watch reloads the policy file whenever it changes, until the context is cancelled.
+func (e *engine) Watch(ctx context.Context) {
+	if e.file == "" || e.interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			info, err := os.Stat(e.file)
+			if err != nil {
+				log.Printf("Error reading policy file %s: %v", e.file, err)
+
+				continue
+			}
+
+			e.mu.RLock()
+			changed := !info.ModTime().Equal(e.modTime)
+			e.mu.RUnlock()
+
+			if !changed {
+				continue
+			}
+
+			if err = e.Reload(); err != nil {
+				log.Printf("Error reloading policy file %s, keeping the current policy: %v", e.file, err)
+
+				continue
+			}
+
+			log.Printf("Reloaded policy file %s", e.file)
+		}
+	}
+}
+
+func parse(data []byte) (model.Policy, error) {
+	var policy model.Policy
+
+	if err := json.Unmarshal(data, &policy); err != nil {
+		return policy, errors.New("Invalid policy: " + err.Error())
+	}
+
+	if policy.DefaultAction == "" {
+		policy.DefaultAction = model.PolicyAllow
+	}
+
+	if policy.DefaultAction != model.PolicyAllow && policy.DefaultAction != model.PolicyDeny {
+		return policy, errors.New("Invalid policy: defaultAction should be one of [allow, deny]")
+	}
+
+	for i, rule := range policy.Rules {
+		if rule.Name == "" {
+			return policy, errors.New("Invalid policy: rule " + strconv.Itoa(i) + " has no name")
+		}
+
+		if rule.Action != model.PolicyAllow && rule.Action != model.PolicyDeny {
+			return policy, errors.New("Invalid policy: action of rule " + rule.Name + " should be one of [allow, deny]")
+		}
+
+		for _, host := range rule.Hosts {
+			if _, err := netip.ParsePrefix(host); err == nil {
+				continue
+			}
+
+			if _, err := path.Match(host, ""); err != nil {
+				return policy, errors.New("Invalid policy: malformed host " + host + " in rule " + rule.Name)
+			}
+		}
+
+		for j, method := range rule.Methods {
+			policy.Rules[i].Methods[j] = strings.ToUpper(method)
+		}
+	}
+
+	sort.SliceStable(policy.Rules, func(i, j int) bool {
+		return policy.Rules[i].Priority > policy.Rules[j].Priority
+	})
+
+	return policy, nil
+}
+
+// destination is the host of the evaluated url, along with its addresses once resolved
+type destination struct {
+	name     string
+	addrs    []netip.Addr
+	resolved bool
+	err      error
+}
+
+// matches tells if the rule applies to the call. It fails when the host cannot be resolved for a deny rule with a
+// CIDR, as the destination cannot be told apart from the denied range; an allow rule just does not match then.
+func (e *engine) matches(ctx context.Context, rule model.PolicyRule, method string, u *url.URL, host *destination) (bool, error) {
+	if len(rule.Methods) > 0 && !contains(rule.Methods, method) {
+		return false, nil
+	}
+
+	if len(rule.Ports) > 0 && !matchesPort(rule.Ports, u) {
+		return false, nil
+	}
+
+	if len(rule.PathPrefixes) > 0 && !matchesPath(rule.PathPrefixes, u.Path) {
+		return false, nil
+	}
+
+	if len(rule.Hosts) == 0 {
+		return true, nil
+	}
+
+	return e.matchesHost(ctx, rule, host)
+}
+
+// matchesHost matches the host against the globs of the rule, then against its CIDRs by the addresses the host
+// resolves to. A deny rule matches when any of the addresses is in a CIDR, an allow rule only when all of them are.
+func (e *engine) matchesHost(ctx context.Context, rule model.PolicyRule, host *destination) (bool, error) {
+	var prefixes []netip.Prefix
+
+	for _, pattern := range rule.Hosts {
+		if prefix, err := netip.ParsePrefix(pattern); err == nil {
+			prefixes = append(prefixes, prefix)
+
+			continue
+		}
+
+		if ok, _ := path.Match(strings.ToLower(pattern), host.name); ok {
+			return true, nil
+		}
+	}
+
+	if len(prefixes) == 0 {
+		return false, nil
+	}
+
+	if !host.resolved {
+		host.addrs, host.err = e.resolve(ctx, host.name)
+		host.resolved = true
+	}
+
+	if host.err != nil {
+		if rule.Action == model.PolicyDeny {
+			return false, host.err
+		}
+
+		return false, nil
+	}
+
+	for _, addr := range host.addrs {
+		inRange := containsAddr(prefixes, addr)
+
+		if inRange && rule.Action == model.PolicyDeny {
+			return true, nil
+		}
+
+		if !inRange && rule.Action != model.PolicyDeny {
+			return false, nil
+		}
+	}
+
+	return rule.Action != model.PolicyDeny, nil
+}
+
+// resolve gives the addresses of the host, the host itself for an IP literal
+func (e *engine) resolve(ctx context.Context, host string) ([]netip.Addr, error) {
+	if addr, err := netip.ParseAddr(host); err == nil {
+		return []netip.Addr{addr.Unmap()}, nil
+	}
+
+	addrs, err := e.lookup(ctx, host)
+	if err != nil {
+		return nil, errors.New("cannot resolve " + host)
+	}
+
+	if len(addrs) == 0 {
+		return nil, errors.New("no address for " + host)
+	}
+
+	for i := range addrs {
+		addrs[i] = addrs[i].Unmap()
+	}
+
+	return addrs, nil
+}
+
+func lookupHost(ctx context.Context, host string) ([]netip.Addr, error) {
+	return net.DefaultResolver.LookupNetIP(ctx, "ip", host)
+}
+
+func containsAddr(prefixes []netip.Prefix, addr netip.Addr) bool {
+	for _, prefix := range prefixes {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchesPort(ports []int, u *url.URL) bool {
+	port := u.Port()
+	if port == "" {
+		port = map[string]string{"http": "80", "https": "443"}[u.Scheme]
+	}
+
+	for _, p := range ports {
+		if strconv.Itoa(p) == port {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchesPath matches the path against the prefixes on segment boundaries, so that /admin matches /admin and
+// /admin/users but not /administrator
+func matchesPath(prefixes []string, urlPath string) bool {
+	// resolve the dot segments, so that /public/../admin is matched as /admin, keeping the trailing slash
+	cleaned := path.Clean("/" + urlPath)
+	if strings.HasSuffix(urlPath, "/") && cleaned != "/" {
+		cleaned += "/"
+	}
+
+	for _, prefix := range prefixes {
+		if cleaned == prefix || strings.HasPrefix(cleaned, strings.TrimSuffix(prefix, "/")+"/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,188 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testPolicy = `{
+  "defaultAction": "deny",
+  "rules": [
+    {"name": "partners", "action": "allow", "priority": 10, "hosts": ["*.partner.com"], "ports": [443]},
+    {"name": "no-admin", "action": "deny", "priority": 20, "pathPrefixes": ["/admin"]},
+    {"name": "no-partner-deletes", "action": "deny", "priority": 20, "hosts": ["*.partner.com"], "methods": ["delete"]},
+    {"name": "office", "action": "allow", "priority": 5, "hosts": ["203.0.113.0/24"]},
+    {"name": "blocked-range", "action": "deny", "priority": 30, "hosts": ["198.51.100.0/24"]},
+    {"name": "no-internal", "action": "deny", "priority": 20, "pathPrefixes": ["/internal/"]}
+  ]
+}`
+
+// testHosts are the addresses of the host names resolved by the tests, any other host cannot be resolved
+var testHosts = map[string][]netip.Addr{
+	"api.partner.com":    {netip.MustParseAddr("192.0.2.10")},
+	"office.example.com": {netip.MustParseAddr("203.0.113.9")},
+	"mixed.example.com":  {netip.MustParseAddr("203.0.113.10"), netip.MustParseAddr("192.0.2.20")},
+	"evil.example.com":   {netip.MustParseAddr("192.0.2.30"), netip.MustParseAddr("198.51.100.5")},
+}
+
+func lookupTestHost(_ context.Context, host string) ([]netip.Addr, error) {
+	addrs, ok := testHosts[host]
+	if !ok {
+		return nil, errors.New("no such host")
+	}
+
+	return append([]netip.Addr(nil), addrs...), nil
+}
+
+func writePolicy(t *testing.T, content string) string {
+	file := filepath.Join(t.TempDir(), "policy.json")
+
+	assert.Nil(t, os.WriteFile(file, []byte(content), 0o600))
+
+	return file
+}
+
+func TestEngine_Evaluate(t *testing.T) {
+	e, err := New(writePolicy(t, testPolicy), 0)
+	assert.Nil(t, err)
+
+	e.(*engine).lookup = lookupTestHost
+
+	tcs := []struct {
+		description string
+		method      string
+		url         string
+		expErr      error
+	}{
+		{
+			description: "Positive case: allowed by host and port",
+			method:      "GET",
+			url:         "https://api.partner.com/orders",
+		},
+		{
+			description: "Positive case: allowed by CIDR",
+			method:      "POST",
+			url:         "http://203.0.113.7:8080/hook",
+		},
+		{
+			description: "Positive case: host name allowed by the CIDR it resolves to",
+			method:      "POST",
+			url:         "http://office.example.com:8080/hook",
+		},
+		{
+			description: "Positive case: the path prefix ends on a segment boundary",
+			method:      "GET",
+			url:         "https://api.partner.com/administrator",
+		},
+		{
+			description: "Negative case: host name resolving into a denied CIDR",
+			method:      "GET",
+			url:         "https://evil.example.com/orders",
+			expErr:      errors.New("Invalid request: destination denied by policy rule blocked-range"),
+		},
+		{
+			description: "Negative case: host name resolving partly outside of an allowed CIDR, denied by default",
+			method:      "GET",
+			url:         "http://mixed.example.com/hook",
+			expErr:      errors.New("Invalid request: destination denied by policy default action"),
+		},
+		{
+			description: "Negative case: host name that cannot be resolved is denied by a CIDR deny rule",
+			method:      "GET",
+			url:         "https://unknown.example.com/orders",
+			expErr:      errors.New("Invalid request: destination denied by policy rule blocked-range: cannot resolve unknown.example.com"),
+		},
+		{
+			description: "Negative case: denied by a path prefix with a trailing slash",
+			method:      "GET",
+			url:         "https://api.partner.com/internal/",
+			expErr:      errors.New("Invalid request: destination denied by policy rule no-internal"),
+		},
+		{
+			description: "Negative case: denied by a higher priority rule",
+			method:      "GET",
+			url:         "https://api.partner.com/admin/users",
+			expErr:      errors.New("Invalid request: destination denied by policy rule no-admin"),
+		},
+		{
+			description: "Negative case: dot segments do not bypass the path prefix",
+			method:      "GET",
+			url:         "https://api.partner.com/public/../admin",
+			expErr:      errors.New("Invalid request: destination denied by policy rule no-admin"),
+		},
+		{
+			description: "Negative case: denied by method",
+			method:      "delete",
+			url:         "https://api.partner.com/orders/1",
+			expErr:      errors.New("Invalid request: destination denied by policy rule no-partner-deletes"),
+		},
+		{
+			description: "Negative case: port does not match, denied by default",
+			method:      "GET",
+			url:         "http://api.partner.com/orders",
+			expErr:      errors.New("Invalid request: destination denied by policy default action"),
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			err := e.Evaluate(context.Background(), tc.method, tc.url)
+
+			if tc.expErr == nil {
+				assert.Nil(t, err)
+
+				return
+			}
+
+			assert.Equal(t, tc.expErr.Error(), err.Error())
+			assert.True(t, errors.Is(err, ErrDenied))
+		})
+	}
+}
+
+func TestEngine_New(t *testing.T) {
+	e, err := New("", 0)
+	assert.Nil(t, err)
+	assert.Nil(t, e.Evaluate(context.Background(), "DELETE", "https://anything.com/admin"))
+
+	_, err = New(writePolicy(t, `{"rules": [{"name": "x", "action": "block"}]}`), 0)
+	assert.Equal(t, errors.New("Invalid policy: action of rule x should be one of [allow, deny]"), err)
+
+	_, err = New(writePolicy(t, `{"defaultAction": "maybe"}`), 0)
+	assert.Equal(t, errors.New("Invalid policy: defaultAction should be one of [allow, deny]"), err)
+}
+
+func TestEngine_Watch(t *testing.T) {
+	file := writePolicy(t, `{"defaultAction": "allow"}`)
+
+	e, err := New(file, 10*time.Millisecond)
+	assert.Nil(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go e.Watch(ctx)
+
+	assert.Nil(t, os.WriteFile(file, []byte(`{"defaultAction": "deny"}`), 0o600))
+	assert.Nil(t, os.Chtimes(file, time.Now(), time.Now().Add(time.Minute)))
+
+	assert.Eventually(t, func() bool {
+		return e.Evaluate(context.Background(), "GET", "https://api.partner.com") != nil
+	}, time.Second, 10*time.Millisecond)
+
+	// an invalid file keeps the current policy
+	assert.Nil(t, os.WriteFile(file, []byte(`{`), 0o600))
+	assert.Nil(t, os.Chtimes(file, time.Now(), time.Now().Add(2*time.Minute)))
+
+	time.Sleep(50 * time.Millisecond)
+	assert.NotNil(t, e.Evaluate(context.Background(), "GET", "https://api.partner.com"))
+}
@@ -0,0 +1,10 @@
+package policy
+
+import "context"
+
+// Engine decides whether a task may call a destination
+type Engine interface {
+	Evaluate(ctx context.Context, method string, rawURL string) error
+	Reload() error
+	Watch(ctx context.Context)
+}
@@ -0,0 +1,26 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/axxonsoft-assignment/pkg/service"
+)
+
+// Run checks for due schedules on every tick of the interval and fires them, until the context is cancelled.
+func Run(ctx context.Context, schedules service.Schedules, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if err := schedules.SchedulesRunDue(ctx, now); err != nil {
+				log.Printf("Error running due schedules: %v", err)
+			}
+		}
+	}
+}
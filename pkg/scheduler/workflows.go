@@ -0,0 +1,28 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/axxonsoft-assignment/pkg/service"
+)
+
+// ResumeWorkflows resumes the workflows nobody runs right away and on every tick of the interval, until the context
+// is cancelled.
+func ResumeWorkflows(ctx context.Context, workflows service.Workflows, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := workflows.WorkflowsResume(ctx); err != nil {
+			log.Printf("Error resuming workflows: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
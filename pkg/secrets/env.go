@@ -0,0 +1,64 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+)
+
+// ErrNotFound is returned for a secret none of the providers holds
+var ErrNotFound = errors.New("secret not found")
+
+type env struct {
+	prefix string
+}
+
+// NewEnv builds a provider reading the secrets from environment variables, the secret partner.token being
+// read from the variable <prefix>PARTNER_TOKEN.
+func NewEnv(prefix string) Provider {
+	return &env{prefix: prefix}
+}
+
+func (e env) Lookup(_ context.Context, name string) (string, error) {
+	value, ok := os.LookupEnv(e.prefix + envName(name))
+	if !ok {
+		return "", ErrNotFound
+	}
+
+	return value, nil
+}
+
+// envName upper cases the secret name and replaces the characters not allowed in a variable name with '_'
+func envName(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		default:
+			return '_'
+		}
+	}, name)
+}
+
+type chain []Provider
+
+// NewChain builds a provider looking up a secret in the given providers in order, the first one holding it wins
+func NewChain(providers ...Provider) Provider {
+	return chain(providers)
+}
+
+func (c chain) Lookup(ctx context.Context, name string) (string, error) {
+	for _, provider := range c {
+		value, err := provider.Lookup(ctx, name)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+
+		return value, err
+	}
+
+	return "", ErrNotFound
+}
@@ -0,0 +1,89 @@
+package secrets
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"os"
+)
+
+type file struct {
+	secrets map[string]string
+}
+
+// NewFile builds a provider holding the secrets of an encrypted file, as written by Seal with the same key.
+// The key is the base64 encoding of 32 random bytes, the file is decrypted once at startup.
+func NewFile(path string, key string) (Provider, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) < aead.NonceSize() {
+		return nil, errors.New("Invalid secrets file: too short")
+	}
+
+	plaintext, err := aead.Open(nil, data[:aead.NonceSize()], data[aead.NonceSize():], nil)
+	if err != nil {
+		return nil, errors.New("Invalid secrets file: cannot be decrypted with the key")
+	}
+
+	f := &file{}
+	if err = json.Unmarshal(plaintext, &f.secrets); err != nil {
+		return nil, errors.New("Invalid secrets file: " + err.Error())
+	}
+
+	return f, nil
+}
+
+func (f file) Lookup(_ context.Context, name string) (string, error) {
+	value, ok := f.secrets[name]
+	if !ok {
+		return "", ErrNotFound
+	}
+
+	return value, nil
+}
+
+// Seal encrypts the secrets of name -> value with AES-256-GCM, giving the content of a file read by NewFile
+func Seal(secrets map[string]string, key string) ([]byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, err := json.Marshal(secrets)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err = rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	return aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+func newAEAD(key string) (cipher.AEAD, error) {
+	rawKey, err := base64.StdEncoding.DecodeString(key)
+	if err != nil || len(rawKey) != 32 {
+		return nil, errors.New("Invalid secrets key: should be the base64 encoding of 32 bytes")
+	}
+
+	block, err := aes.NewCipher(rawKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,40 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testKey = "MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY="
+
+func TestSecrets_File(t *testing.T) {
+	sealed, err := Seal(map[string]string{"partner.token": "abc"}, testKey)
+	assert.Nil(t, err)
+	assert.NotContains(t, string(sealed), "abc")
+
+	path := filepath.Join(t.TempDir(), "secrets.enc")
+	assert.Nil(t, os.WriteFile(path, sealed, 0o600))
+
+	provider, err := NewFile(path, testKey)
+	assert.Nil(t, err)
+
+	value, err := provider.Lookup(context.TODO(), "partner.token")
+	assert.Nil(t, err)
+	assert.Equal(t, "abc", value)
+
+	_, err = provider.Lookup(context.TODO(), "other")
+	assert.Equal(t, ErrNotFound, err)
+
+	// wrong key
+	_, err = NewFile(path, "YWJjZGVmZ2hpamtsbW5vcHFyc3R1dnd4eXowMTIzNDU=")
+	assert.Equal(t, errors.New("Invalid secrets file: cannot be decrypted with the key"), err)
+
+	// malformed key
+	_, err = NewFile(path, "abc")
+	assert.Equal(t, errors.New("Invalid secrets key: should be the base64 encoding of 32 bytes"), err)
+}
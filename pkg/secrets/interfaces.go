@@ -0,0 +1,8 @@
+package secrets
+
+import "context"
+
+// Provider looks up the value of a named secret, returning ErrNotFound if it has none
+type Provider interface {
+	Lookup(ctx context.Context, name string) (string, error)
+}
@@ -0,0 +1,131 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// refPattern matches a secret reference like ${secret:partner.token}
+var refPattern = regexp.MustCompile(`\$\{secret:([A-Za-z0-9_.\-/]+)\}`)
+
+// Resolver replaces the secret references of a task with their values, remembering the values it has
+// resolved so that they can be redacted from anything stored or logged afterwards.
+type Resolver struct {
+	provider Provider
+	values   map[string]string
+}
+
+// NewResolver builds a resolver of the secrets held by the provider
+func NewResolver(provider Provider) *Resolver {
+	return &Resolver{provider: provider, values: map[string]string{}}
+}
+
+// References gives the names of the secrets referenced in the string
+func References(s string) []string {
+	var names []string
+
+	for _, match := range refPattern.FindAllStringSubmatch(s, -1) {
+		names = append(names, match[1])
+	}
+
+	return names
+}
+
+// ReplaceReferences replaces every secret reference of the string with what replace gives for its name
+func ReplaceReferences(s string, replace func(name string) string) string {
+	return refPattern.ReplaceAllStringFunc(s, func(ref string) string {
+		return replace(refPattern.FindStringSubmatch(ref)[1])
+	})
+}
+
+// Resolve replaces every secret reference of the string with the value of the secret
+func (r *Resolver) Resolve(ctx context.Context, s string) (string, error) {
+	return r.resolve(ctx, s, func(value string) string { return value })
+}
+
+// ResolveUserinfo replaces every secret reference of the url with the value of the secret escaped as the userinfo
+// of a url, so that a value with e.g. a colon or an at sign leaves the url valid
+func (r *Resolver) ResolveUserinfo(ctx context.Context, s string) (string, error) {
+	return r.resolve(ctx, s, func(value string) string { return url.User(value).String() })
+}
+
+func (r *Resolver) resolve(ctx context.Context, s string, escape func(string) string) (string, error) {
+	if !strings.Contains(s, "${secret:") {
+		return s, nil
+	}
+
+	var resolveErr error
+
+	resolved := refPattern.ReplaceAllStringFunc(s, func(ref string) string {
+		name := refPattern.FindStringSubmatch(ref)[1]
+
+		value, err := r.provider.Lookup(ctx, name)
+		if err != nil {
+			if errors.Is(err, ErrNotFound) {
+				err = errors.New("unknown secret " + name)
+			}
+
+			if resolveErr == nil {
+				resolveErr = err
+			}
+
+			return ref
+		}
+
+		r.values[name] = value
+
+		return escape(value)
+	})
+	if resolveErr != nil {
+		return "", resolveErr
+	}
+
+	return resolved, nil
+}
+
+// Redact replaces the values resolved so far with their secret references, also in their url encoded forms
+func (r *Resolver) Redact(s string) string {
+	for name, value := range r.values {
+		if value == "" {
+			continue
+		}
+
+		ref := "${secret:" + name + "}"
+
+		for _, form := range []string{value, url.QueryEscape(value), url.PathEscape(value), url.User(value).String()} {
+			s = strings.ReplaceAll(s, form, ref)
+		}
+	}
+
+	return s
+}
+
+// RedactError redacts the message of the error, e.g. a *url.Error quoting the resolved url, keeping it unwrappable
+func (r *Resolver) RedactError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	msg := r.Redact(err.Error())
+	if msg == err.Error() {
+		return err
+	}
+
+	return &redactedError{err: err, msg: msg}
+}
+
+type redactedError struct {
+	err error
+	msg string
+}
+
+func (e *redactedError) Error() string {
+	return e.msg
+}
+
+func (e *redactedError) Unwrap() error {
+	return e.err
+}
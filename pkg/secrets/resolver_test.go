@@ -0,0 +1,88 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSecrets_Resolver(t *testing.T) {
+	t.Setenv("TEST_SECRET_PARTNER_TOKEN", "from-env")
+	t.Setenv("TEST_SECRET_API_KEY", "a b/c")
+
+	path := t.TempDir() + "/secrets.enc"
+	sealed, _ := Seal(map[string]string{"partner.token": "from-file"}, testKey)
+	assert.Nil(t, os.WriteFile(path, sealed, 0o600))
+
+	fileProvider, err := NewFile(path, testKey)
+	assert.Nil(t, err)
+
+	tcs := []struct {
+		description string
+		provider    Provider
+		input       string
+		expOutput   string
+		expErr      error
+	}{
+		{
+			description: "Positive case: no references",
+			provider:    NewChain(),
+			input:       "https://api.partner.com/items",
+			expOutput:   "https://api.partner.com/items",
+		},
+		{
+			description: "Positive case: env variable",
+			provider:    NewEnv("TEST_SECRET_"),
+			input:       "Bearer ${secret:partner.token}",
+			expOutput:   "Bearer from-env",
+		},
+		{
+			description: "Positive case: the file wins over the env variables",
+			provider:    NewChain(fileProvider, NewEnv("TEST_SECRET_")),
+			input:       "${secret:partner.token}:${secret:api-key}",
+			expOutput:   "from-file:a b/c",
+		},
+		{
+			description: "Negative case: unknown secret",
+			provider:    NewChain(fileProvider, NewEnv("TEST_SECRET_")),
+			input:       "${secret:partner.token}:${secret:other}",
+			expErr:      errors.New("unknown secret other"),
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			output, err := NewResolver(tc.provider).Resolve(context.TODO(), tc.input)
+
+			assert.Equal(t, tc.expErr, err)
+			assert.Equal(t, tc.expOutput, output)
+		})
+	}
+}
+
+func TestSecrets_Redact(t *testing.T) {
+	t.Setenv("TEST_SECRET_API_KEY", "a b/c")
+
+	resolver := NewResolver(NewEnv("TEST_SECRET_"))
+
+	_, err := resolver.Resolve(context.TODO(), "${secret:api_key}")
+	assert.Nil(t, err)
+
+	assert.Equal(t, "key=${secret:api_key}", resolver.Redact("key="+url.QueryEscape("a b/c")))
+	assert.Equal(t, "/${secret:api_key}", resolver.Redact("/"+url.PathEscape("a b/c")))
+
+	urlErr := &url.Error{Op: "Get", URL: "https://api.partner.com/?key=" + url.QueryEscape("a b/c"), Err: errors.New("refused")}
+	redacted := resolver.RedactError(urlErr)
+
+	assert.Equal(t, `Get "https://api.partner.com/?key=${secret:api_key}": refused`, redacted.Error())
+	assert.True(t, errors.Is(redacted, urlErr))
+	assert.Equal(t, fmt.Errorf("other"), resolver.RedactError(fmt.Errorf("other")))
+	assert.Nil(t, resolver.RedactError(nil))
+}
@@ -0,0 +1,195 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/axxonsoft-assignment/pkg/model"
+	"github.com/axxonsoft-assignment/pkg/secrets"
+)
+
+// oauthTokenSkew renews the access token a little before it expires, so that it does not expire in flight
+const oauthTokenSkew = 30 * time.Second
+
+// oauthTokenResponse is the response of an OAuth2 token endpoint as per RFC 6749
+type oauthTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int    `json:"expires_in"`
+}
+
+// applyAuth sets the credentials of the task's auth scheme on the request. The access token of oauth2 is fetched
+// through the task's TLS profile and proxy, as the call itself, the resolver redacting the secrets of what is logged.
+func (t tasks) applyAuth(ctx context.Context, request *http.Request, taskDetails model.Task, resolver *secrets.Resolver) error {
+	auth := taskDetails.Auth
+	if auth == nil {
+		return nil
+	}
+
+	switch auth.Type {
+	case model.AuthBasic:
+		request.SetBasicAuth(auth.Username, auth.Password)
+	case model.AuthBearer:
+		request.Header.Set("Authorization", "Bearer "+auth.Token)
+	case model.AuthAPIKey:
+		if auth.In == model.APIKeyInQuery {
+			query := request.URL.Query()
+			query.Set(auth.Name, auth.Value)
+			request.URL.RawQuery = query.Encode()
+		} else {
+			request.Header.Set(auth.Name, auth.Value)
+		}
+	case model.AuthOAuth2:
+		client, _ := t.clientFor(taskDetails.TLSProfile)
+
+		token, err := t.oauthToken(withTaskProxy(ctx, taskDetails), auth, client, resolver)
+		if err != nil {
+			return err
+		}
+
+		request.Header.Set("Authorization", token.TokenType+" "+token.AccessToken)
+	}
+
+	return nil
+}
+
+// oauthToken gives the access token of the client credentials, from the cache if it is still valid,
+// otherwise a new one is fetched from the token endpoint with the client and cached till it expires.
+func (t tasks) oauthToken(ctx context.Context, auth *model.Auth, client http.Client,
+	resolver *secrets.Resolver) (*model.OAuthToken, error) {
+	key := oauthTokenKey(auth)
+
+	token, err := t.cache.GetOAuthToken(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	if token.AccessToken != "" && time.Now().Add(oauthTokenSkew).Before(token.ExpiresAt) {
+		return token, nil
+	}
+
+	token, err = t.fetchOAuthToken(ctx, auth, client, resolver)
+	if err != nil {
+		return nil, err
+	}
+
+	// the token is still usable by this task, the next ones fetch a new token
+	_ = t.cache.StoreOAuthToken(ctx, key, token)
+
+	return token, nil
+}
+
+// fetchOAuthToken makes the client credentials grant call to the token endpoint, through the proxy of the context.
+func (t tasks) fetchOAuthToken(ctx context.Context, auth *model.Auth, client http.Client,
+	resolver *secrets.Resolver) (*model.OAuthToken, error) {
+	// the token endpoint is subject to the same destination policy as the task itself
+	if err := t.settings.Policy.Evaluate(ctx, http.MethodPost, auth.TokenURL); err != nil {
+		return nil, err
+	}
+
+	form := url.Values{"grant_type": {"client_credentials"}}
+	if len(auth.Scopes) > 0 {
+		form.Set("scope", strings.Join(auth.Scopes, " "))
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, auth.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+
+	request.Header.Set(model.ContentType, "application/x-www-form-urlencoded")
+	request.Header.Set("Accept", "application/json")
+	request.SetBasicAuth(url.QueryEscape(auth.ClientID), url.QueryEscape(auth.ClientSecret))
+
+	// through a proxy the dialer never sees the token endpoint's address, so it is resolved and checked upfront
+	if proxyURL, _ := t.settings.proxyFor(request); proxyURL != nil {
+		if err = t.settings.Guard.CheckHost(ctx, request.URL.Hostname()); err != nil {
+			return nil, err
+		}
+	}
+
+	// every redirect of the token endpoint is checked as the first call
+	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if err := t.settings.Guard.CheckRedirect(req, via); err != nil {
+			return err
+		}
+
+		if err := t.settings.Policy.Evaluate(req.Context(), req.Method, req.URL.String()); err != nil {
+			return err
+		}
+
+		if proxyURL, _ := t.settings.proxyFor(req); proxyURL != nil {
+			return t.settings.Guard.CheckHost(req.Context(), req.URL.Hostname())
+		}
+
+		return nil
+	}
+
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	// the body of a rejection may echo the credentials, so only the status is reported
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("token endpoint responded with status %d", response.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(response.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+
+	tokenResp := oauthTokenResponse{}
+	if err = json.Unmarshal(body, &tokenResp); err != nil {
+		return nil, errors.New("token endpoint responded with an invalid body")
+	}
+
+	if tokenResp.AccessToken == "" {
+		return nil, errors.New("token endpoint responded without an access_token")
+	}
+
+	// the token type is case-insensitive, some servers respond with "bearer"
+	tokenType := "Bearer"
+	if tokenResp.TokenType != "" && !strings.EqualFold(tokenResp.TokenType, "bearer") {
+		tokenType = tokenResp.TokenType
+	}
+
+	// a token without expiry is renewed every hour
+	expiresIn := time.Duration(tokenResp.ExpiresIn) * time.Second
+	if expiresIn <= 0 {
+		expiresIn = time.Hour
+	}
+
+	log.Printf("Fetched oauth token from %s for client %s", resolver.Redact(request.URL.Host), resolver.Redact(auth.ClientID))
+
+	return &model.OAuthToken{AccessToken: tokenResp.AccessToken, TokenType: tokenType, ExpiresAt: time.Now().Add(expiresIn)}, nil
+}
+
+// invalidateAuth drops the cached access token once the target has rejected it, the next task fetches a new one.
+func (t tasks) invalidateAuth(ctx context.Context, auth *model.Auth, statusCode int) {
+	if auth == nil || auth.Type != model.AuthOAuth2 || statusCode != http.StatusUnauthorized {
+		return
+	}
+
+	_ = t.cache.DeleteOAuthToken(ctx, oauthTokenKey(auth))
+}
+
+// oauthTokenKey identifies the cached token of the client credentials, the secret is hashed so that it is not stored.
+func oauthTokenKey(auth *model.Auth) string {
+	sum := sha256.Sum256([]byte(strings.Join([]string{auth.TokenURL, auth.ClientID, auth.ClientSecret,
+		strings.Join(auth.Scopes, " ")}, "\n")))
+
+	return hex.EncodeToString(sum[:])
+}
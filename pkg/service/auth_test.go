@@ -0,0 +1,234 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/axxonsoft-assignment/pkg/breaker"
+	"github.com/axxonsoft-assignment/pkg/cache"
+	"github.com/axxonsoft-assignment/pkg/model"
+	"github.com/axxonsoft-assignment/pkg/secrets"
+	"github.com/axxonsoft-assignment/pkg/ssrf"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTasks_applyAuth(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tokenCalls := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		clientID, clientSecret, _ := r.BasicAuth()
+		if clientID != "client" || clientSecret != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+
+		tokenCalls++
+
+		assert.Equal(t, "client_credentials", r.FormValue("grant_type"))
+		assert.Equal(t, "read write", r.FormValue("scope"))
+
+		w.Header().Set(model.ContentType, "application/json")
+		_, _ = w.Write([]byte(`{"access_token": "fetched", "token_type": "bearer", "expires_in": 3600}`))
+	}))
+	defer server.Close()
+
+	// a forward proxy answering the token requests itself, the token endpoint behind it cannot be dialled directly
+	proxyCalls := 0
+
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxyCalls++
+
+		assert.Equal(t, "http://127.0.0.1:1/token", r.URL.String())
+
+		w.Header().Set(model.ContentType, "application/json")
+		_, _ = w.Write([]byte(`{"access_token": "proxied", "expires_in": 3600}`))
+	}))
+	defer proxy.Close()
+
+	// a token endpoint only trusted through the TLS profile
+	tlsServer := httptest.NewTLSServer(server.Config.Handler)
+	defer tlsServer.Close()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(tlsServer.Certificate())
+
+	cacheMock := cache.NewMockCache(ctrl)
+	guard, _ := ssrf.New([]string{"127.0.0.0/8"})
+	task := New(cacheMock, breaker.New(breaker.Settings{}), Settings{Guard: guard,
+		TLSProfiles: map[string]*tls.Config{"partner": {RootCAs: roots}}}).(*tasks)
+
+	oauth := &model.Auth{Type: model.AuthOAuth2, TokenURL: server.URL + "/token", ClientID: "client", ClientSecret: "secret",
+		Scopes: []string{"read", "write"}}
+
+	tcs := []struct {
+		description   string
+		auth          *model.Auth
+		tlsProfile    string
+		proxy         string
+		mockCalls     []*gomock.Call
+		expHeader     string
+		expQuery      string
+		expTokenCalls int
+		expProxyCalls int
+		expErr        error
+	}{
+		{
+			description: "Positive case: no auth",
+		},
+		{
+			description: "Positive case: basic",
+			auth:        &model.Auth{Type: model.AuthBasic, Username: "user", Password: "pass"},
+			expHeader:   "Basic dXNlcjpwYXNz",
+		},
+		{
+			description: "Positive case: bearer",
+			auth:        &model.Auth{Type: model.AuthBearer, Token: "abc"},
+			expHeader:   "Bearer abc",
+		},
+		{
+			description: "Positive case: api key in query",
+			auth:        &model.Auth{Type: model.AuthAPIKey, Name: "api_key", Value: "abc", In: model.APIKeyInQuery},
+			expQuery:    "api_key=abc&page=2",
+		},
+		{
+			description: "Positive case: oauth2 token from the cache",
+			auth:        oauth,
+			mockCalls: []*gomock.Call{
+				cacheMock.EXPECT().GetOAuthToken(gomock.Any(), oauthTokenKey(oauth)).
+					Return(&model.OAuthToken{AccessToken: "cached", TokenType: "Bearer", ExpiresAt: time.Now().Add(time.Hour)}, nil),
+			},
+			expHeader: "Bearer cached",
+		},
+		{
+			description: "Positive case: oauth2 token about to expire is renewed",
+			auth:        oauth,
+			mockCalls: []*gomock.Call{
+				cacheMock.EXPECT().GetOAuthToken(gomock.Any(), oauthTokenKey(oauth)).
+					Return(&model.OAuthToken{AccessToken: "cached", TokenType: "Bearer", ExpiresAt: time.Now().Add(time.Second)}, nil),
+				cacheMock.EXPECT().StoreOAuthToken(gomock.Any(), oauthTokenKey(oauth), gomock.Any()).Return(nil),
+			},
+			expHeader:     "Bearer fetched",
+			expTokenCalls: 1,
+		},
+		{
+			description: "Positive case: oauth2 token fetched through the task's TLS profile",
+			auth: &model.Auth{Type: model.AuthOAuth2, TokenURL: tlsServer.URL + "/token", ClientID: "client", ClientSecret: "secret",
+				Scopes: []string{"read", "write"}},
+			tlsProfile: "partner",
+			mockCalls: []*gomock.Call{
+				cacheMock.EXPECT().GetOAuthToken(gomock.Any(), gomock.Any()).Return(&model.OAuthToken{}, nil),
+				cacheMock.EXPECT().StoreOAuthToken(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil),
+			},
+			expHeader:     "Bearer fetched",
+			expTokenCalls: 1,
+		},
+		{
+			description: "Positive case: oauth2 token fetched through the task's proxy",
+			auth: &model.Auth{Type: model.AuthOAuth2, TokenURL: "http://127.0.0.1:1/token", ClientID: "client",
+				ClientSecret: "secret"},
+			proxy: proxy.URL,
+			mockCalls: []*gomock.Call{
+				cacheMock.EXPECT().GetOAuthToken(gomock.Any(), gomock.Any()).Return(&model.OAuthToken{}, nil),
+				cacheMock.EXPECT().StoreOAuthToken(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil),
+			},
+			expHeader:     "Bearer proxied",
+			expProxyCalls: 1,
+		},
+		{
+			description: "Negative case: token endpoint rejects the client",
+			auth:        &model.Auth{Type: model.AuthOAuth2, TokenURL: server.URL + "/token", ClientID: "client", ClientSecret: "wrong"},
+			mockCalls: []*gomock.Call{
+				cacheMock.EXPECT().GetOAuthToken(gomock.Any(), gomock.Any()).Return(&model.OAuthToken{}, nil),
+			},
+			expErr: errors.New("token endpoint responded with status 401"),
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			tokenCalls, proxyCalls = 0, 0
+
+			request, _ := http.NewRequest(http.MethodGet, "https://api.partner.com/items?page=2", nil)
+
+			err := task.applyAuth(context.TODO(), request, model.Task{Auth: tc.auth, TLSProfile: tc.tlsProfile, Proxy: tc.proxy},
+				secrets.NewResolver(nil))
+
+			assert.Equal(t, tc.expErr, err)
+			assert.Equal(t, tc.expHeader, request.Header.Get("Authorization"))
+			assert.Equal(t, tc.expTokenCalls, tokenCalls)
+			assert.Equal(t, tc.expProxyCalls, proxyCalls)
+
+			if tc.expQuery != "" {
+				assert.Equal(t, tc.expQuery, request.URL.RawQuery)
+			}
+		})
+	}
+}
+
+func TestTasks_applyAuth_redactsLog(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Setenv("TEST_SECRET_CLIENT", "client-7f3a")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"access_token": "abc", "token_type": "Bearer"}`))
+	}))
+	defer server.Close()
+
+	cacheMock := cache.NewMockCache(ctrl)
+	cacheMock.EXPECT().GetOAuthToken(gomock.Any(), gomock.Any()).Return(&model.OAuthToken{}, nil)
+	cacheMock.EXPECT().StoreOAuthToken(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+	guard, _ := ssrf.New([]string{"127.0.0.0/8"})
+	task := New(cacheMock, breaker.New(breaker.Settings{}), Settings{Guard: guard, Secrets: secrets.NewEnv("TEST_SECRET_")}).(*tasks)
+
+	taskDetails, resolver, err := task.resolveSecrets(context.TODO(), model.Task{Auth: &model.Auth{Type: model.AuthOAuth2,
+		TokenURL: server.URL + "/token", ClientID: "${secret:client}", ClientSecret: "secret"}})
+	assert.Nil(t, err)
+
+	var logged bytes.Buffer
+
+	log.SetOutput(&logged)
+	defer log.SetOutput(os.Stderr)
+
+	request, _ := http.NewRequest(http.MethodGet, "https://api.partner.com/items", nil)
+	assert.Nil(t, task.applyAuth(context.TODO(), request, taskDetails, resolver))
+
+	assert.Contains(t, logged.String(), "for client ${secret:client}")
+	assert.NotContains(t, logged.String(), "client-7f3a")
+}
+
+func TestTasks_invalidateAuth(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cacheMock := cache.NewMockCache(ctrl)
+	task := New(cacheMock, breaker.New(breaker.Settings{}), Settings{}).(*tasks)
+
+	oauth := &model.Auth{Type: model.AuthOAuth2, TokenURL: "https://auth.partner.com/token", ClientID: "client", ClientSecret: "secret"}
+
+	// only a rejected oauth2 token is dropped
+	cacheMock.EXPECT().DeleteOAuthToken(gomock.Any(), oauthTokenKey(oauth)).Return(nil).Times(1)
+
+	task.invalidateAuth(context.TODO(), oauth, http.StatusUnauthorized)
+	task.invalidateAuth(context.TODO(), oauth, http.StatusOK)
+	task.invalidateAuth(context.TODO(), &model.Auth{Type: model.AuthBearer, Token: "abc"}, http.StatusUnauthorized)
+	task.invalidateAuth(context.TODO(), nil, http.StatusUnauthorized)
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/axxonsoft-assignment/pkg/blob"
+	"github.com/axxonsoft-assignment/pkg/model"
+)
+
+// defaultBlobThreshold is the size above which the response bodies are streamed to the blob store
+const defaultBlobThreshold = 1 << 20
+
+// readBody reads the response body, unless it is larger than the blob threshold in which case it is streamed to the
+// blob store as it is read, the task then pointing to its blob. It gives the body, nil once streamed to the blob store.
+// A body the task keeps is read in memory all the same, and stored in the blob store once read if it is large.
+func (t tasks) readBody(ctx context.Context, taskObj *model.TasksObject, body io.Reader, contentType string, offload, keep bool) ([]byte, error) {
+	if t.settings.Blobs == nil || !offload {
+		return io.ReadAll(body)
+	}
+
+	if keep {
+		data, err := io.ReadAll(body)
+		if err != nil || int64(len(data)) <= t.settings.BlobThreshold {
+			return data, err
+		}
+
+		return data, t.storeBlob(ctx, taskObj, bytes.NewReader(data), contentType)
+	}
+
+	head, err := io.ReadAll(io.LimitReader(body, t.settings.BlobThreshold+1))
+	if err != nil || int64(len(head)) <= t.settings.BlobThreshold {
+		return head, err
+	}
+
+	return nil, t.storeBlob(ctx, taskObj, io.MultiReader(bytes.NewReader(head), body), contentType)
+}
+
+// keepsBody tells if the response body of the task is used once received, by its until condition or its outputs
+func keepsBody(taskDetails model.Task) bool {
+	if taskDetails.KeepBody || (taskDetails.Until != nil && taskDetails.Until.Path != "") {
+		return true
+	}
+
+	return extractsBody(taskDetails.Extract)
+}
+
+// extractsBody tells if any of the rules takes its value out of the response body
+func extractsBody(rules map[string]model.ExtractRule) bool {
+	for _, rule := range rules {
+		if rule.From == model.ExtractFromBody || rule.From == model.ExtractFromRegex {
+			return true
+		}
+	}
+
+	return false
+}
+
+// storeBlob streams the body to the blob of the task, replacing the blob of a previous attempt if any
+func (t tasks) storeBlob(ctx context.Context, taskObj *model.TasksObject, body io.Reader, contentType string) error {
+	key := "tasks/" + taskObj.ID
+
+	size, err := t.settings.Blobs.Put(ctx, key, body)
+	if err != nil {
+		return fmt.Errorf("cannot store the body: %w", err)
+	}
+
+	taskObj.Blob = &model.Blob{Key: key, Size: size, ContentType: contentType}
+
+	return nil
+}
+
+// TasksBody gives the response body of the task streamed to the blob store, as a seekable reader to be closed by
+// the caller.
+func (t tasks) TasksBody(ctx context.Context, taskID string) (*model.Blob, io.ReadSeekCloser, error) {
+	taskObj, err := t.cache.GetTask(ctx, taskID)
+	if err != nil {
+		return nil, nil, unavailableError(err)
+	}
+
+	if taskObj.ID == "" {
+		return nil, nil, errTaskNotFound
+	}
+
+	if taskObj.Blob == nil || t.settings.Blobs == nil {
+		return nil, nil, notFoundError("Task has no stored body")
+	}
+
+	return taskObj.Blob, blob.NewReader(ctx, t.settings.Blobs, taskObj.Blob.Key, taskObj.Blob.Size), nil
+}
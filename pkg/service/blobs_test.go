@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/axxonsoft-assignment/pkg/blob"
+	"github.com/axxonsoft-assignment/pkg/breaker"
+	"github.com/axxonsoft-assignment/pkg/cache"
+	"github.com/axxonsoft-assignment/pkg/model"
+	"github.com/axxonsoft-assignment/pkg/ssrf"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTasks_readBody(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path + "?" + r.URL.RawQuery {
+		case "/small?":
+			_, _ = w.Write([]byte(`[1,2]`))
+		case "/large?":
+			w.Header().Set(model.ContentType, "application/json")
+			_, _ = w.Write([]byte(`[1,2,3,4,5,6,7,8,9]`))
+		case "/object?":
+			w.Header().Set(model.ContentType, "application/json")
+			_, _ = w.Write([]byte(`{"id":"abc","items":[1,2,3]}`))
+		case "/pages?":
+			w.Header().Set("Link", `</pages?page=2>; rel="next"`)
+			_, _ = w.Write([]byte(`[1,2]`))
+		case "/pages?page=2":
+			_, _ = w.Write([]byte(`[3,4]`))
+		}
+	}))
+	defer server.Close()
+
+	cacheMock := cache.NewMockCache(ctrl)
+	cacheMock.EXPECT().GetRateLimit(gomock.Any(), gomock.Any()).Return(&model.RateLimit{}, nil).AnyTimes()
+	cacheMock.EXPECT().StoreTask(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	store, _ := blob.NewLocal(t.TempDir())
+	guard, _ := ssrf.New([]string{"127.0.0.0/8"})
+	task := New(cacheMock, breaker.New(breaker.Settings{}), Settings{Guard: guard, Blobs: store, BlobThreshold: 10})
+
+	tcs := []struct {
+		description string
+		task        model.Task
+		expBody     string
+		expBlob     string
+		expType     string
+		expOutputs  map[string]interface{}
+	}{
+		{
+			description: "Positive case: body under the threshold kept in memory",
+			task:        model.Task{Method: "GET", URL: server.URL + "/small"},
+			expBody:     `[1,2]`,
+		},
+		{
+			description: "Positive case: body over the threshold streamed to the blob store",
+			task:        model.Task{Method: "GET", URL: server.URL + "/large"},
+			expBlob:     `[1,2,3,4,5,6,7,8,9]`,
+			expType:     "application/json",
+		},
+		{
+			description: "Positive case: body over the threshold kept for the outputs and stored",
+			task: model.Task{Method: "GET", URL: server.URL + "/object",
+				Extract: map[string]model.ExtractRule{"id": {From: model.ExtractFromBody, Path: "$.id"}}},
+			expBody:    `{"id":"abc","items":[1,2,3]}`,
+			expBlob:    `{"id":"abc","items":[1,2,3]}`,
+			expType:    "application/json",
+			expOutputs: map[string]interface{}{"id": "abc"},
+		},
+		{
+			description: "Positive case: body over the threshold kept for the until condition and stored",
+			task: model.Task{Method: "GET", URL: server.URL + "/object",
+				Until: &model.Until{Path: "$.id", Equals: "abc", Interval: "1s", Deadline: "5s"}},
+			expBody: `{"id":"abc","items":[1,2,3]}`,
+			expBlob: `{"id":"abc","items":[1,2,3]}`,
+			expType: "application/json",
+		},
+		{
+			description: "Positive case: collected pages over the threshold stored once complete",
+			task:        model.Task{Method: "GET", URL: server.URL + "/pages", Paginate: &model.Paginate{From: model.PaginateFromLink}},
+			expBody:     `[[1,2],[3,4]]`,
+			expBlob:     `[[1,2],[3,4]]`,
+			expType:     "application/json",
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			taskObj, body, err := task.TasksRun(context.TODO(), tc.task)
+			assert.Nil(t, err)
+			assert.Equal(t, model.Done, taskObj.Status)
+			assert.Equal(t, tc.expOutputs, taskObj.Outputs)
+
+			if tc.expBody != "" {
+				assert.Equal(t, tc.expBody, string(body))
+			} else {
+				assert.Nil(t, body)
+			}
+
+			if tc.expBlob == "" {
+				assert.Nil(t, taskObj.Blob)
+
+				return
+			}
+
+			assert.Equal(t, &model.Blob{Key: "tasks/" + taskObj.ID, Size: int64(len(tc.expBlob)), ContentType: tc.expType},
+				taskObj.Blob)
+
+			stored, _ := store.Open(context.TODO(), taskObj.Blob.Key, 0)
+			defer stored.Close()
+
+			data, _ := io.ReadAll(stored)
+			assert.Equal(t, tc.expBlob, string(data))
+		})
+	}
+}
+
+func TestTasks_TasksBody(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cacheMock := cache.NewMockCache(ctrl)
+
+	store, _ := blob.NewLocal(t.TempDir())
+	_, _ = store.Put(context.TODO(), "tasks/task-1", strings.NewReader("0123456789"))
+
+	task := New(cacheMock, breaker.New(breaker.Settings{}), Settings{Blobs: store})
+
+	tcs := []struct {
+		description string
+		mockCalls   []*gomock.Call
+		expBody     string
+		expErr      error
+	}{
+		{
+			description: "Positive case: body of the blob",
+			mockCalls: []*gomock.Call{
+				cacheMock.EXPECT().GetTask(gomock.Any(), "task-1").
+					Return(&model.TasksObject{ID: "task-1", Blob: &model.Blob{Key: "tasks/task-1", Size: 10}}, nil),
+			},
+			expBody: "0123456789",
+		},
+		{
+			description: "Negative case: task without a blob",
+			mockCalls: []*gomock.Call{
+				cacheMock.EXPECT().GetTask(gomock.Any(), "task-1").Return(&model.TasksObject{ID: "task-1"}, nil),
+			},
+			expErr: notFoundError("Task has no stored body"),
+		},
+		{
+			description: "Negative case: error from cache",
+			mockCalls: []*gomock.Call{
+				cacheMock.EXPECT().GetTask(gomock.Any(), "task-1").Return(nil, errors.New("redis down")),
+			},
+			expErr: unavailableError(errors.New("redis down")),
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			_, content, err := task.TasksBody(context.TODO(), "task-1")
+			assert.Equal(t, tc.expErr, err)
+
+			if err != nil {
+				return
+			}
+
+			defer content.Close()
+
+			data, _ := io.ReadAll(content)
+			assert.Equal(t, tc.expBody, string(data))
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package service
+
+import (
+	"context"
+
+	"github.com/axxonsoft-assignment/pkg/breaker"
+	"github.com/axxonsoft-assignment/pkg/model"
+)
+
+type breakers struct {
+	breakers breaker.Breakers
+}
+
+func NewBreakers(b breaker.Breakers) Breakers {
+	return &breakers{breakers: b}
+}
+
+// BreakersList gives the circuit state of every host called by this instance.
+func (b breakers) BreakersList(ctx context.Context) []model.CircuitState {
+	return b.breakers.States()
+}
+
+// BreakersGet gives the circuit state of a host.
+func (b breakers) BreakersGet(ctx context.Context, host string) model.CircuitState {
+	return b.breakers.State(host)
+}
+
+// BreakersReset closes the circuit of a host, letting its tasks through right away.
+func (b breakers) BreakersReset(ctx context.Context, host string) model.CircuitState {
+	b.breakers.Reset(host)
+
+	return b.breakers.State(host)
+}
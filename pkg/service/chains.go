@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strconv"
+
+	"github.com/axxonsoft-assignment/pkg/cache"
+	"github.com/axxonsoft-assignment/pkg/model"
+	"github.com/google/uuid"
+)
+
+var errChainNotFound = notFoundError("Chain not found")
+
+type chains struct {
+	cache cache.Cache
+	tasks Tasks
+}
+
+func NewChains(cache cache.Cache, tasks Tasks) Chains {
+	return &chains{cache: cache, tasks: tasks}
+}
+
+// ChainsCreate validates the chain, stores it in the cache and runs its steps in the background.
+func (c chains) ChainsCreate(ctx context.Context, chain model.Chain) (*model.Chain, error) {
+	if err := model.ValidateChain(chain); err != nil {
+		return nil, validationError(err)
+	}
+
+	chain.ID = uuid.New().String()
+	chain.Status = model.New
+	chain.Error = nil
+
+	for i := range chain.Steps {
+		chain.Steps[i].TaskID = ""
+		chain.Steps[i].Result = nil
+	}
+
+	if err := c.cache.StoreChain(ctx, &chain); err != nil {
+		return nil, unavailableError(err)
+	}
+
+	// the running chain gets its own copy of the steps, as the returned chain is marshalled meanwhile
+	running := chain
+	running.Steps = append([]model.ChainStep(nil), chain.Steps...)
+
+	go c.run(ctx, &running)
+
+	return &chain, nil
+}
+
+// ChainsGet gives the chain details given a chainID, along with the task details of each step run so far.
+func (c chains) ChainsGet(ctx context.Context, chainID string) (*model.Chain, error) {
+	chain, err := c.cache.GetChain(ctx, chainID)
+	if err != nil {
+		return nil, unavailableError(err)
+	}
+
+	if chain.ID == "" {
+		return nil, errChainNotFound
+	}
+
+	for i := range chain.Steps {
+		if chain.Steps[i].TaskID == "" {
+			continue
+		}
+
+		// the task of a step may have expired before the chain
+		chain.Steps[i].Result, err = c.tasks.TasksGet(ctx, chain.Steps[i].TaskID)
+		if err != nil && KindOf(err) != KindNotFound {
+			return nil, err
+		}
+	}
+
+	return chain, nil
+}
+
+// run runs the steps one after the other, rendering each step's task with the values extracted so far.
+// The chain stops at the first step whose task does not end up done.
+func (c chains) run(ctx context.Context, chain *model.Chain) {
+	variables := map[string]interface{}{}
+
+	chain.Status = model.InProcess
+	c.store(ctx, chain)
+
+	for i := range chain.Steps {
+		step := &chain.Steps[i]
+
+		task, err := model.TaskTemplate{Task: step.Task}.Render(variables)
+		if err != nil {
+			c.fail(ctx, chain, i, model.ErrCodeInvalidRequest, err)
+
+			return
+		}
+
+		task.ChainID = chain.ID
+		task.KeepBody = extractsBody(step.Extract)
+
+		taskObj, body, err := c.tasks.TasksRun(ctx, task)
+		if err != nil {
+			c.fail(ctx, chain, i, model.ErrCodeInvalidRequest, err)
+
+			return
+		}
+
+		step.TaskID = taskObj.ID
+		c.store(ctx, chain)
+
+		if taskObj.Status != model.Done {
+			c.fail(ctx, chain, i, model.ErrCodeStepFailed, errors.New("task "+taskObj.ID+" ended with status "+taskObj.Status))
+
+			return
+		}
+
+		outputs, err := extractOutputs(step.Extract, taskObj, body)
+		if err != nil {
+			c.fail(ctx, chain, i, model.ErrCodeExtractFailed, err)
+
+			return
+		}
+
+		for name, value := range outputs {
+			variables[name] = value
+		}
+	}
+
+	chain.Status = model.Done
+	c.store(ctx, chain)
+}
+
+// fail updates the chain's status to "error" in the cache along with the step that failed and the reason.
+func (c chains) fail(ctx context.Context, chain *model.Chain, stepIndex int, code string, err error) {
+	step := "step " + strconv.Itoa(stepIndex)
+	if name := chain.Steps[stepIndex].Name; name != "" {
+		step += " (" + name + ")"
+	}
+
+	log.Printf("Chain:%s failed at %s: %v", chain.ID, step, err)
+
+	chain.Status = model.Error
+	chain.Error = &model.TaskError{Code: code, Message: step + ": " + err.Error()}
+	c.store(ctx, chain)
+}
+
+func (c chains) store(ctx context.Context, chain *model.Chain) {
+	// the cache layer logs the failure to store the chain, the steps go on regardless
+	_ = c.cache.StoreChain(ctx, chain)
+}
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/axxonsoft-assignment/pkg/cache"
+	"github.com/axxonsoft-assignment/pkg/model"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChains_ChainsCreate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	chains := NewChains(cache.NewMockCache(ctrl), NewMockTasks(ctrl))
+
+	_, err := chains.ChainsCreate(context.TODO(), model.Chain{})
+	assert.Equal(t, invalidRequest("/steps", model.RuleRequired, "Invalid request: steps cannot be empty"), err)
+
+	_, err = chains.ChainsCreate(context.TODO(), model.Chain{Steps: []model.ChainStep{
+		{Task: model.Task{Method: "GET", URL: "https://www.getyourtasks.com/login"}, Extract: map[string]model.ExtractRule{
+			"token": {From: "cookie"},
+		}},
+	}})
+	assert.Equal(t, invalidRequest("/steps/0/extract/token/from", model.RuleEnum, "Invalid step 0: Invalid request: extract token: from should be one of [body, header, regex, status]"), err)
+}
+
+func TestChains_run(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cacheMock := cache.NewMockCache(ctrl)
+	tasksMock := NewMockTasks(ctrl)
+
+	c := &chains{cache: cacheMock, tasks: tasksMock}
+
+	steps := []model.ChainStep{
+		{
+			Name: "login",
+			Task: model.Task{Method: "GET", URL: "https://www.getyourtasks.com/login"},
+			Extract: map[string]model.ExtractRule{
+				"token":   {From: model.ExtractFromBody, Path: "$.access_token"},
+				"userId":  {From: model.ExtractFromBody, Path: "$.user.id"},
+				"session": {From: model.ExtractFromHeader, Path: "X-Session"},
+			},
+		},
+		{
+			Name: "orders",
+			Task: model.Task{Method: "GET", URL: "https://www.getyourtasks.com/users/{{.userId}}/orders",
+				Headers: map[string]interface{}{"Authorization": "Bearer {{.token}}", "X-Session": "{{.session}}"}},
+		},
+	}
+
+	loginStatus := http.StatusOK
+	login := &model.TasksObject{ID: "task-1", Status: model.Done, HTTPStatusCode: &loginStatus,
+		Headers: http.Header{"X-Session": {"s-1"}}}
+
+	tcs := []struct {
+		description string
+		mockCalls   []*gomock.Call
+		expStatus   string
+		expTaskIDs  []string
+		expErr      *model.TaskError
+	}{
+		{
+			description: "Positive case: the extracted values are injected into the next step",
+			mockCalls: []*gomock.Call{
+				// the login step extracts from the response body, so its task keeps it however large
+				tasksMock.EXPECT().TasksRun(gomock.Any(), model.Task{Method: "GET", URL: "https://www.getyourtasks.com/login",
+					ChainID: "chain-1", KeepBody: true}).Return(login, []byte(`{"access_token": "abc", "user": {"id": 12345678}}`), nil),
+				tasksMock.EXPECT().TasksRun(gomock.Any(), model.Task{Method: "GET", URL: "https://www.getyourtasks.com/users/12345678/orders",
+					Headers: map[string]interface{}{"Authorization": "Bearer abc", "X-Session": "s-1"}, ChainID: "chain-1"}).
+					Return(&model.TasksObject{ID: "task-2", Status: model.Done}, []byte(`[]`), nil),
+			},
+			expStatus:  model.Done,
+			expTaskIDs: []string{"task-1", "task-2"},
+		},
+		{
+			description: "Negative case: a failed step stops the chain",
+			mockCalls: []*gomock.Call{
+				tasksMock.EXPECT().TasksRun(gomock.Any(), gomock.Any()).
+					Return(&model.TasksObject{ID: "task-1", Status: model.Error}, nil, nil),
+			},
+			expStatus:  model.Error,
+			expTaskIDs: []string{"task-1", ""},
+			expErr:     &model.TaskError{Code: model.ErrCodeStepFailed, Message: "step 0 (login): task task-1 ended with status error"},
+		},
+		{
+			description: "Negative case: value missing from the response",
+			mockCalls: []*gomock.Call{
+				tasksMock.EXPECT().TasksRun(gomock.Any(), gomock.Any()).Return(login, []byte(`{"user": {"id": 1}}`), nil),
+			},
+			expStatus:  model.Error,
+			expTaskIDs: []string{"task-1", ""},
+			expErr: &model.TaskError{Code: model.ErrCodeExtractFailed,
+				Message: "step 0 (login): cannot extract token: no value at path $.access_token: no member access_token"},
+		},
+	}
+
+	var stored *model.Chain
+
+	cacheMock.EXPECT().StoreChain(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, chain *model.Chain) error {
+		stored = chain
+
+		return nil
+	}).AnyTimes()
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			chain := &model.Chain{ID: "chain-1", Status: model.New, Steps: append([]model.ChainStep(nil), steps...)}
+
+			c.run(context.TODO(), chain)
+
+			assert.Equal(t, tc.expStatus, stored.Status)
+			assert.Equal(t, tc.expErr, stored.Error)
+
+			for i, taskID := range tc.expTaskIDs {
+				assert.Equal(t, taskID, stored.Steps[i].TaskID)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package service
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http"
+	"time"
+)
+
+// newClient builds the client making the outbound calls through the configured proxies, every connection it opens
+// is checked by the guard. The TLS config is nil for the default client.
+func newClient(settings Settings, tlsConfig *tls.Config) http.Client {
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = settings.Guard.DialContext(dialer)
+	transport.Proxy = settings.proxyFor
+
+	if tlsConfig != nil {
+		transport.TLSClientConfig = tlsConfig.Clone()
+	}
+
+	return http.Client{
+		Transport:     transport,
+		CheckRedirect: settings.Guard.CheckRedirect,
+	}
+}
+
+// clientFor gives the client of the task's TLS profile, the default client if the task has none
+func (t tasks) clientFor(tlsProfile string) (http.Client, bool) {
+	if tlsProfile == "" {
+		return t.client, true
+	}
+
+	client, ok := t.profileClients[tlsProfile]
+
+	return client, ok
+}
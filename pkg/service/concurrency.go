@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/axxonsoft-assignment/pkg/model"
+)
+
+const (
+	// slotPollInterval is how often a queued task checks whether it is its turn
+	slotPollInterval = 200 * time.Millisecond
+
+	// slotLease is how long a slot is held without renewal, so that a crashed instance does not hold it forever
+	slotLease = time.Minute
+)
+
+// errSlotLost is the cause of a call cancelled because the lease of its slot could not be renewed
+var errSlotLost = errors.New("the concurrency slot was lost before the call was over")
+
+// acquireSlot blocks till the task gets one of the host's concurrency slots, the task is marked queued while it waits.
+// The call must be made with the returned context, which is cancelled if the slot is lost midway, and the returned
+// func gives the slot back and must be called once the call is over.
+func (t tasks) acquireSlot(ctx context.Context, host string, taskObj *model.TasksObject) (context.Context, func(), error) {
+	limit, ok := model.MatchConcurrencyLimit(t.settings.ConcurrencyLimits, host)
+	if !ok {
+		return ctx, func() {}, nil
+	}
+
+	host = strings.ToLower(host)
+
+	for {
+		acquired, err := t.cache.AcquireSlot(ctx, host, taskObj.ID, limit.Limit, slotLease, time.Now())
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if acquired {
+			break
+		}
+
+		if taskObj.Status != model.Queued {
+			taskObj.Status = model.Queued
+			if err = t.cache.StoreTask(ctx, taskObj.ID, taskObj); err != nil {
+				return nil, nil, err
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		case <-time.After(slotPollInterval):
+		}
+	}
+
+	// renew the lease while the call is in flight
+	callCtx, cancel := context.WithCancelCause(ctx)
+
+	go t.renewSlot(callCtx, cancel, host, taskObj.ID, slotLease/3)
+
+	return callCtx, func() {
+		cancel(nil)
+
+		_ = t.cache.ReleaseSlot(ctx, host, taskObj.ID)
+	}, nil
+}
+
+// renewSlot renews the lease of the slot till ctx is done, the call is cancelled once the slot is lost
+func (t tasks) renewSlot(ctx context.Context, cancel context.CancelCauseFunc, host string, holderID string,
+	interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	renewed := time.Now()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			ok, err := t.cache.RenewSlot(ctx, host, holderID, slotLease, time.Now())
+
+			switch {
+			case err == nil && ok:
+				renewed = time.Now()
+			case err == nil:
+				log.Printf("Task:%s lost its slot for host:%s", holderID, host)
+
+				cancel(errSlotLost)
+
+				return
+			case time.Since(renewed) >= slotLease:
+				// failing to reach the cache is retried on the next tick, till the lease expired anyway
+				log.Printf("Task:%s slot for host:%s expired without being renewed: %v", holderID, host, err)
+
+				cancel(errSlotLost)
+
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/axxonsoft-assignment/pkg/cache"
+	"github.com/axxonsoft-assignment/pkg/model"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTasks_acquireSlot(t *testing.T) {
+	settings := Settings{ConcurrencyLimits: []model.ConcurrencyLimit{{Pattern: "*.partner.com", Limit: 2}}}
+
+	tcs := []struct {
+		description string
+		host        string
+		mockCalls   func(cacheMock *cache.MockCache)
+		expStatus   string
+		expErr      error
+	}{
+		{
+			description: "Positive case: host is not limited",
+			host:        "example.com",
+			mockCalls:   func(cacheMock *cache.MockCache) {},
+			expStatus:   model.New,
+		},
+		{
+			description: "Positive case: task is queued till a slot is free",
+			host:        "API.partner.com",
+			mockCalls: func(cacheMock *cache.MockCache) {
+				gomock.InOrder(
+					cacheMock.EXPECT().AcquireSlot(gomock.Any(), "api.partner.com", "2313", 2, slotLease, gomock.Any()).Return(false, nil),
+					cacheMock.EXPECT().StoreTask(gomock.Any(), "2313", gomock.Any()).Return(nil),
+					cacheMock.EXPECT().AcquireSlot(gomock.Any(), "api.partner.com", "2313", 2, slotLease, gomock.Any()).Return(true, nil),
+					cacheMock.EXPECT().ReleaseSlot(gomock.Any(), "api.partner.com", "2313").Return(nil),
+				)
+			},
+			expStatus: model.Queued,
+		},
+		{
+			description: "Negative case: error from cache",
+			host:        "api.partner.com",
+			mockCalls: func(cacheMock *cache.MockCache) {
+				cacheMock.EXPECT().AcquireSlot(gomock.Any(), "api.partner.com", "2313", 2, slotLease, gomock.Any()).
+					Return(false, errors.New("DB error"))
+			},
+			expStatus: model.New,
+			expErr:    errors.New("DB error"),
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			cacheMock := cache.NewMockCache(ctrl)
+			tc.mockCalls(cacheMock)
+
+			taskObj := &model.TasksObject{ID: "2313", Status: model.New}
+
+			_, release, err := tasks{cache: cacheMock, settings: settings}.acquireSlot(context.TODO(), tc.host, taskObj)
+			if release != nil {
+				release()
+			}
+
+			assert.Equal(t, tc.expErr, err)
+			assert.Equal(t, tc.expStatus, taskObj.Status)
+		})
+	}
+}
+
+func TestTasks_renewSlot(t *testing.T) {
+	tcs := []struct {
+		description string
+		mockCalls   func(cacheMock *cache.MockCache)
+		expCause    error
+	}{
+		{
+			description: "Negative case: the call is cancelled once the slot is lost",
+			mockCalls: func(cacheMock *cache.MockCache) {
+				gomock.InOrder(
+					cacheMock.EXPECT().RenewSlot(gomock.Any(), "api.partner.com", "2313", slotLease, gomock.Any()).Return(true, nil),
+					cacheMock.EXPECT().RenewSlot(gomock.Any(), "api.partner.com", "2313", slotLease, gomock.Any()).Return(false, nil),
+				)
+			},
+			expCause: errSlotLost,
+		},
+		{
+			description: "Negative case: a failure to reach the cache is retried till the slot is lost",
+			mockCalls: func(cacheMock *cache.MockCache) {
+				gomock.InOrder(
+					cacheMock.EXPECT().RenewSlot(gomock.Any(), "api.partner.com", "2313", slotLease, gomock.Any()).
+						Return(false, errors.New("DB error")),
+					cacheMock.EXPECT().RenewSlot(gomock.Any(), "api.partner.com", "2313", slotLease, gomock.Any()).
+						Return(false, nil),
+				)
+			},
+			expCause: errSlotLost,
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			cacheMock := cache.NewMockCache(ctrl)
+			tc.mockCalls(cacheMock)
+
+			ctx, cancel := context.WithCancelCause(context.TODO())
+
+			tasks{cache: cacheMock}.renewSlot(ctx, cancel, "api.partner.com", "2313", time.Millisecond)
+
+			assert.Equal(t, tc.expCause, context.Cause(ctx))
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"bufio"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+)
+
+const (
+	// acceptEncoding lists the encodings decoded by decodeBody, asked for unless the task sets its own Accept-Encoding
+	acceptEncoding = "gzip, deflate, br"
+	// defaultMaxResponseBytes caps the decoded size of the response bodies
+	defaultMaxResponseBytes = 100 << 20
+)
+
+// errResponseTooLarge is returned once the decoded body is larger than the max response size
+var errResponseTooLarge = errors.New("response body too large")
+
+// countingReader counts the bytes read through it, failing with errResponseTooLarge once there are more than the
+// limit if there is one
+type countingReader struct {
+	reader io.Reader
+	count  int64
+	limit  int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.reader.Read(p)
+	c.count += int64(n)
+
+	if c.limit > 0 && c.count > c.limit {
+		return n, fmt.Errorf("%w: more than %d bytes", errResponseTooLarge, c.limit)
+	}
+
+	return n, err
+}
+
+// decodeBody gives the reader of the body decoded as per its Content-Encoding, which is removed from the headers
+// along with the Content-Length of the encoded body. A body of an unknown encoding is given as is.
+func decodeBody(body io.Reader, header http.Header) (io.Reader, error) {
+	var (
+		decoded io.Reader
+		err     error
+	)
+
+	switch strings.ToLower(strings.TrimSpace(header.Get("Content-Encoding"))) {
+	case "gzip", "x-gzip":
+		decoded, err = gzip.NewReader(body)
+	case "deflate":
+		decoded, err = deflateReader(body)
+	case "br":
+		decoded = brotli.NewReader(body)
+	default:
+		return body, nil
+	}
+
+	// an empty body, e.g. of a 204, has nothing to decode
+	if errors.Is(err, io.EOF) {
+		decoded, err = http.NoBody, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	header.Del("Content-Encoding")
+	header.Del("Content-Length")
+
+	return decoded, nil
+}
+
+// deflateReader reads a deflate body, which is meant to be zlib wrapped but is sent raw by some servers
+func deflateReader(body io.Reader) (io.Reader, error) {
+	buffered := bufio.NewReader(body)
+
+	header, err := buffered.Peek(2)
+	if err != nil {
+		return nil, err
+	}
+
+	// a zlib header has the deflate method and is a multiple of 31
+	if header[0]&0x0f == 8 && (uint16(header[0])<<8|uint16(header[1]))%31 == 0 {
+		return zlib.NewReader(buffered)
+	}
+
+	return flate.NewReader(buffered), nil
+}
@@ -0,0 +1,197 @@
+package service
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	"github.com/axxonsoft-assignment/pkg/breaker"
+	"github.com/axxonsoft-assignment/pkg/cache"
+	"github.com/axxonsoft-assignment/pkg/model"
+	"github.com/axxonsoft-assignment/pkg/ssrf"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTasks_decodeBody(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const payload = `{"items": ["a", "a", "a", "a", "a", "a", "a", "a", "a", "a", "a", "a", "a", "a", "a", "a"]}`
+
+	encode := func(newWriter func(io.Writer) io.WriteCloser) []byte {
+		var buf bytes.Buffer
+
+		writer := newWriter(&buf)
+		_, _ = writer.Write([]byte(payload))
+		_ = writer.Close()
+
+		return buf.Bytes()
+	}
+
+	encoded := map[string][]byte{
+		"gzip": encode(func(w io.Writer) io.WriteCloser { return gzip.NewWriter(w) }),
+		"zlib": encode(func(w io.Writer) io.WriteCloser { return zlib.NewWriter(w) }),
+		"flate": encode(func(w io.Writer) io.WriteCloser {
+			writer, _ := flate.NewWriter(w, flate.DefaultCompression)
+
+			return writer
+		}),
+		"br": encode(func(w io.Writer) io.WriteCloser { return brotli.NewWriter(w) }),
+	}
+
+	var acceptEncodings []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		acceptEncodings = append(acceptEncodings, r.Header.Get("Accept-Encoding"))
+
+		switch r.URL.Path {
+		case "/gzip":
+			w.Header().Set("Content-Encoding", "gzip")
+			_, _ = w.Write(encoded["gzip"])
+		case "/zlib":
+			w.Header().Set("Content-Encoding", "deflate")
+			_, _ = w.Write(encoded["zlib"])
+		case "/flate":
+			w.Header().Set("Content-Encoding", "deflate")
+			_, _ = w.Write(encoded["flate"])
+		case "/br":
+			w.Header().Set("Content-Encoding", "br")
+			_, _ = w.Write(encoded["br"])
+		case "/empty":
+			w.Header().Set("Content-Encoding", "gzip")
+		case "/unknown":
+			w.Header().Set("Content-Encoding", "zstd")
+			_, _ = w.Write([]byte("zstd-data"))
+		default:
+			_, _ = w.Write([]byte(payload))
+		}
+	}))
+	defer server.Close()
+
+	cacheMock := cache.NewMockCache(ctrl)
+	cacheMock.EXPECT().GetRateLimit(gomock.Any(), gomock.Any()).Return(&model.RateLimit{}, nil).AnyTimes()
+	cacheMock.EXPECT().StoreTask(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	guard, _ := ssrf.New([]string{"127.0.0.0/8"})
+	task := New(cacheMock, breaker.New(breaker.Settings{}), Settings{Guard: guard, MaxResponseBytes: 64 << 10})
+	limited := New(cacheMock, breaker.New(breaker.Settings{}), Settings{Guard: guard, MaxResponseBytes: 50})
+
+	tcs := []struct {
+		description       string
+		tasks             Tasks
+		path              string
+		headers           map[string]interface{}
+		expStatus         string
+		expBody           string
+		expWireLength     int64
+		expEncoding       string
+		expAcceptEncoding string
+		expErr            *model.TaskError
+	}{
+		{
+			description:       "Positive case: identity body",
+			tasks:             task,
+			path:              "/plain",
+			expStatus:         model.Done,
+			expBody:           payload,
+			expWireLength:     int64(len(payload)),
+			expAcceptEncoding: acceptEncoding,
+		},
+		{
+			description:       "Positive case: gzip body",
+			tasks:             task,
+			path:              "/gzip",
+			expStatus:         model.Done,
+			expBody:           payload,
+			expWireLength:     int64(len(encoded["gzip"])),
+			expAcceptEncoding: acceptEncoding,
+		},
+		{
+			description:       "Positive case: zlib wrapped deflate body",
+			tasks:             task,
+			path:              "/zlib",
+			expStatus:         model.Done,
+			expBody:           payload,
+			expWireLength:     int64(len(encoded["zlib"])),
+			expAcceptEncoding: acceptEncoding,
+		},
+		{
+			description:       "Positive case: raw deflate body",
+			tasks:             task,
+			path:              "/flate",
+			expStatus:         model.Done,
+			expBody:           payload,
+			expWireLength:     int64(len(encoded["flate"])),
+			expAcceptEncoding: acceptEncoding,
+		},
+		{
+			description:       "Positive case: brotli body, with the task's own Accept-Encoding",
+			tasks:             task,
+			path:              "/br",
+			headers:           map[string]interface{}{"Accept-Encoding": "br"},
+			expStatus:         model.Done,
+			expBody:           payload,
+			expWireLength:     int64(len(encoded["br"])),
+			expAcceptEncoding: "br",
+		},
+		{
+			description:       "Positive case: empty gzip body",
+			tasks:             task,
+			path:              "/empty",
+			expStatus:         model.Done,
+			expAcceptEncoding: acceptEncoding,
+		},
+		{
+			description:       "Positive case: unknown encoding left as is",
+			tasks:             task,
+			path:              "/unknown",
+			expStatus:         model.Done,
+			expBody:           "zstd-data",
+			expWireLength:     9,
+			expEncoding:       "zstd",
+			expAcceptEncoding: acceptEncoding,
+		},
+		{
+			description:       "Negative case: decoded body larger than the max response size",
+			tasks:             limited,
+			path:              "/gzip",
+			expStatus:         model.Error,
+			expAcceptEncoding: acceptEncoding,
+			expErr: &model.TaskError{Code: model.ErrCodeResponseTooLarge,
+				Message: "response body too large: more than 50 bytes"},
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			acceptEncodings = nil
+
+			taskObj, body, err := tc.tasks.TasksRun(context.TODO(),
+				model.Task{Method: "GET", URL: server.URL + tc.path, Headers: tc.headers})
+			assert.Nil(t, err)
+
+			assert.Equal(t, tc.expStatus, taskObj.Status)
+			assert.Equal(t, tc.expErr, taskObj.Error)
+			assert.Equal(t, []string{tc.expAcceptEncoding}, acceptEncodings)
+
+			if tc.expErr != nil {
+				return
+			}
+
+			assert.Equal(t, tc.expBody, string(body))
+			assert.Equal(t, int64(len(tc.expBody)), *taskObj.Length)
+			assert.Equal(t, tc.expWireLength, *taskObj.WireLength)
+			assert.Equal(t, tc.expEncoding, taskObj.Headers.Get("Content-Encoding"))
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package service
+
+import "errors"
+
+// Kind tells what went wrong with a call of the services, the transports map it to their status codes
+type Kind int
+
+const (
+	// KindValidation is a request that is invalid as such
+	KindValidation Kind = iota + 1
+	// KindNotFound is a request about something that does not exist
+	KindNotFound
+	// KindConflict is a request at odds with the current state of what it is about
+	KindConflict
+	// KindUnavailable is a failure of a dependency, e.g. redis, the same request may succeed later
+	KindUnavailable
+)
+
+// Error is an error of the services along with its kind, its message being the one of the wrapped error
+type Error struct {
+	Kind Kind
+	Err  error
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// KindOf gives the kind of the error, 0 for an error of none of the kinds
+func KindOf(err error) Kind {
+	var serviceErr *Error
+	if errors.As(err, &serviceErr) {
+		return serviceErr.Kind
+	}
+
+	return 0
+}
+
+// withKind wraps the error with the kind, unless it is nil or already has a kind, which is then kept
+func withKind(kind Kind, err error) error {
+	if err == nil || KindOf(err) != 0 {
+		return err
+	}
+
+	return &Error{Kind: kind, Err: err}
+}
+
+func validationError(err error) error {
+	return withKind(KindValidation, err)
+}
+
+func notFoundError(message string) error {
+	return &Error{Kind: KindNotFound, Err: errors.New(message)}
+}
+
+func conflictError(message string) error {
+	return &Error{Kind: KindConflict, Err: errors.New(message)}
+}
+
+func unavailableError(err error) error {
+	return withKind(KindUnavailable, err)
+}
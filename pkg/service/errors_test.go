@@ -0,0 +1,57 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/axxonsoft-assignment/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+// invalidRequest gives the validation error of a request with a single violation
+func invalidRequest(pointer, rule, message string) error {
+	return validationError(&model.ValidationError{Violations: []model.Violation{{Pointer: pointer, Rule: rule, Message: message}}})
+}
+
+func TestErrors_KindOf(t *testing.T) {
+	tcs := []struct {
+		description string
+		err         error
+		expKind     Kind
+	}{
+		{
+			description: "Positive case: typed error",
+			err:         validationError(errors.New("Invalid request: url cannot be empty")),
+			expKind:     KindValidation,
+		},
+		{
+			description: "Positive case: typed error wrapped again",
+			err:         fmt.Errorf("Invalid fanOut target 1: %w", notFoundError("Template not found")),
+			expKind:     KindNotFound,
+		},
+		{
+			description: "Positive case: kind kept when wrapped with another kind",
+			err:         unavailableError(conflictError("Schedule is already paused")),
+			expKind:     KindConflict,
+		},
+		{
+			description: "Negative case: untyped error",
+			err:         errors.New("DB error"),
+		},
+		{
+			description: "Negative case: no error",
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, tc.expKind, KindOf(tc.err))
+		})
+	}
+
+	assert.Nil(t, unavailableError(nil))
+	assert.Equal(t, "Invalid request: url cannot be empty", validationError(errors.New("Invalid request: url cannot be empty")).Error())
+}
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"regexp"
+	"strconv"
+
+	"github.com/axxonsoft-assignment/pkg/jsonpath"
+	"github.com/axxonsoft-assignment/pkg/model"
+)
+
+// extractOutputs takes the values of the rules out of the task's response. The numbers of the body are kept
+// as json.Number, so that large ids are rendered as they were received. A value larger than MaxOutputBytes
+// as JSON is an error, as the outputs are stored with the task.
+func extractOutputs(rules map[string]model.ExtractRule, taskObj *model.TasksObject, body []byte) (map[string]interface{}, error) {
+	outputs := make(map[string]interface{}, len(rules))
+
+	var (
+		doc       interface{}
+		docParsed bool
+	)
+
+	for name, rule := range rules {
+		switch rule.From {
+		case model.ExtractFromStatus:
+			if taskObj.HTTPStatusCode == nil {
+				return nil, errors.New("cannot extract " + name + ": no response")
+			}
+
+			outputs[name] = *taskObj.HTTPStatusCode
+		case model.ExtractFromHeader:
+			values := taskObj.Headers.Values(rule.Path)
+			if len(values) == 0 {
+				return nil, errors.New("cannot extract " + name + ": no header " + rule.Path)
+			}
+
+			outputs[name] = values[0]
+		case model.ExtractFromBody:
+			if !docParsed {
+				decoder := json.NewDecoder(bytes.NewReader(body))
+				decoder.UseNumber()
+
+				if err := decoder.Decode(&doc); err != nil {
+					return nil, errors.New("cannot extract " + name + ": response body is not JSON")
+				}
+
+				docParsed = true
+			}
+
+			value, err := jsonpath.Get(doc, rule.Path)
+			if err != nil {
+				return nil, errors.New("cannot extract " + name + ": " + err.Error())
+			}
+
+			outputs[name] = value
+		case model.ExtractFromRegex:
+			match := regexp.MustCompile(rule.Path).FindSubmatch(body)
+			if match == nil {
+				return nil, errors.New("cannot extract " + name + ": no match for " + rule.Path)
+			}
+
+			if len(match) > 1 {
+				outputs[name] = string(match[1])
+			} else {
+				outputs[name] = string(match[0])
+			}
+		}
+
+		if encoded, _ := json.Marshal(outputs[name]); len(encoded) > model.MaxOutputBytes {
+			return nil, errors.New("cannot extract " + name + ": value larger than " + strconv.Itoa(model.MaxOutputBytes) + " bytes")
+		}
+	}
+
+	return outputs, nil
+}
@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/axxonsoft-assignment/pkg/breaker"
+	"github.com/axxonsoft-assignment/pkg/cache"
+	"github.com/axxonsoft-assignment/pkg/model"
+	"github.com/axxonsoft-assignment/pkg/ssrf"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTasks_Extract(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-1")
+		_, _ = w.Write([]byte(`{"order": {"id": 12345678901, "items": [{"sku": "A-1"}]}, "note": "shipped on 2026-10-01"}`))
+	}))
+	defer server.Close()
+
+	cacheMock := cache.NewMockCache(ctrl)
+	cacheMock.EXPECT().GetRateLimit(gomock.Any(), gomock.Any()).Return(&model.RateLimit{}, nil).AnyTimes()
+	cacheMock.EXPECT().StoreTask(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	guard, _ := ssrf.New([]string{"127.0.0.0/8"})
+	task := New(cacheMock, breaker.New(breaker.Settings{}), Settings{Guard: guard})
+
+	tcs := []struct {
+		description string
+		extract     map[string]model.ExtractRule
+		expStatus   string
+		expOutputs  map[string]interface{}
+		expErr      *model.TaskError
+	}{
+		{
+			description: "Positive case: values from the body, a header, a regex and the status",
+			extract: map[string]model.ExtractRule{
+				"orderId":   {From: model.ExtractFromBody, Path: "$.order.id"},
+				"sku":       {From: model.ExtractFromBody, Path: "$.order.items[0].sku"},
+				"requestId": {From: model.ExtractFromHeader, Path: "X-Request-Id"},
+				"shippedOn": {From: model.ExtractFromRegex, Path: `shipped on (\d{4}-\d{2}-\d{2})`},
+				"status":    {From: model.ExtractFromStatus},
+			},
+			expStatus: model.Done,
+			expOutputs: map[string]interface{}{"orderId": "12345678901", "sku": "A-1", "requestId": "req-1",
+				"shippedOn": "2026-10-01", "status": http.StatusOK},
+		},
+		{
+			description: "Negative case: regex without a match",
+			extract:     map[string]model.ExtractRule{"trackingId": {From: model.ExtractFromRegex, Path: `tracking (\w+)`}},
+			expStatus:   model.Error,
+			expErr:      &model.TaskError{Code: model.ErrCodeExtractFailed, Message: `cannot extract trackingId: no match for tracking (\w+)`},
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			taskObj, _, err := task.TasksRun(context.TODO(), model.Task{Method: "GET", URL: server.URL + "/orders/1", Extract: tc.extract})
+			assert.Nil(t, err)
+
+			assert.Equal(t, tc.expStatus, taskObj.Status)
+			assert.Equal(t, tc.expErr, taskObj.Error)
+
+			// the numbers are kept as they were received
+			if orderID, ok := taskObj.Outputs["orderId"]; ok {
+				taskObj.Outputs["orderId"] = orderID.(interface{ String() string }).String()
+			}
+
+			assert.Equal(t, tc.expOutputs, taskObj.Outputs)
+		})
+	}
+}
+
+func TestTasks_extractOutputs_tooLarge(t *testing.T) {
+	body := []byte(`{"blob": "` + strings.Repeat("a", model.MaxOutputBytes) + `"}`)
+
+	_, err := extractOutputs(map[string]model.ExtractRule{"blob": {From: model.ExtractFromBody, Path: "$.blob"}}, &model.TasksObject{}, body)
+	assert.Equal(t, errors.New("cannot extract blob: value larger than 4096 bytes"), err)
+}
@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/axxonsoft-assignment/pkg/model"
+)
+
+// prepareFanOut checks every target of the fan-out task, then stores the task along with a task of its own for each
+// target, all of them with the status "new".
+func (t tasks) prepareFanOut(ctx context.Context, taskDetails model.Task) (*model.TasksObject, []model.Task, []*model.TasksObject, error) {
+	targets, err := t.checkFanOut(ctx, taskDetails)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	taskObj, err := t.store(ctx, taskDetails)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	policy := taskDetails.FanOut.Policy
+	if policy == "" {
+		policy = model.FanOutAll
+	}
+
+	taskObj.Aggregate = &model.Aggregate{Policy: policy, Required: taskDetails.FanOut.Required()}
+	taskObj.Targets = make([]model.FanOutTarget, len(targets))
+	targetObjs := make([]*model.TasksObject, len(targets))
+
+	for i := range targets {
+		targets[i].ParentID = taskObj.ID
+
+		if targetObjs[i], err = t.store(ctx, targets[i]); err != nil {
+			return nil, nil, nil, err
+		}
+
+		taskObj.Targets[i] = model.FanOutTarget{URL: targets[i].URL, TaskID: targetObjs[i].ID}
+	}
+
+	if err = t.cache.StoreTask(ctx, taskObj.ID, taskObj); err != nil {
+		return nil, nil, nil, unavailableError(err)
+	}
+
+	return taskObj, targets, targetObjs, nil
+}
+
+// checkFanOut checks the fan-out task along with every one of its targets, giving the targets. A violation of a
+// target's url is reported at the pointer of the target, any other one once for all of them.
+func (t tasks) checkFanOut(ctx context.Context, taskDetails model.Task) ([]model.Task, error) {
+	if err := model.ValidateRequestBody(taskDetails); err != nil {
+		return nil, validationError(err)
+	}
+
+	targets := taskDetails.Targets()
+
+	var found []model.Violation
+
+	for i, target := range targets {
+		err := t.check(ctx, target)
+		if err == nil {
+			continue
+		}
+
+		var validationErr *model.ValidationError
+		if !errors.As(err, &validationErr) {
+			return nil, fmt.Errorf("Invalid fanOut target %d: %w", i, err)
+		}
+
+		for _, violation := range validationErr.Violations {
+			if violation.Pointer == "/url" {
+				violation.Pointer = "/fanOut/urls/" + strconv.Itoa(i)
+				if i >= len(taskDetails.FanOut.URLs) {
+					violation.Pointer = "/fanOut/hosts/" + strconv.Itoa(i-len(taskDetails.FanOut.URLs))
+				}
+
+				violation.Message = fmt.Sprintf("Invalid fanOut target %d: %s", i, violation.Message)
+			}
+
+			if !containsViolation(found, violation) {
+				found = append(found, violation)
+			}
+		}
+	}
+
+	if len(found) > 0 {
+		return nil, validationError(&model.ValidationError{Violations: found})
+	}
+
+	return targets, nil
+}
+
+// containsViolation tells if the violation was already found
+func containsViolation(found []model.Violation, violation model.Violation) bool {
+	for _, existing := range found {
+		if existing == violation {
+			return true
+		}
+	}
+
+	return false
+}
+
+// dispatchFanOut makes the calls of the targets in parallel, the fan-out task ending up done if as many targets as
+// its policy requires did.
+func (t tasks) dispatchFanOut(ctx context.Context, taskObj *model.TasksObject, targets []model.Task, targetObjs []*model.TasksObject) {
+	taskObj.Status = model.InProcess
+	_ = t.cache.StoreTask(ctx, taskObj.ID, taskObj)
+
+	var wg sync.WaitGroup
+
+	for i := range targets {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			t.execute(ctx, targets[i], targetObjs[i])
+		}(i)
+	}
+
+	wg.Wait()
+
+	for _, targetObj := range targetObjs {
+		if targetObj.Status == model.Done {
+			taskObj.Aggregate.Succeeded++
+		} else {
+			taskObj.Aggregate.Failed++
+		}
+	}
+
+	if taskObj.Aggregate.Succeeded >= taskObj.Aggregate.Required {
+		taskObj.Status = model.Done
+		_ = t.cache.StoreTask(ctx, taskObj.ID, taskObj)
+
+		return
+	}
+
+	t.failTask(ctx, taskObj, model.ErrCodeFanOutFailed, fmt.Errorf("%d of %d targets succeeded, %d required",
+		taskObj.Aggregate.Succeeded, len(targets), taskObj.Aggregate.Required))
+}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/axxonsoft-assignment/pkg/breaker"
+	"github.com/axxonsoft-assignment/pkg/cache"
+	"github.com/axxonsoft-assignment/pkg/model"
+	"github.com/axxonsoft-assignment/pkg/ssrf"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTasks_FanOut(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/down" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	var (
+		mu     sync.Mutex
+		stored = map[string]*model.TasksObject{}
+	)
+
+	cacheMock := cache.NewMockCache(ctrl)
+	cacheMock.EXPECT().GetRateLimit(gomock.Any(), gomock.Any()).Return(&model.RateLimit{}, nil).AnyTimes()
+	cacheMock.EXPECT().StoreTask(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, taskID string, taskObj *model.TasksObject) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		copied := *taskObj
+		stored[taskID] = &copied
+
+		return nil
+	}).AnyTimes()
+	cacheMock.EXPECT().GetTask(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, taskID string) (*model.TasksObject, error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		copied := *stored[taskID]
+
+		return &copied, nil
+	}).AnyTimes()
+
+	guard, _ := ssrf.New([]string{"127.0.0.0/8"})
+	task := New(cacheMock, breaker.New(breaker.Settings{}), Settings{Guard: guard})
+
+	urls := []string{server.URL + "/eu", server.URL + "/us", server.URL + "/down"}
+
+	tcs := []struct {
+		description  string
+		fanOut       *model.FanOut
+		expStatus    string
+		expAggregate *model.Aggregate
+		expErr       *model.TaskError
+	}{
+		{
+			description:  "Positive case: quorum of the targets succeeded",
+			fanOut:       &model.FanOut{URLs: urls, Policy: model.FanOutQuorum},
+			expStatus:    model.Done,
+			expAggregate: &model.Aggregate{Policy: model.FanOutQuorum, Required: 2, Succeeded: 2, Failed: 1},
+		},
+		{
+			description:  "Negative case: not all the targets succeeded",
+			fanOut:       &model.FanOut{URLs: urls},
+			expStatus:    model.Error,
+			expAggregate: &model.Aggregate{Policy: model.FanOutAll, Required: 3, Succeeded: 2, Failed: 1},
+			expErr:       &model.TaskError{Code: model.ErrCodeFanOutFailed, Message: "2 of 3 targets succeeded, 3 required"},
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			taskObj, body, err := task.TasksRun(context.TODO(), model.Task{Method: "GET", FanOut: tc.fanOut})
+			assert.Nil(t, err)
+			assert.Nil(t, body)
+			assert.Equal(t, tc.expStatus, taskObj.Status)
+
+			resp, err := task.TasksGet(context.TODO(), taskObj.ID)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expStatus, resp.Status)
+			assert.Equal(t, tc.expAggregate, resp.Aggregate)
+			assert.Equal(t, tc.expErr, resp.Error)
+
+			// every target reports its own task
+			for i, target := range resp.Targets {
+				assert.Equal(t, urls[i], target.URL)
+				assert.Equal(t, target.TaskID, target.Result.ID)
+				assert.Equal(t, taskObj.ID, target.Result.ParentID)
+			}
+
+			assert.Equal(t, model.Done, resp.Targets[0].Result.Status)
+			assert.Equal(t, http.StatusServiceUnavailable, *resp.Targets[2].Result.HTTPStatusCode)
+		})
+	}
+}
@@ -3,9 +3,59 @@ package service
 import (
 	"context"
 	"github.com/axxonsoft-assignment/pkg/model"
+	"io"
+	"time"
 )
 
 type Tasks interface {
 	TasksCreate(ctx context.Context, body model.Task) (*model.TasksResponse, error)
 	TasksGet(ctx context.Context, taskID string) (*model.TasksObject, error)
+	TasksList(ctx context.Context, offset, limit int) ([]*model.TasksObject, int, error)
+	TasksRun(ctx context.Context, body model.Task) (*model.TasksObject, []byte, error)
+	TasksBody(ctx context.Context, taskID string) (*model.Blob, io.ReadSeekCloser, error)
+	TasksCheck(ctx context.Context, body model.Task) error
+}
+
+type Schedules interface {
+	SchedulesCreate(ctx context.Context, schedule model.Schedule) (*model.Schedule, error)
+	SchedulesGet(ctx context.Context, scheduleID string) (*model.Schedule, error)
+	SchedulesList(ctx context.Context) ([]*model.Schedule, error)
+	SchedulesUpdate(ctx context.Context, scheduleID string, schedule model.Schedule) (*model.Schedule, error)
+	SchedulesDelete(ctx context.Context, scheduleID string) error
+	SchedulesPause(ctx context.Context, scheduleID string) (*model.Schedule, error)
+	SchedulesResume(ctx context.Context, scheduleID string) (*model.Schedule, error)
+	SchedulesHistory(ctx context.Context, scheduleID string) ([]*model.ScheduleRun, error)
+	SchedulesRunDue(ctx context.Context, now time.Time) error
+}
+
+type RateLimits interface {
+	RateLimitsSet(ctx context.Context, host string, limit model.RateLimit) (*model.RateLimit, error)
+	RateLimitsGet(ctx context.Context, host string) (*model.RateLimit, error)
+	RateLimitsList(ctx context.Context) ([]*model.RateLimit, error)
+	RateLimitsDelete(ctx context.Context, host string) error
+}
+
+type Breakers interface {
+	BreakersList(ctx context.Context) []model.CircuitState
+	BreakersGet(ctx context.Context, host string) model.CircuitState
+	BreakersReset(ctx context.Context, host string) model.CircuitState
+}
+
+type Templates interface {
+	TemplatesSet(ctx context.Context, name string, tmpl model.TaskTemplate) (*model.TaskTemplate, error)
+	TemplatesGet(ctx context.Context, name string) (*model.TaskTemplate, error)
+	TemplatesList(ctx context.Context) ([]*model.TaskTemplate, error)
+	TemplatesDelete(ctx context.Context, name string) error
+	TemplatesCreateTask(ctx context.Context, name string, variables map[string]interface{}) (*model.TasksResponse, error)
+}
+
+type Chains interface {
+	ChainsCreate(ctx context.Context, chain model.Chain) (*model.Chain, error)
+	ChainsGet(ctx context.Context, chainID string) (*model.Chain, error)
+}
+
+type Workflows interface {
+	WorkflowsCreate(ctx context.Context, workflow model.Workflow) (*model.Workflow, error)
+	WorkflowsGet(ctx context.Context, workflowID string) (*model.Workflow, error)
+	WorkflowsResume(ctx context.Context) error
 }
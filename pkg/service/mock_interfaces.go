@@ -6,7 +6,9 @@ package service
 
 import (
 	context "context"
+	io "io"
 	reflect "reflect"
+	time "time"
 
 	model "github.com/axxonsoft-assignment/pkg/model"
 	gomock "github.com/golang/mock/gomock"
@@ -35,6 +37,36 @@ func (m *MockTasks) EXPECT() *MockTasksMockRecorder {
 	return m.recorder
 }
 
+// TasksBody mocks base method.
+func (m *MockTasks) TasksBody(ctx context.Context, taskID string) (*model.Blob, io.ReadSeekCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TasksBody", ctx, taskID)
+	ret0, _ := ret[0].(*model.Blob)
+	ret1, _ := ret[1].(io.ReadSeekCloser)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// TasksBody indicates an expected call of TasksBody.
+func (mr *MockTasksMockRecorder) TasksBody(ctx, taskID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TasksBody", reflect.TypeOf((*MockTasks)(nil).TasksBody), ctx, taskID)
+}
+
+// TasksCheck mocks base method.
+func (m *MockTasks) TasksCheck(ctx context.Context, body model.Task) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TasksCheck", ctx, body)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TasksCheck indicates an expected call of TasksCheck.
+func (mr *MockTasksMockRecorder) TasksCheck(ctx, body interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TasksCheck", reflect.TypeOf((*MockTasks)(nil).TasksCheck), ctx, body)
+}
+
 // TasksCreate mocks base method.
 func (m *MockTasks) TasksCreate(ctx context.Context, body model.Task) (*model.TasksResponse, error) {
 	m.ctrl.T.Helper()
@@ -64,3 +96,555 @@ func (mr *MockTasksMockRecorder) TasksGet(ctx, taskID interface{}) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TasksGet", reflect.TypeOf((*MockTasks)(nil).TasksGet), ctx, taskID)
 }
+
+// TasksList mocks base method.
+func (m *MockTasks) TasksList(ctx context.Context, offset, limit int) ([]*model.TasksObject, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TasksList", ctx, offset, limit)
+	ret0, _ := ret[0].([]*model.TasksObject)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// TasksList indicates an expected call of TasksList.
+func (mr *MockTasksMockRecorder) TasksList(ctx, offset, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TasksList", reflect.TypeOf((*MockTasks)(nil).TasksList), ctx, offset, limit)
+}
+
+// TasksRun mocks base method.
+func (m *MockTasks) TasksRun(ctx context.Context, body model.Task) (*model.TasksObject, []byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TasksRun", ctx, body)
+	ret0, _ := ret[0].(*model.TasksObject)
+	ret1, _ := ret[1].([]byte)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// TasksRun indicates an expected call of TasksRun.
+func (mr *MockTasksMockRecorder) TasksRun(ctx, body interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TasksRun", reflect.TypeOf((*MockTasks)(nil).TasksRun), ctx, body)
+}
+
+// MockSchedules is a mock of Schedules interface.
+type MockSchedules struct {
+	ctrl     *gomock.Controller
+	recorder *MockSchedulesMockRecorder
+}
+
+// MockSchedulesMockRecorder is the mock recorder for MockSchedules.
+type MockSchedulesMockRecorder struct {
+	mock *MockSchedules
+}
+
+// NewMockSchedules creates a new mock instance.
+func NewMockSchedules(ctrl *gomock.Controller) *MockSchedules {
+	mock := &MockSchedules{ctrl: ctrl}
+	mock.recorder = &MockSchedulesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSchedules) EXPECT() *MockSchedulesMockRecorder {
+	return m.recorder
+}
+
+// SchedulesCreate mocks base method.
+func (m *MockSchedules) SchedulesCreate(ctx context.Context, schedule model.Schedule) (*model.Schedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SchedulesCreate", ctx, schedule)
+	ret0, _ := ret[0].(*model.Schedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SchedulesCreate indicates an expected call of SchedulesCreate.
+func (mr *MockSchedulesMockRecorder) SchedulesCreate(ctx, schedule interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SchedulesCreate", reflect.TypeOf((*MockSchedules)(nil).SchedulesCreate), ctx, schedule)
+}
+
+// SchedulesDelete mocks base method.
+func (m *MockSchedules) SchedulesDelete(ctx context.Context, scheduleID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SchedulesDelete", ctx, scheduleID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SchedulesDelete indicates an expected call of SchedulesDelete.
+func (mr *MockSchedulesMockRecorder) SchedulesDelete(ctx, scheduleID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SchedulesDelete", reflect.TypeOf((*MockSchedules)(nil).SchedulesDelete), ctx, scheduleID)
+}
+
+// SchedulesGet mocks base method.
+func (m *MockSchedules) SchedulesGet(ctx context.Context, scheduleID string) (*model.Schedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SchedulesGet", ctx, scheduleID)
+	ret0, _ := ret[0].(*model.Schedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SchedulesGet indicates an expected call of SchedulesGet.
+func (mr *MockSchedulesMockRecorder) SchedulesGet(ctx, scheduleID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SchedulesGet", reflect.TypeOf((*MockSchedules)(nil).SchedulesGet), ctx, scheduleID)
+}
+
+// SchedulesHistory mocks base method.
+func (m *MockSchedules) SchedulesHistory(ctx context.Context, scheduleID string) ([]*model.ScheduleRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SchedulesHistory", ctx, scheduleID)
+	ret0, _ := ret[0].([]*model.ScheduleRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SchedulesHistory indicates an expected call of SchedulesHistory.
+func (mr *MockSchedulesMockRecorder) SchedulesHistory(ctx, scheduleID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SchedulesHistory", reflect.TypeOf((*MockSchedules)(nil).SchedulesHistory), ctx, scheduleID)
+}
+
+// SchedulesList mocks base method.
+func (m *MockSchedules) SchedulesList(ctx context.Context) ([]*model.Schedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SchedulesList", ctx)
+	ret0, _ := ret[0].([]*model.Schedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SchedulesList indicates an expected call of SchedulesList.
+func (mr *MockSchedulesMockRecorder) SchedulesList(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SchedulesList", reflect.TypeOf((*MockSchedules)(nil).SchedulesList), ctx)
+}
+
+// SchedulesPause mocks base method.
+func (m *MockSchedules) SchedulesPause(ctx context.Context, scheduleID string) (*model.Schedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SchedulesPause", ctx, scheduleID)
+	ret0, _ := ret[0].(*model.Schedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SchedulesPause indicates an expected call of SchedulesPause.
+func (mr *MockSchedulesMockRecorder) SchedulesPause(ctx, scheduleID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SchedulesPause", reflect.TypeOf((*MockSchedules)(nil).SchedulesPause), ctx, scheduleID)
+}
+
+// SchedulesResume mocks base method.
+func (m *MockSchedules) SchedulesResume(ctx context.Context, scheduleID string) (*model.Schedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SchedulesResume", ctx, scheduleID)
+	ret0, _ := ret[0].(*model.Schedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SchedulesResume indicates an expected call of SchedulesResume.
+func (mr *MockSchedulesMockRecorder) SchedulesResume(ctx, scheduleID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SchedulesResume", reflect.TypeOf((*MockSchedules)(nil).SchedulesResume), ctx, scheduleID)
+}
+
+// SchedulesRunDue mocks base method.
+func (m *MockSchedules) SchedulesRunDue(ctx context.Context, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SchedulesRunDue", ctx, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SchedulesRunDue indicates an expected call of SchedulesRunDue.
+func (mr *MockSchedulesMockRecorder) SchedulesRunDue(ctx, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SchedulesRunDue", reflect.TypeOf((*MockSchedules)(nil).SchedulesRunDue), ctx, now)
+}
+
+// SchedulesUpdate mocks base method.
+func (m *MockSchedules) SchedulesUpdate(ctx context.Context, scheduleID string, schedule model.Schedule) (*model.Schedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SchedulesUpdate", ctx, scheduleID, schedule)
+	ret0, _ := ret[0].(*model.Schedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SchedulesUpdate indicates an expected call of SchedulesUpdate.
+func (mr *MockSchedulesMockRecorder) SchedulesUpdate(ctx, scheduleID, schedule interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SchedulesUpdate", reflect.TypeOf((*MockSchedules)(nil).SchedulesUpdate), ctx, scheduleID, schedule)
+}
+
+// MockRateLimits is a mock of RateLimits interface.
+type MockRateLimits struct {
+	ctrl     *gomock.Controller
+	recorder *MockRateLimitsMockRecorder
+}
+
+// MockRateLimitsMockRecorder is the mock recorder for MockRateLimits.
+type MockRateLimitsMockRecorder struct {
+	mock *MockRateLimits
+}
+
+// NewMockRateLimits creates a new mock instance.
+func NewMockRateLimits(ctrl *gomock.Controller) *MockRateLimits {
+	mock := &MockRateLimits{ctrl: ctrl}
+	mock.recorder = &MockRateLimitsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRateLimits) EXPECT() *MockRateLimitsMockRecorder {
+	return m.recorder
+}
+
+// RateLimitsDelete mocks base method.
+func (m *MockRateLimits) RateLimitsDelete(ctx context.Context, host string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RateLimitsDelete", ctx, host)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RateLimitsDelete indicates an expected call of RateLimitsDelete.
+func (mr *MockRateLimitsMockRecorder) RateLimitsDelete(ctx, host interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RateLimitsDelete", reflect.TypeOf((*MockRateLimits)(nil).RateLimitsDelete), ctx, host)
+}
+
+// RateLimitsGet mocks base method.
+func (m *MockRateLimits) RateLimitsGet(ctx context.Context, host string) (*model.RateLimit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RateLimitsGet", ctx, host)
+	ret0, _ := ret[0].(*model.RateLimit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RateLimitsGet indicates an expected call of RateLimitsGet.
+func (mr *MockRateLimitsMockRecorder) RateLimitsGet(ctx, host interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RateLimitsGet", reflect.TypeOf((*MockRateLimits)(nil).RateLimitsGet), ctx, host)
+}
+
+// RateLimitsList mocks base method.
+func (m *MockRateLimits) RateLimitsList(ctx context.Context) ([]*model.RateLimit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RateLimitsList", ctx)
+	ret0, _ := ret[0].([]*model.RateLimit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RateLimitsList indicates an expected call of RateLimitsList.
+func (mr *MockRateLimitsMockRecorder) RateLimitsList(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RateLimitsList", reflect.TypeOf((*MockRateLimits)(nil).RateLimitsList), ctx)
+}
+
+// RateLimitsSet mocks base method.
+func (m *MockRateLimits) RateLimitsSet(ctx context.Context, host string, limit model.RateLimit) (*model.RateLimit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RateLimitsSet", ctx, host, limit)
+	ret0, _ := ret[0].(*model.RateLimit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RateLimitsSet indicates an expected call of RateLimitsSet.
+func (mr *MockRateLimitsMockRecorder) RateLimitsSet(ctx, host, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RateLimitsSet", reflect.TypeOf((*MockRateLimits)(nil).RateLimitsSet), ctx, host, limit)
+}
+
+// MockBreakers is a mock of Breakers interface.
+type MockBreakers struct {
+	ctrl     *gomock.Controller
+	recorder *MockBreakersMockRecorder
+}
+
+// MockBreakersMockRecorder is the mock recorder for MockBreakers.
+type MockBreakersMockRecorder struct {
+	mock *MockBreakers
+}
+
+// NewMockBreakers creates a new mock instance.
+func NewMockBreakers(ctrl *gomock.Controller) *MockBreakers {
+	mock := &MockBreakers{ctrl: ctrl}
+	mock.recorder = &MockBreakersMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBreakers) EXPECT() *MockBreakersMockRecorder {
+	return m.recorder
+}
+
+// BreakersGet mocks base method.
+func (m *MockBreakers) BreakersGet(ctx context.Context, host string) model.CircuitState {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BreakersGet", ctx, host)
+	ret0, _ := ret[0].(model.CircuitState)
+	return ret0
+}
+
+// BreakersGet indicates an expected call of BreakersGet.
+func (mr *MockBreakersMockRecorder) BreakersGet(ctx, host interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BreakersGet", reflect.TypeOf((*MockBreakers)(nil).BreakersGet), ctx, host)
+}
+
+// BreakersList mocks base method.
+func (m *MockBreakers) BreakersList(ctx context.Context) []model.CircuitState {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BreakersList", ctx)
+	ret0, _ := ret[0].([]model.CircuitState)
+	return ret0
+}
+
+// BreakersList indicates an expected call of BreakersList.
+func (mr *MockBreakersMockRecorder) BreakersList(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BreakersList", reflect.TypeOf((*MockBreakers)(nil).BreakersList), ctx)
+}
+
+// BreakersReset mocks base method.
+func (m *MockBreakers) BreakersReset(ctx context.Context, host string) model.CircuitState {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BreakersReset", ctx, host)
+	ret0, _ := ret[0].(model.CircuitState)
+	return ret0
+}
+
+// BreakersReset indicates an expected call of BreakersReset.
+func (mr *MockBreakersMockRecorder) BreakersReset(ctx, host interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BreakersReset", reflect.TypeOf((*MockBreakers)(nil).BreakersReset), ctx, host)
+}
+
+// MockTemplates is a mock of Templates interface.
+type MockTemplates struct {
+	ctrl     *gomock.Controller
+	recorder *MockTemplatesMockRecorder
+}
+
+// MockTemplatesMockRecorder is the mock recorder for MockTemplates.
+type MockTemplatesMockRecorder struct {
+	mock *MockTemplates
+}
+
+// NewMockTemplates creates a new mock instance.
+func NewMockTemplates(ctrl *gomock.Controller) *MockTemplates {
+	mock := &MockTemplates{ctrl: ctrl}
+	mock.recorder = &MockTemplatesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTemplates) EXPECT() *MockTemplatesMockRecorder {
+	return m.recorder
+}
+
+// TemplatesCreateTask mocks base method.
+func (m *MockTemplates) TemplatesCreateTask(ctx context.Context, name string, variables map[string]interface{}) (*model.TasksResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TemplatesCreateTask", ctx, name, variables)
+	ret0, _ := ret[0].(*model.TasksResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TemplatesCreateTask indicates an expected call of TemplatesCreateTask.
+func (mr *MockTemplatesMockRecorder) TemplatesCreateTask(ctx, name, variables interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TemplatesCreateTask", reflect.TypeOf((*MockTemplates)(nil).TemplatesCreateTask), ctx, name, variables)
+}
+
+// TemplatesDelete mocks base method.
+func (m *MockTemplates) TemplatesDelete(ctx context.Context, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TemplatesDelete", ctx, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TemplatesDelete indicates an expected call of TemplatesDelete.
+func (mr *MockTemplatesMockRecorder) TemplatesDelete(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TemplatesDelete", reflect.TypeOf((*MockTemplates)(nil).TemplatesDelete), ctx, name)
+}
+
+// TemplatesGet mocks base method.
+func (m *MockTemplates) TemplatesGet(ctx context.Context, name string) (*model.TaskTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TemplatesGet", ctx, name)
+	ret0, _ := ret[0].(*model.TaskTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TemplatesGet indicates an expected call of TemplatesGet.
+func (mr *MockTemplatesMockRecorder) TemplatesGet(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TemplatesGet", reflect.TypeOf((*MockTemplates)(nil).TemplatesGet), ctx, name)
+}
+
+// TemplatesList mocks base method.
+func (m *MockTemplates) TemplatesList(ctx context.Context) ([]*model.TaskTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TemplatesList", ctx)
+	ret0, _ := ret[0].([]*model.TaskTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TemplatesList indicates an expected call of TemplatesList.
+func (mr *MockTemplatesMockRecorder) TemplatesList(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TemplatesList", reflect.TypeOf((*MockTemplates)(nil).TemplatesList), ctx)
+}
+
+// TemplatesSet mocks base method.
+func (m *MockTemplates) TemplatesSet(ctx context.Context, name string, tmpl model.TaskTemplate) (*model.TaskTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TemplatesSet", ctx, name, tmpl)
+	ret0, _ := ret[0].(*model.TaskTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TemplatesSet indicates an expected call of TemplatesSet.
+func (mr *MockTemplatesMockRecorder) TemplatesSet(ctx, name, tmpl interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TemplatesSet", reflect.TypeOf((*MockTemplates)(nil).TemplatesSet), ctx, name, tmpl)
+}
+
+// MockChains is a mock of Chains interface.
+type MockChains struct {
+	ctrl     *gomock.Controller
+	recorder *MockChainsMockRecorder
+}
+
+// MockChainsMockRecorder is the mock recorder for MockChains.
+type MockChainsMockRecorder struct {
+	mock *MockChains
+}
+
+// NewMockChains creates a new mock instance.
+func NewMockChains(ctrl *gomock.Controller) *MockChains {
+	mock := &MockChains{ctrl: ctrl}
+	mock.recorder = &MockChainsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChains) EXPECT() *MockChainsMockRecorder {
+	return m.recorder
+}
+
+// ChainsCreate mocks base method.
+func (m *MockChains) ChainsCreate(ctx context.Context, chain model.Chain) (*model.Chain, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChainsCreate", ctx, chain)
+	ret0, _ := ret[0].(*model.Chain)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChainsCreate indicates an expected call of ChainsCreate.
+func (mr *MockChainsMockRecorder) ChainsCreate(ctx, chain interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainsCreate", reflect.TypeOf((*MockChains)(nil).ChainsCreate), ctx, chain)
+}
+
+// ChainsGet mocks base method.
+func (m *MockChains) ChainsGet(ctx context.Context, chainID string) (*model.Chain, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChainsGet", ctx, chainID)
+	ret0, _ := ret[0].(*model.Chain)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChainsGet indicates an expected call of ChainsGet.
+func (mr *MockChainsMockRecorder) ChainsGet(ctx, chainID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChainsGet", reflect.TypeOf((*MockChains)(nil).ChainsGet), ctx, chainID)
+}
+
+// MockWorkflows is a mock of Workflows interface.
+type MockWorkflows struct {
+	ctrl     *gomock.Controller
+	recorder *MockWorkflowsMockRecorder
+}
+
+// MockWorkflowsMockRecorder is the mock recorder for MockWorkflows.
+type MockWorkflowsMockRecorder struct {
+	mock *MockWorkflows
+}
+
+// NewMockWorkflows creates a new mock instance.
+func NewMockWorkflows(ctrl *gomock.Controller) *MockWorkflows {
+	mock := &MockWorkflows{ctrl: ctrl}
+	mock.recorder = &MockWorkflowsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWorkflows) EXPECT() *MockWorkflowsMockRecorder {
+	return m.recorder
+}
+
+// WorkflowsCreate mocks base method.
+func (m *MockWorkflows) WorkflowsCreate(ctx context.Context, workflow model.Workflow) (*model.Workflow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowsCreate", ctx, workflow)
+	ret0, _ := ret[0].(*model.Workflow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowsCreate indicates an expected call of WorkflowsCreate.
+func (mr *MockWorkflowsMockRecorder) WorkflowsCreate(ctx, workflow interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowsCreate", reflect.TypeOf((*MockWorkflows)(nil).WorkflowsCreate), ctx, workflow)
+}
+
+// WorkflowsGet mocks base method.
+func (m *MockWorkflows) WorkflowsGet(ctx context.Context, workflowID string) (*model.Workflow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowsGet", ctx, workflowID)
+	ret0, _ := ret[0].(*model.Workflow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WorkflowsGet indicates an expected call of WorkflowsGet.
+func (mr *MockWorkflowsMockRecorder) WorkflowsGet(ctx, workflowID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowsGet", reflect.TypeOf((*MockWorkflows)(nil).WorkflowsGet), ctx, workflowID)
+}
+
+// WorkflowsResume mocks base method.
+func (m *MockWorkflows) WorkflowsResume(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WorkflowsResume", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WorkflowsResume indicates an expected call of WorkflowsResume.
+func (mr *MockWorkflowsMockRecorder) WorkflowsResume(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkflowsResume", reflect.TypeOf((*MockWorkflows)(nil).WorkflowsResume), ctx)
+}
@@ -0,0 +1,307 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/axxonsoft-assignment/pkg/jsonpath"
+	"github.com/axxonsoft-assignment/pkg/model"
+)
+
+// paginate makes the call of the task for each of its pages, giving the pages as a JSON array once there is no next
+// page or a limit is reached. The status code and size of each page are recorded with the task, which fails with
+// the first page that does not end up done. The pages are read in memory, the collected array going to the blob store
+// once complete if it is larger than the blob threshold.
+func (t tasks) paginate(ctx context.Context, taskDetails model.Task, taskObj *model.TasksObject) []byte {
+	maxPages, maxBytes := taskDetails.Paginate.Limits()
+
+	var (
+		pages []json.RawMessage
+		total int64
+	)
+
+	pagination := &model.Pagination{Pages: []model.PageResult{}}
+	pageTask := taskDetails
+
+	for {
+		taskObj.HTTPStatusCode = nil
+		taskObj.Redirects = nil
+		taskObj.Error = nil
+
+		body := t.dispatch(ctx, pageTask, taskObj, false)
+
+		// the call failed before getting a response, the task already failed with the reason
+		if taskObj.HTTPStatusCode == nil {
+			taskObj.Pagination = pagination
+			_ = t.cache.StoreTask(ctx, taskObj.ID, taskObj)
+
+			return nil
+		}
+
+		pagination.Pages = append(pagination.Pages, model.PageResult{HTTPStatusCode: *taskObj.HTTPStatusCode, Bytes: len(body)})
+		pagination.PageCount = len(pagination.Pages)
+		taskObj.Pagination = pagination
+
+		if taskObj.Status != model.Done {
+			_ = t.cache.StoreTask(ctx, taskObj.ID, taskObj)
+
+			return nil
+		}
+
+		pages = append(pages, pageJSON(body))
+		total += int64(len(body))
+
+		current := pageTask.URL
+		if pageTask.PageURL != "" {
+			current = pageTask.PageURL
+		}
+
+		next, fromServer, err := nextPage(taskDetails.Paginate, current, taskObj.Headers, body)
+		if err != nil {
+			t.failTask(ctx, taskObj, model.ErrCodePaginationFailed,
+				errors.New("page "+strconv.Itoa(pagination.PageCount)+": "+err.Error()))
+
+			return nil
+		}
+
+		if next == "" {
+			break
+		}
+
+		if len(pages) >= maxPages || total >= maxBytes {
+			pagination.Truncated = true
+
+			break
+		}
+
+		// the task is in process till the last page
+		taskObj.Status = model.InProcess
+		_ = t.cache.StoreTask(ctx, taskObj.ID, taskObj)
+
+		// a url given by the server is used as is, the one built from the task's own url is templated as the task's
+		if fromServer {
+			pageTask.PageURL = next
+		} else {
+			pageTask.URL = next
+		}
+	}
+
+	collected, _ := json.Marshal(pages)
+
+	if t.settings.Blobs != nil && int64(len(collected)) > t.settings.BlobThreshold {
+		if err := t.storeBlob(ctx, taskObj, bytes.NewReader(collected), "application/json"); err != nil {
+			t.failTask(ctx, taskObj, model.ErrCodeReadFailed, err)
+
+			return nil
+		}
+	}
+
+	taskObj.Status = model.Done
+	_ = t.cache.StoreTask(ctx, taskObj.ID, taskObj)
+
+	return collected
+}
+
+// pageJSON gives the page as an element of the collected JSON array, a page that is not JSON as a string
+func pageJSON(body []byte) json.RawMessage {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return json.RawMessage("null")
+	}
+
+	if json.Valid(body) {
+		return body
+	}
+
+	encoded, _ := json.Marshal(string(body))
+
+	return encoded
+}
+
+// nextPage gives the url of the page after the current one, empty if it was the last one. fromServer tells whether
+// the url was given by the server, as a link or as a cursor, rather than built from the current url.
+func nextPage(paginate *model.Paginate, current string, header http.Header, body []byte) (next string, fromServer bool,
+	err error) {
+	if paginate.From == model.PaginateFromLink {
+		link := nextLink(header)
+		if link == "" {
+			return "", false, nil
+		}
+
+		next, err = serverPageURL(current, link)
+
+		return next, true, err
+	}
+
+	var doc interface{}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	if err := decoder.Decode(&doc); err != nil {
+		return "", false, errors.New("response body is not JSON")
+	}
+
+	value, err := jsonpath.Get(doc, paginate.Path)
+	if errors.Is(err, jsonpath.ErrNotFound) {
+		return "", false, nil
+	}
+
+	var cursor string
+
+	switch v := value.(type) {
+	case nil:
+		return "", false, nil
+	case string:
+		cursor = v
+	case json.Number:
+		cursor = v.String()
+	default:
+		return "", false, errors.New("cursor at path " + paginate.Path + " should be a string or a number")
+	}
+
+	if cursor == "" {
+		return "", false, nil
+	}
+
+	if paginate.Param == "" {
+		next, err = serverPageURL(current, cursor)
+
+		return next, true, err
+	}
+
+	return withQueryParam(current, paginate.Param, cursor), false, nil
+}
+
+// nextLink gives the url of the rel="next" link of the Link header, empty if there is none
+func nextLink(header http.Header) string {
+	for _, value := range header.Values("Link") {
+		for _, link := range strings.Split(value, ",") {
+			parts := strings.Split(link, ";")
+
+			target := strings.TrimSpace(parts[0])
+			if !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+				continue
+			}
+
+			for _, param := range parts[1:] {
+				key, rels, ok := strings.Cut(strings.TrimSpace(param), "=")
+				if !ok || !strings.EqualFold(strings.TrimSpace(key), "rel") {
+					continue
+				}
+
+				for _, rel := range strings.Fields(strings.Trim(strings.TrimSpace(rels), `"`)) {
+					if strings.EqualFold(rel, "next") {
+						return target[1 : len(target)-1]
+					}
+				}
+			}
+		}
+	}
+
+	return ""
+}
+
+// serverPageURL resolves the url of the next page given by the server against the url of the current one. As the
+// task's credentials go along with it, it has to be on the same origin and it cannot reference secrets.
+func serverPageURL(current string, next string) (string, error) {
+	if strings.Contains(next, "${secret:") {
+		return "", errors.New("next page url cannot reference secrets")
+	}
+
+	resolved, err := resolveURL(current, next)
+	if err != nil {
+		return "", err
+	}
+
+	if strings.Contains(resolved, "${secret:") {
+		return "", errors.New("next page url cannot reference secrets")
+	}
+
+	if !sameOrigin(current, resolved) {
+		return "", errors.New("next page url should be on the same origin as the task's url")
+	}
+
+	return resolved, nil
+}
+
+// sameOrigin tells whether both urls have the same scheme, host and port
+func sameOrigin(a string, b string) bool {
+	first, err := url.Parse(a)
+	if err != nil {
+		return false
+	}
+
+	second, err := url.Parse(b)
+	if err != nil {
+		return false
+	}
+
+	return strings.EqualFold(first.Scheme, second.Scheme) &&
+		strings.EqualFold(first.Hostname(), second.Hostname()) &&
+		originPort(first) == originPort(second)
+}
+
+// originPort gives the port of the url, the default one of its scheme if it has none
+func originPort(u *url.URL) string {
+	if port := u.Port(); port != "" {
+		return port
+	}
+
+	switch strings.ToLower(u.Scheme) {
+	case "http":
+		return "80"
+	case "https":
+		return "443"
+	}
+
+	return ""
+}
+
+// resolveURL resolves the url of the next page against the url of the current one
+func resolveURL(current string, next string) (string, error) {
+	base, err := url.Parse(current)
+	if err != nil {
+		return "", err
+	}
+
+	ref, err := url.Parse(next)
+	if err != nil {
+		return "", fmt.Errorf("invalid next page url: %w", err)
+	}
+
+	return base.ResolveReference(ref).String(), nil
+}
+
+// withQueryParam sets the query parameter of the url, the rest of the query is kept as is so that its secret
+// references are not escaped
+func withQueryParam(rawURL string, name string, value string) string {
+	base, fragment, _ := strings.Cut(rawURL, "#")
+	path, query, _ := strings.Cut(base, "?")
+
+	params := []string{}
+
+	for _, param := range strings.Split(query, "&") {
+		key, _, _ := strings.Cut(param, "=")
+		if param == "" || key == url.QueryEscape(name) {
+			continue
+		}
+
+		params = append(params, param)
+	}
+
+	params = append(params, url.QueryEscape(name)+"="+url.QueryEscape(value))
+
+	rawURL = path + "?" + strings.Join(params, "&")
+	if fragment != "" {
+		rawURL += "#" + fragment
+	}
+
+	return rawURL
+}
@@ -0,0 +1,224 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/axxonsoft-assignment/pkg/breaker"
+	"github.com/axxonsoft-assignment/pkg/cache"
+	"github.com/axxonsoft-assignment/pkg/model"
+	"github.com/axxonsoft-assignment/pkg/secrets"
+	"github.com/axxonsoft-assignment/pkg/ssrf"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTasks_paginate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path + "?" + r.URL.RawQuery {
+		case "/items?":
+			w.Header().Set("Link", `</items?page=2>; rel="next", </items?page=3>; rel="last"`)
+			_, _ = w.Write([]byte(`[1, 2]`))
+		case "/items?page=2":
+			w.Header().Set("Link", `</items?page=3>; rel="next"`)
+			_, _ = w.Write([]byte(`[3, 4]`))
+		case "/items?page=3":
+			_, _ = w.Write([]byte(`[5]`))
+		case "/cursor?limit=2":
+			_, _ = w.Write([]byte(`{"items": [1, 2], "next": 1234567890123}`))
+		case "/cursor?limit=2&after=1234567890123":
+			_, _ = w.Write([]byte(`{"items": [3], "next": null}`))
+		case "/broken?":
+			w.Header().Set("Link", `</broken?page=2>; rel="next"`)
+			_, _ = w.Write([]byte(`[1]`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	cacheMock := cache.NewMockCache(ctrl)
+	cacheMock.EXPECT().GetRateLimit(gomock.Any(), gomock.Any()).Return(&model.RateLimit{}, nil).AnyTimes()
+	cacheMock.EXPECT().StoreTask(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	guard, _ := ssrf.New([]string{"127.0.0.0/8"})
+	task := New(cacheMock, breaker.New(breaker.Settings{}), Settings{Guard: guard})
+
+	tcs := []struct {
+		description   string
+		path          string
+		paginate      model.Paginate
+		expStatus     string
+		expBody       string
+		expPagination *model.Pagination
+	}{
+		{
+			description: "Positive case: Link rel=next followed till the last page",
+			path:        "/items",
+			paginate:    model.Paginate{From: model.PaginateFromLink},
+			expStatus:   model.Done,
+			expBody:     `[[1,2],[3,4],[5]]`,
+			expPagination: &model.Pagination{PageCount: 3, Pages: []model.PageResult{
+				{HTTPStatusCode: http.StatusOK, Bytes: 6}, {HTTPStatusCode: http.StatusOK, Bytes: 6}, {HTTPStatusCode: http.StatusOK, Bytes: 3},
+			}},
+		},
+		{
+			description: "Positive case: cursor set as a query parameter",
+			path:        "/cursor?limit=2",
+			paginate:    model.Paginate{From: model.PaginateFromCursor, Path: "$.next", Param: "after"},
+			expStatus:   model.Done,
+			expBody:     `[{"items":[1,2],"next":1234567890123},{"items":[3],"next":null}]`,
+			expPagination: &model.Pagination{PageCount: 2, Pages: []model.PageResult{
+				{HTTPStatusCode: http.StatusOK, Bytes: 40}, {HTTPStatusCode: http.StatusOK, Bytes: 28},
+			}},
+		},
+		{
+			description: "Positive case: page limit reached",
+			path:        "/items",
+			paginate:    model.Paginate{From: model.PaginateFromLink, MaxPages: 2},
+			expStatus:   model.Done,
+			expBody:     `[[1,2],[3,4]]`,
+			expPagination: &model.Pagination{PageCount: 2, Truncated: true, Pages: []model.PageResult{
+				{HTTPStatusCode: http.StatusOK, Bytes: 6}, {HTTPStatusCode: http.StatusOK, Bytes: 6},
+			}},
+		},
+		{
+			description: "Negative case: a failed page fails the task",
+			path:        "/broken",
+			paginate:    model.Paginate{From: model.PaginateFromLink},
+			expStatus:   model.Error,
+			expPagination: &model.Pagination{PageCount: 2, Pages: []model.PageResult{
+				{HTTPStatusCode: http.StatusOK, Bytes: 3}, {HTTPStatusCode: http.StatusInternalServerError, Bytes: 0},
+			}},
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			paginate := tc.paginate
+
+			taskObj, body, err := task.TasksRun(context.TODO(), model.Task{Method: "GET", URL: server.URL + tc.path, Paginate: &paginate})
+			assert.Nil(t, err)
+
+			assert.Equal(t, tc.expStatus, taskObj.Status)
+			assert.Equal(t, tc.expPagination, taskObj.Pagination)
+
+			if tc.expBody != "" {
+				assert.JSONEq(t, tc.expBody, string(body))
+			} else {
+				assert.Nil(t, body)
+			}
+		})
+	}
+}
+
+func TestTasks_paginate_serverURL(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Setenv("TEST_SECRET_X", "s3cr3t")
+	t.Setenv("TEST_SECRET_TOKEN", "t0k3n")
+
+	var received []*http.Request
+
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = append(received, r)
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer other.Close()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/cross":
+			w.Header().Set("Link", "<"+other.URL+"/items?key=${secret:x}>; rel=\"next\"")
+		case "/secret":
+			w.Header().Set("Link", `</items?key=${secret:x}>; rel="next"`)
+		case "/cursor":
+			_, _ = w.Write([]byte(`{"next": "` + other.URL + `/items"}`))
+
+			return
+		}
+
+		_, _ = w.Write([]byte(`[1]`))
+	}))
+	defer server.Close()
+
+	cacheMock := cache.NewMockCache(ctrl)
+	cacheMock.EXPECT().GetRateLimit(gomock.Any(), gomock.Any()).Return(&model.RateLimit{}, nil).AnyTimes()
+	cacheMock.EXPECT().StoreTask(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	guard, _ := ssrf.New([]string{"127.0.0.0/8"})
+	task := New(cacheMock, breaker.New(breaker.Settings{}), Settings{Guard: guard, Secrets: secrets.NewEnv("TEST_SECRET_")})
+
+	tcs := []struct {
+		description string
+		path        string
+		paginate    model.Paginate
+		expMessage  string
+	}{
+		{
+			description: "Negative case: Link rel=next on another origin",
+			path:        "/cross",
+			paginate:    model.Paginate{From: model.PaginateFromLink},
+			expMessage:  "page 1: next page url cannot reference secrets",
+		},
+		{
+			description: "Negative case: Link rel=next referencing a secret",
+			path:        "/secret",
+			paginate:    model.Paginate{From: model.PaginateFromLink},
+			expMessage:  "page 1: next page url cannot reference secrets",
+		},
+		{
+			description: "Negative case: cursor url on another origin",
+			path:        "/cursor",
+			paginate:    model.Paginate{From: model.PaginateFromCursor, Path: "$.next"},
+			expMessage:  "page 1: next page url should be on the same origin as the task's url",
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			paginate := tc.paginate
+
+			taskObj, body, err := task.TasksRun(context.TODO(), model.Task{
+				Method:   "GET",
+				URL:      server.URL + tc.path,
+				Auth:     &model.Auth{Type: model.AuthBearer, Token: "${secret:token}"},
+				Paginate: &paginate,
+			})
+			assert.Nil(t, err)
+			assert.Nil(t, body)
+
+			assert.Equal(t, model.Error, taskObj.Status)
+			assert.Equal(t, model.ErrCodePaginationFailed, taskObj.Error.Code)
+			assert.Equal(t, tc.expMessage, taskObj.Error.Message)
+		})
+	}
+
+	// neither the secret nor the task's credentials ever reach the other server
+	assert.Empty(t, received)
+}
+
+func TestTasks_nextLink(t *testing.T) {
+	header := http.Header{}
+	header.Add("Link", `<https://api.partner.com/items?page=1>; rel="prev"`)
+	header.Add("Link", `<https://api.partner.com/items?page=3>; rel="next last"`)
+
+	assert.Equal(t, "https://api.partner.com/items?page=3", nextLink(header))
+	assert.Equal(t, "", nextLink(http.Header{"Link": {`<https://api.partner.com/items?page=1>; rel="prev"`}}))
+}
+
+func TestTasks_withQueryParam(t *testing.T) {
+	assert.Equal(t, "https://api.partner.com/items?key=${secret:partner.key}&cursor=a%2Bb",
+		withQueryParam("https://api.partner.com/items?cursor=old&key=${secret:partner.key}", "cursor", "a+b"))
+	assert.Equal(t, "https://api.partner.com/items?cursor=abc#top", withQueryParam("https://api.partner.com/items#top", "cursor", "abc"))
+}
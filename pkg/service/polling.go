@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/axxonsoft-assignment/pkg/jsonpath"
+	"github.com/axxonsoft-assignment/pkg/model"
+)
+
+// poll makes the call of the task till its response meets the until condition, the task staying in the "polling"
+// status in between. Every attempt is recorded with the task, which ends up with the response of the last one.
+// A call failing before it gets a response stops the polling, unless the failure is transient.
+func (t tasks) poll(ctx context.Context, taskDetails model.Task, taskObj *model.TasksObject) []byte {
+	until := taskDetails.Until
+
+	interval, maxInterval, deadline, _ := until.Durations()
+	stopAt := time.Now().Add(deadline)
+
+	for {
+		taskObj.HTTPStatusCode = nil
+		taskObj.Headers = nil
+		taskObj.Length = nil
+		taskObj.WireLength = nil
+		taskObj.Redirects = nil
+		taskObj.Blob = nil
+		taskObj.Error = nil
+
+		body := t.dispatch(ctx, taskDetails, taskObj, true)
+
+		attempt := model.PollAttempt{At: time.Now(), HTTPStatusCode: taskObj.HTTPStatusCode, Error: taskObj.Error}
+		attempt.Matched = taskObj.Error == nil && untilMet(until, taskObj, body)
+
+		taskObj.Attempts = append(taskObj.Attempts, attempt)
+		if len(taskObj.Attempts) > model.MaxPollAttempts {
+			taskObj.Attempts = taskObj.Attempts[len(taskObj.Attempts)-model.MaxPollAttempts:]
+		}
+
+		switch {
+		case attempt.Matched:
+			taskObj.Status = model.Done
+			_ = t.cache.StoreTask(ctx, taskObj.ID, taskObj)
+
+			return body
+		case taskObj.Error != nil && taskObj.Error.Code != model.ErrCodeCallFailed && taskObj.Error.Code != model.ErrCodeCircuitOpen:
+			// the task already failed with the error of the attempt, stored along with the attempt
+			_ = t.cache.StoreTask(ctx, taskObj.ID, taskObj)
+
+			return nil
+		}
+
+		remaining := time.Until(stopAt)
+		if remaining <= 0 {
+			t.failTask(ctx, taskObj, model.ErrCodePollTimeout,
+				errors.New("condition not met after "+strconv.Itoa(len(taskObj.Attempts))+" attempts"))
+
+			return nil
+		}
+
+		taskObj.Status = model.Polling
+		_ = t.cache.StoreTask(ctx, taskObj.ID, taskObj)
+
+		// the last attempt is made right at the deadline
+		wait := interval
+		if wait > remaining {
+			wait = remaining
+		}
+
+		select {
+		case <-ctx.Done():
+			t.failTask(ctx, taskObj, model.ErrCodePollTimeout, ctx.Err())
+
+			return nil
+		case <-time.After(wait):
+		}
+
+		if until.Backoff > 1 {
+			interval = time.Duration(float64(interval) * until.Backoff)
+		}
+
+		if maxInterval > 0 && interval > maxInterval {
+			interval = maxInterval
+		}
+	}
+}
+
+// untilMet tells if the response has one of the status codes of the condition, and if the condition has a path
+// whether the body has a value there equal to the expected one.
+func untilMet(until *model.Until, taskObj *model.TasksObject, body []byte) bool {
+	if taskObj.HTTPStatusCode == nil {
+		return false
+	}
+
+	if len(until.Status) > 0 {
+		matched := false
+
+		for _, status := range until.Status {
+			matched = matched || status == *taskObj.HTTPStatusCode
+		}
+
+		if !matched {
+			return false
+		}
+	}
+
+	if until.Path == "" {
+		return true
+	}
+
+	// the numbers of both the body and the expected value are decoded as float64, so that they compare equal
+	var doc interface{}
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return false
+	}
+
+	value, err := jsonpath.Get(doc, until.Path)
+	if err != nil {
+		return false
+	}
+
+	return until.Equals == nil || reflect.DeepEqual(value, until.Equals)
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/axxonsoft-assignment/pkg/breaker"
+	"github.com/axxonsoft-assignment/pkg/cache"
+	"github.com/axxonsoft-assignment/pkg/model"
+	"github.com/axxonsoft-assignment/pkg/ssrf"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTasks_poll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var calls int32
+
+	// the job is done on the third poll
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusAccepted)
+			_, _ = w.Write([]byte(`{"state": "running"}`))
+
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"state": "done", "count": 3}`))
+	}))
+	defer server.Close()
+
+	cacheMock := cache.NewMockCache(ctrl)
+	cacheMock.EXPECT().GetRateLimit(gomock.Any(), gomock.Any()).Return(&model.RateLimit{}, nil).AnyTimes()
+	cacheMock.EXPECT().StoreTask(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	guard, _ := ssrf.New([]string{"127.0.0.0/8"})
+	task := New(cacheMock, breaker.New(breaker.Settings{}), Settings{Guard: guard}).(*tasks)
+	blocking := New(cacheMock, breaker.New(breaker.Settings{}), Settings{}).(*tasks)
+
+	tcs := []struct {
+		description string
+		task        *tasks
+		until       model.Until
+		expStatus   string
+		expAttempts int
+		expErr      *model.TaskError
+		expBody     string
+	}{
+		{
+			description: "Positive case: polled till the value at the path is the expected one",
+			task:        task,
+			until:       model.Until{Path: "$.state", Equals: "done", Interval: "10ms", Backoff: 2},
+			expStatus:   model.Done,
+			expAttempts: 3,
+			expBody:     `{"state": "done", "count": 3}`,
+		},
+		{
+			description: "Positive case: numbers compare equal",
+			task:        task,
+			until:       model.Until{Status: []int{http.StatusOK}, Path: "$.count", Equals: float64(3), Interval: "10ms"},
+			expStatus:   model.Done,
+			expAttempts: 3,
+			expBody:     `{"state": "done", "count": 3}`,
+		},
+		{
+			description: "Negative case: deadline passed",
+			task:        task,
+			until:       model.Until{Status: []int{http.StatusNoContent}, Interval: "20ms", Deadline: "50ms"},
+			expStatus:   model.Error,
+			expErr:      &model.TaskError{Code: model.ErrCodePollTimeout},
+		},
+		{
+			description: "Negative case: a blocked destination stops the polling",
+			task:        blocking,
+			until:       model.Until{Status: []int{http.StatusOK}, Interval: "10ms"},
+			expStatus:   model.Error,
+			expAttempts: 1,
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			atomic.StoreInt32(&calls, 0)
+
+			// the task polls faster than a request may ask for
+			taskDetails := model.Task{Method: "GET", URL: server.URL + "/jobs/1", Until: &model.Until{Status: []int{http.StatusOK}}}
+
+			taskObj, err := tc.task.prepare(context.TODO(), taskDetails)
+			assert.Nil(t, err)
+
+			taskDetails.Until = &tc.until
+			body := tc.task.execute(context.TODO(), taskDetails, taskObj)
+
+			assert.Equal(t, tc.expStatus, taskObj.Status)
+
+			// the number of attempts made till the deadline depends on the timing of the calls
+			if tc.expAttempts > 0 {
+				assert.Len(t, taskObj.Attempts, tc.expAttempts)
+			}
+			assert.Equal(t, tc.expBody != "", taskObj.Attempts[len(taskObj.Attempts)-1].Matched)
+
+			if tc.expBody != "" {
+				assert.Equal(t, tc.expBody, string(body))
+				assert.Equal(t, http.StatusOK, *taskObj.HTTPStatusCode)
+			}
+
+			if tc.expErr != nil {
+				assert.Equal(t, tc.expErr.Code, taskObj.Error.Code)
+				assert.Equal(t, "condition not met after "+strconv.Itoa(len(taskObj.Attempts))+" attempts", taskObj.Error.Message)
+			}
+		})
+	}
+}
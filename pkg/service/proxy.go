@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptrace"
+	"net/netip"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/axxonsoft-assignment/pkg/model"
+)
+
+type proxyContextKey struct{}
+
+// withProxy makes the requests made with the context go through the given proxy instead of the global one
+func withProxy(ctx context.Context, proxyURL *url.URL) context.Context {
+	return context.WithValue(ctx, proxyContextKey{}, proxyURL)
+}
+
+// withTaskProxy makes the requests made with the context go through the task's own proxy, if it has one. The proxy is
+// validated with the request body.
+func withTaskProxy(ctx context.Context, taskDetails model.Task) context.Context {
+	if taskDetails.Proxy == "" {
+		return ctx
+	}
+
+	proxyURL, _ := model.ParseProxy(taskDetails.Proxy)
+
+	return withProxy(ctx, proxyURL)
+}
+
+// proxyFor picks the proxy of a request: none for the hosts matching NoProxy, otherwise the task's own proxy,
+// otherwise the global one.
+func (s Settings) proxyFor(req *http.Request) (*url.URL, error) {
+	if bypassProxy(s.NoProxy, req.URL.Hostname()) {
+		return nil, nil
+	}
+
+	if proxyURL, ok := req.Context().Value(proxyContextKey{}).(*url.URL); ok {
+		return proxyURL, nil
+	}
+
+	return s.Proxy, nil
+}
+
+// bypassProxy matches the host against the NO_PROXY patterns: "*", a host glob, a ".domain" suffix or a CIDR
+func bypassProxy(noProxy []string, host string) bool {
+	host = strings.ToLower(host)
+	addr, addrErr := netip.ParseAddr(host)
+
+	for _, pattern := range noProxy {
+		pattern = strings.ToLower(pattern)
+
+		if prefix, err := netip.ParsePrefix(pattern); err == nil {
+			if addrErr == nil && prefix.Contains(addr.Unmap()) {
+				return true
+			}
+
+			continue
+		}
+
+		if strings.HasPrefix(pattern, ".") && (strings.HasSuffix(host, pattern) || host == pattern[1:]) {
+			return true
+		}
+
+		if ok, _ := path.Match(pattern, host); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// proxyTrace tracks whether the connection to the target has been established through the proxy.
+// The returned func tells if an error of the call happened before that, i.e. at the proxy.
+func proxyTrace(ctx context.Context, proxyURL *url.URL) (context.Context, func(err error) bool) {
+	var (
+		established bool
+		handshakes  int
+	)
+
+	// the first handshake through a https proxy is made with the proxy itself
+	proxyHandshakes := 0
+	if proxyURL != nil && proxyURL.Scheme == "https" {
+		proxyHandshakes = 1
+	}
+
+	trace := &httptrace.ClientTrace{
+		GetConn: func(string) {
+			established, handshakes = false, 0
+		},
+		TLSHandshakeStart: func() {
+			handshakes++
+			established = established || handshakes > proxyHandshakes
+		},
+		GotConn: func(httptrace.GotConnInfo) {
+			established = true
+		},
+	}
+
+	isProxyError := func(err error) bool {
+		var opErr *net.OpError
+		if errors.As(err, &opErr) && opErr.Op == "proxyconnect" {
+			return true
+		}
+
+		return proxyURL != nil && !established
+	}
+
+	return httptrace.WithClientTrace(ctx, trace), isProxyError
+}
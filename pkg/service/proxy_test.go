@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/axxonsoft-assignment/pkg/breaker"
+	"github.com/axxonsoft-assignment/pkg/model"
+	"github.com/axxonsoft-assignment/pkg/ssrf"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProxy_bypassProxy(t *testing.T) {
+	noProxy := []string{".internal.corp", "*.svc.local", "10.0.0.0/8", "partner.com"}
+
+	for host, bypass := range map[string]bool{
+		"internal.corp":        true,
+		"api.internal.corp":    true,
+		"orders.svc.local":     true,
+		"10.1.2.3":             true,
+		"PARTNER.com":          true,
+		"api.partner.com":      false,
+		"11.1.2.3":             false,
+		"notinternal.corp.com": false,
+	} {
+		assert.Equal(t, bypass, bypassProxy(noProxy, host), host)
+	}
+
+	assert.True(t, bypassProxy([]string{"*"}, "anything.com"))
+}
+
+func TestSettings_proxyFor(t *testing.T) {
+	global, _ := url.Parse("http://proxy.corp:3128")
+	own, _ := url.Parse("socks5://proxy.partner.com:1080")
+
+	settings := Settings{Proxy: global, NoProxy: []string{".internal.corp"}}
+
+	req := httptest.NewRequest(http.MethodGet, "https://api.partner.com/orders", nil)
+
+	proxyURL, err := settings.proxyFor(req)
+	assert.Nil(t, err)
+	assert.Equal(t, global, proxyURL)
+
+	proxyURL, _ = settings.proxyFor(req.WithContext(withProxy(req.Context(), own)))
+	assert.Equal(t, own, proxyURL)
+
+	// NO_PROXY wins over the task's own proxy
+	req = httptest.NewRequest(http.MethodGet, "https://api.internal.corp/orders", nil)
+
+	proxyURL, _ = settings.proxyFor(req.WithContext(withProxy(req.Context(), own)))
+	assert.Nil(t, proxyURL)
+}
+
+func TestProxy_proxyTrace(t *testing.T) {
+	forwardProxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodConnect {
+			w.WriteHeader(http.StatusProxyAuthRequired)
+
+			return
+		}
+
+		_, _ = io.WriteString(w, "proxied "+r.URL.String())
+	}))
+	defer forwardProxy.Close()
+
+	// a closed port, so that the proxy cannot be dialled
+	listener, _ := net.Listen("tcp", "127.0.0.1:0")
+	deadProxy := "http://" + listener.Addr().String()
+	listener.Close()
+
+	guard, _ := ssrf.New([]string{"127.0.0.0/8"})
+	task := New(nil, breaker.New(breaker.Settings{}), Settings{Guard: guard}).(*tasks)
+
+	tcs := []struct {
+		description string
+		proxy       string
+		url         string
+		expBody     string
+		expProxyErr bool
+	}{
+		{
+			description: "Positive case: plain http call through the proxy",
+			proxy:       forwardProxy.URL,
+			url:         "http://api.partner.com/orders",
+			expBody:     "proxied http://api.partner.com/orders",
+		},
+		{
+			description: "Negative case: proxy refuses the tunnel",
+			proxy:       forwardProxy.URL,
+			url:         "https://api.partner.com/orders",
+			expProxyErr: true,
+		},
+		{
+			description: "Negative case: proxy is down",
+			proxy:       deadProxy,
+			url:         "http://api.partner.com/orders",
+			expProxyErr: true,
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			proxyURL, _ := model.ParseProxy(tc.proxy)
+
+			ctx, isProxyError := proxyTrace(withProxy(context.Background(), proxyURL), proxyURL)
+			req, _ := http.NewRequestWithContext(ctx, http.MethodGet, tc.url, nil)
+
+			resp, err := task.client.Do(req)
+
+			assert.Equal(t, tc.expProxyErr, err != nil && isProxyError(err))
+
+			if !tc.expProxyErr {
+				defer resp.Body.Close()
+
+				body, _ := io.ReadAll(resp.Body)
+				assert.Equal(t, tc.expBody, string(body))
+			}
+		})
+	}
+}
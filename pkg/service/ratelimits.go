@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/axxonsoft-assignment/pkg/cache"
+	"github.com/axxonsoft-assignment/pkg/model"
+)
+
+var errRateLimitNotFound = notFoundError("Rate limit not found")
+
+type rateLimits struct {
+	cache cache.Cache
+}
+
+func NewRateLimits(cache cache.Cache) RateLimits {
+	return &rateLimits{cache: cache}
+}
+
+// RateLimitsSet creates or replaces the rate limit of a host, it is applied to the tasks dispatched from then on.
+func (r rateLimits) RateLimitsSet(ctx context.Context, host string, limit model.RateLimit) (*model.RateLimit, error) {
+	limit.Host = strings.ToLower(host)
+
+	if err := model.ValidateRateLimit(limit); err != nil {
+		return nil, validationError(err)
+	}
+
+	if err := r.cache.StoreRateLimit(ctx, &limit); err != nil {
+		return nil, unavailableError(err)
+	}
+
+	return &limit, nil
+}
+
+// RateLimitsGet gives the rate limit configured for a host.
+func (r rateLimits) RateLimitsGet(ctx context.Context, host string) (*model.RateLimit, error) {
+	limit, err := r.cache.GetRateLimit(ctx, strings.ToLower(host))
+	if err != nil {
+		return nil, unavailableError(err)
+	}
+
+	if limit.Host == "" {
+		return nil, errRateLimitNotFound
+	}
+
+	return limit, nil
+}
+
+// RateLimitsList gives the rate limits of all the hosts.
+func (r rateLimits) RateLimitsList(ctx context.Context) ([]*model.RateLimit, error) {
+	limits, err := r.cache.ListRateLimits(ctx)
+
+	return limits, unavailableError(err)
+}
+
+// RateLimitsDelete removes the rate limit of a host, its calls are then limited by the default limit if any.
+func (r rateLimits) RateLimitsDelete(ctx context.Context, host string) error {
+	if _, err := r.RateLimitsGet(ctx, host); err != nil {
+		return err
+	}
+
+	return unavailableError(r.cache.DeleteRateLimit(ctx, strings.ToLower(host)))
+}
+
+// waitForRateLimit blocks till the host's bucket has a token, the task is marked throttled while it waits.
+// The host's own limit is applied if present, otherwise the default limit, otherwise the call is not limited.
+func (t tasks) waitForRateLimit(ctx context.Context, host string, taskObj *model.TasksObject) error {
+	host = strings.ToLower(host)
+
+	limit, err := t.cache.GetRateLimit(ctx, host)
+	if err != nil {
+		return err
+	}
+
+	if limit.Host == "" {
+		limit, err = t.cache.GetRateLimit(ctx, model.DefaultRateLimitHost)
+		if err != nil {
+			return err
+		}
+
+		if limit.Host == "" {
+			return nil
+		}
+	}
+
+	for {
+		wait, err := t.cache.TakeToken(ctx, host, limit, time.Now())
+		if err != nil {
+			return err
+		}
+
+		if wait == 0 {
+			return nil
+		}
+
+		if taskObj.Status != model.Throttled {
+			taskObj.Status = model.Throttled
+			if err = t.cache.StoreTask(ctx, taskObj.ID, taskObj); err != nil {
+				return err
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
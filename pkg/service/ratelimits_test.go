@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/axxonsoft-assignment/pkg/cache"
+	"github.com/axxonsoft-assignment/pkg/model"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimits_RateLimitsSet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cacheMock := cache.NewMockCache(ctrl)
+
+	tcs := []struct {
+		description string
+		host        string
+		req         model.RateLimit
+		resp        *model.RateLimit
+		mockCalls   []*gomock.Call
+		expErr      error
+	}{
+		{
+			description: "Positive case: valid rate limit",
+			host:        "API.Partner.com",
+			req:         model.RateLimit{Rate: 5, Burst: 10},
+			mockCalls: []*gomock.Call{
+				cacheMock.EXPECT().StoreRateLimit(gomock.Any(), &model.RateLimit{Host: "api.partner.com", Rate: 5, Burst: 10}).Return(nil),
+			},
+			resp: &model.RateLimit{Host: "api.partner.com", Rate: 5, Burst: 10},
+		},
+		{
+			description: "Negative case: invalid rate",
+			host:        "api.partner.com",
+			req:         model.RateLimit{Burst: 10},
+			expErr:      invalidRequest("/rate", model.RuleRange, "Invalid request: rate should be a positive number of requests per second"),
+		},
+		{
+			description: "Negative case: invalid burst",
+			host:        "api.partner.com",
+			req:         model.RateLimit{Rate: 5},
+			expErr:      invalidRequest("/burst", model.RuleRange, "Invalid request: burst should be at least 1"),
+		},
+	}
+
+	rateLimits := NewRateLimits(cacheMock)
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			resp, err := rateLimits.RateLimitsSet(context.TODO(), tc.host, tc.req)
+
+			assert.Equal(t, tc.expErr, err)
+			assert.Equal(t, tc.resp, resp)
+		})
+	}
+}
+
+func TestRateLimits_RateLimitsGet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cacheMock := cache.NewMockCache(ctrl)
+	cacheMock.EXPECT().GetRateLimit(gomock.Any(), "api.partner.com").Return(&model.RateLimit{}, nil)
+
+	resp, err := NewRateLimits(cacheMock).RateLimitsGet(context.TODO(), "api.partner.com")
+
+	assert.Equal(t, errRateLimitNotFound, err)
+	assert.Nil(t, resp)
+}
+
+func TestTasks_waitForRateLimit(t *testing.T) {
+	limit := &model.RateLimit{Host: model.DefaultRateLimitHost, Rate: 100, Burst: 1}
+
+	tcs := []struct {
+		description string
+		mockCalls   func(cacheMock *cache.MockCache)
+		expStatus   string
+		expErr      error
+	}{
+		{
+			description: "Positive case: host is not limited",
+			mockCalls: func(cacheMock *cache.MockCache) {
+				cacheMock.EXPECT().GetRateLimit(gomock.Any(), "api.partner.com").Return(&model.RateLimit{}, nil)
+				cacheMock.EXPECT().GetRateLimit(gomock.Any(), model.DefaultRateLimitHost).Return(&model.RateLimit{}, nil)
+			},
+			expStatus: model.New,
+		},
+		{
+			description: "Positive case: task is throttled till a token is available as per the default limit",
+			mockCalls: func(cacheMock *cache.MockCache) {
+				cacheMock.EXPECT().GetRateLimit(gomock.Any(), "api.partner.com").Return(&model.RateLimit{}, nil)
+				cacheMock.EXPECT().GetRateLimit(gomock.Any(), model.DefaultRateLimitHost).Return(limit, nil)
+				gomock.InOrder(
+					cacheMock.EXPECT().TakeToken(gomock.Any(), "api.partner.com", limit, gomock.Any()).Return(10*time.Millisecond, nil),
+					cacheMock.EXPECT().StoreTask(gomock.Any(), "2313", gomock.Any()).Return(nil),
+					cacheMock.EXPECT().TakeToken(gomock.Any(), "api.partner.com", limit, gomock.Any()).Return(time.Duration(0), nil),
+				)
+			},
+			expStatus: model.Throttled,
+		},
+		{
+			description: "Negative case: error from cache",
+			mockCalls: func(cacheMock *cache.MockCache) {
+				cacheMock.EXPECT().GetRateLimit(gomock.Any(), "api.partner.com").Return(nil, errors.New("DB error"))
+			},
+			expStatus: model.New,
+			expErr:    errors.New("DB error"),
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			cacheMock := cache.NewMockCache(ctrl)
+			tc.mockCalls(cacheMock)
+
+			taskObj := &model.TasksObject{ID: "2313", Status: model.New}
+
+			err := tasks{cache: cacheMock}.waitForRateLimit(context.TODO(), "API.partner.com", taskObj)
+
+			assert.Equal(t, tc.expErr, err)
+			assert.Equal(t, tc.expStatus, taskObj.Status)
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package service
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/axxonsoft-assignment/pkg/model"
+	"github.com/axxonsoft-assignment/pkg/secrets"
+)
+
+// checkRedirect builds the redirect policy of a task. Every followed hop is recorded on the task, with its secrets redacted, and checked
+// against the SSRF guard and the destination policy; a hop the task does not follow ends the call with the redirect response.
+func (t tasks) checkRedirect(taskDetails model.Task, taskObj *model.TasksObject,
+	resolver *secrets.Resolver) func(req *http.Request, via []*http.Request) error {
+	maxRedirects := model.DefaultMaxRedirects
+	if taskDetails.MaxRedirects != nil {
+		maxRedirects = *taskDetails.MaxRedirects
+	}
+
+	return func(req *http.Request, via []*http.Request) error {
+		if taskDetails.FollowRedirects == model.RedirectsNone {
+			return http.ErrUseLastResponse
+		}
+
+		if taskDetails.FollowRedirects == model.RedirectsSameHost &&
+			!strings.EqualFold(req.URL.Hostname(), via[0].URL.Hostname()) {
+			return http.ErrUseLastResponse
+		}
+
+		if len(via) > maxRedirects {
+			return errors.New("stopped after " + strconv.Itoa(maxRedirects) + " redirects")
+		}
+
+		if err := t.settings.Guard.CheckRedirect(req, via); err != nil {
+			return err
+		}
+
+		if err := t.settings.Policy.Evaluate(req.Context(), req.Method, req.URL.String()); err != nil {
+			return err
+		}
+
+		if proxyURL, _ := t.settings.proxyFor(req); proxyURL != nil {
+			if err := t.settings.Guard.CheckHost(req.Context(), req.URL.Hostname()); err != nil {
+				return err
+			}
+		}
+
+		taskObj.Redirects = append(taskObj.Redirects, model.Redirect{
+			URL:        resolver.Redact(via[len(via)-1].URL.String()),
+			StatusCode: req.Response.StatusCode,
+		})
+
+		return nil
+	}
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/axxonsoft-assignment/pkg/breaker"
+	"github.com/axxonsoft-assignment/pkg/model"
+	"github.com/axxonsoft-assignment/pkg/policy"
+	"github.com/axxonsoft-assignment/pkg/secrets"
+	"github.com/axxonsoft-assignment/pkg/ssrf"
+	"github.com/stretchr/testify/assert"
+)
+
+func intValue(i int) *int {
+	return &i
+}
+
+func TestTasks_checkRedirect(t *testing.T) {
+	var server *httptest.Server
+
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, port, _ := net.SplitHostPort(server.Listener.Addr().String())
+
+		switch r.URL.Path {
+		case "/start":
+			http.Redirect(w, r, "/middle", http.StatusFound)
+		case "/middle":
+			http.Redirect(w, r, "/end", http.StatusMovedPermanently)
+		case "/cross":
+			http.Redirect(w, r, "http://localhost:"+port+"/end", http.StatusFound)
+		case "/to-admin":
+			http.Redirect(w, r, "/admin", http.StatusFound)
+		case "/hops":
+			// redirects as many times as the n query parameter says
+			n, _ := strconv.Atoi(r.URL.Query().Get("n"))
+			if n > 0 {
+				http.Redirect(w, r, "/hops?n="+strconv.Itoa(n-1), http.StatusFound)
+			}
+		}
+	}))
+	defer server.Close()
+
+	file := filepath.Join(t.TempDir(), "policy.json")
+	assert.Nil(t, os.WriteFile(file, []byte(`{"rules": [{"name": "no-admin", "action": "deny", "pathPrefixes": ["/admin"]}]}`), 0o600))
+
+	engine, _ := policy.New(file, 0)
+	guard, _ := ssrf.New([]string{"127.0.0.0/8", "localhost"})
+	task := New(nil, breaker.New(breaker.Settings{}), Settings{Guard: guard, Policy: engine}).(*tasks)
+
+	tcs := []struct {
+		description  string
+		path         string
+		taskDetails  model.Task
+		expStatus    int
+		expRedirects []model.Redirect
+		expHops      int
+		expErr       string
+	}{
+		{
+			description: "Positive case: any redirect is followed by default",
+			path:        "/start",
+			expStatus:   http.StatusOK,
+			expRedirects: []model.Redirect{
+				{URL: server.URL + "/start", StatusCode: http.StatusFound},
+				{URL: server.URL + "/middle", StatusCode: http.StatusMovedPermanently},
+			},
+		},
+		{
+			description: "Positive case: redirects are not followed",
+			path:        "/start",
+			taskDetails: model.Task{FollowRedirects: model.RedirectsNone},
+			expStatus:   http.StatusFound,
+		},
+		{
+			description: "Positive case: redirect to another host is not followed",
+			path:        "/cross",
+			taskDetails: model.Task{FollowRedirects: model.RedirectsSameHost},
+			expStatus:   http.StatusFound,
+		},
+		{
+			description: "Positive case: as many hops as the default maximum",
+			path:        "/hops?n=10",
+			expStatus:   http.StatusOK,
+			expHops:     10,
+		},
+		{
+			description: "Negative case: one hop more than the default maximum",
+			path:        "/hops?n=11",
+			expErr:      "stopped after 10 redirects",
+		},
+		{
+			description: "Negative case: too many hops",
+			path:        "/start",
+			taskDetails: model.Task{MaxRedirects: intValue(1)},
+			expErr:      "stopped after 1 redirects",
+		},
+		{
+			description: "Negative case: hop denied by the policy",
+			path:        "/to-admin",
+			expErr:      "destination denied by policy rule no-admin",
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			taskObj := &model.TasksObject{}
+
+			client := task.client
+			client.CheckRedirect = task.checkRedirect(tc.taskDetails, taskObj, secrets.NewResolver(nil))
+
+			resp, err := client.Get(server.URL + tc.path)
+
+			if tc.expErr != "" {
+				assert.ErrorContains(t, err, tc.expErr)
+
+				return
+			}
+
+			defer resp.Body.Close()
+
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expStatus, resp.StatusCode)
+			if tc.expHops > 0 {
+				assert.Len(t, taskObj.Redirects, tc.expHops)
+			} else {
+				assert.Equal(t, tc.expRedirects, taskObj.Redirects)
+			}
+		})
+	}
+}
@@ -0,0 +1,309 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/axxonsoft-assignment/pkg/cache"
+	"github.com/axxonsoft-assignment/pkg/model"
+	"github.com/google/uuid"
+)
+
+// maxCatchUpRuns caps the number of missed firings created in one go for a schedule with the catch_up policy
+const maxCatchUpRuns = 100
+
+var (
+	errScheduleNotFound = notFoundError("Schedule not found")
+	errSchedulePaused   = conflictError("Schedule is already paused")
+	errScheduleActive   = conflictError("Schedule is not paused")
+)
+
+type schedules struct {
+	cache cache.Cache
+	tasks Tasks
+}
+
+func NewSchedules(cache cache.Cache, tasks Tasks) Schedules {
+	return &schedules{cache: cache, tasks: tasks}
+}
+
+// SchedulesCreate validates the schedule, computes its first firing and stores it in the cache.
+func (s schedules) SchedulesCreate(ctx context.Context, schedule model.Schedule) (*model.Schedule, error) {
+	if err := s.validate(ctx, schedule); err != nil {
+		return nil, err
+	}
+
+	schedule.ID = uuid.New().String()
+	schedule.LastRunAt = nil
+
+	if schedule.MissedRunPolicy == "" {
+		schedule.MissedRunPolicy = model.MissedRunSkip
+	}
+
+	nextRunAt, err := schedule.Next(time.Now())
+	if err != nil {
+		return nil, validationError(err)
+	}
+
+	schedule.NextRunAt = &nextRunAt
+
+	if err = s.cache.StoreSchedule(ctx, &schedule); err != nil {
+		return nil, unavailableError(err)
+	}
+
+	return &schedule, nil
+}
+
+// validate checks the schedule, and its task as TasksCreate would, giving all of their violations at once.
+func (s schedules) validate(ctx context.Context, schedule model.Schedule) error {
+	var found []model.Violation
+
+	if err := model.ValidateSchedule(schedule); err != nil {
+		var validationErr *model.ValidationError
+		if !errors.As(err, &validationErr) {
+			return validationError(err)
+		}
+
+		found = append(found, validationErr.Violations...)
+	}
+
+	if err := s.tasks.TasksCheck(ctx, schedule.Task); err != nil {
+		var validationErr *model.ValidationError
+		if !errors.As(err, &validationErr) {
+			return err
+		}
+
+		// the violations of the task as such were already found by ValidateSchedule
+		for _, violation := range validationErr.Violations {
+			violation.Pointer = "/task" + violation.Pointer
+			if !containsViolation(found, violation) {
+				found = append(found, violation)
+			}
+		}
+	}
+
+	if len(found) > 0 {
+		return validationError(&model.ValidationError{Violations: found})
+	}
+
+	return nil
+}
+
+// SchedulesGet gives the schedule details given a scheduleID.
+func (s schedules) SchedulesGet(ctx context.Context, scheduleID string) (*model.Schedule, error) {
+	schedule, err := s.cache.GetSchedule(ctx, scheduleID)
+	if err != nil {
+		return nil, unavailableError(err)
+	}
+
+	if schedule.ID == "" {
+		return nil, errScheduleNotFound
+	}
+
+	return schedule, nil
+}
+
+// SchedulesList gives all the schedules.
+func (s schedules) SchedulesList(ctx context.Context) ([]*model.Schedule, error) {
+	allSchedules, err := s.cache.ListSchedules(ctx)
+
+	return allSchedules, unavailableError(err)
+}
+
+// SchedulesUpdate replaces the task template, cron expression and policies of an existing schedule.
+func (s schedules) SchedulesUpdate(ctx context.Context, scheduleID string, schedule model.Schedule) (*model.Schedule, error) {
+	if err := s.validate(ctx, schedule); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.SchedulesGet(ctx, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule.ID = existing.ID
+	schedule.LastRunAt = existing.LastRunAt
+
+	if schedule.MissedRunPolicy == "" {
+		schedule.MissedRunPolicy = model.MissedRunSkip
+	}
+
+	nextRunAt, err := schedule.Next(time.Now())
+	if err != nil {
+		return nil, validationError(err)
+	}
+
+	schedule.NextRunAt = &nextRunAt
+
+	if err = s.cache.StoreSchedule(ctx, &schedule); err != nil {
+		return nil, unavailableError(err)
+	}
+
+	return &schedule, nil
+}
+
+// SchedulesDelete removes the schedule, tasks already created by it are retained.
+func (s schedules) SchedulesDelete(ctx context.Context, scheduleID string) error {
+	if _, err := s.SchedulesGet(ctx, scheduleID); err != nil {
+		return err
+	}
+
+	return unavailableError(s.cache.DeleteSchedule(ctx, scheduleID))
+}
+
+// SchedulesPause stops the schedule from firing until it is resumed.
+func (s schedules) SchedulesPause(ctx context.Context, scheduleID string) (*model.Schedule, error) {
+	schedule, err := s.SchedulesGet(ctx, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	if schedule.Paused {
+		return nil, errSchedulePaused
+	}
+
+	schedule.Paused = true
+
+	if err = s.cache.StoreSchedule(ctx, schedule); err != nil {
+		return nil, unavailableError(err)
+	}
+
+	return schedule, nil
+}
+
+// SchedulesResume restarts a paused schedule, the firings that fell in the paused window are not run.
+func (s schedules) SchedulesResume(ctx context.Context, scheduleID string) (*model.Schedule, error) {
+	schedule, err := s.SchedulesGet(ctx, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !schedule.Paused {
+		return nil, errScheduleActive
+	}
+
+	nextRunAt, err := schedule.Next(time.Now())
+	if err != nil {
+		return nil, validationError(err)
+	}
+
+	schedule.Paused = false
+	schedule.NextRunAt = &nextRunAt
+
+	if err = s.cache.StoreSchedule(ctx, schedule); err != nil {
+		return nil, unavailableError(err)
+	}
+
+	return schedule, nil
+}
+
+// SchedulesHistory gives the runs of the schedule along with the tasks created by them, latest first.
+func (s schedules) SchedulesHistory(ctx context.Context, scheduleID string) ([]*model.ScheduleRun, error) {
+	if _, err := s.SchedulesGet(ctx, scheduleID); err != nil {
+		return nil, err
+	}
+
+	runs, err := s.cache.GetScheduleRuns(ctx, scheduleID)
+
+	return runs, unavailableError(err)
+}
+
+// SchedulesRunDue creates a task for every schedule whose firing time has passed.
+// Firings missed while the service was down are either dropped except the latest one (skip) or all run (catch_up).
+func (s schedules) SchedulesRunDue(ctx context.Context, now time.Time) error {
+	allSchedules, err := s.cache.ListSchedules(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, schedule := range allSchedules {
+		if schedule.Paused || schedule.NextRunAt == nil || schedule.NextRunAt.After(now) {
+			continue
+		}
+
+		if er := s.runSchedule(ctx, schedule, now); er != nil {
+			log.Printf("Error running schedule:%s: %v", schedule.ID, er)
+		}
+	}
+
+	return nil
+}
+
+func (s schedules) runSchedule(ctx context.Context, schedule *model.Schedule, now time.Time) error {
+	var dueRuns []time.Time
+
+	nextRunAt := *schedule.NextRunAt
+	for !nextRunAt.After(now) && len(dueRuns) < maxCatchUpRuns {
+		dueRuns = append(dueRuns, nextRunAt)
+
+		next, err := schedule.Next(nextRunAt)
+		if err != nil {
+			return err
+		}
+
+		nextRunAt = next
+	}
+
+	// too many firings were missed, continue from the current time
+	if !nextRunAt.After(now) {
+		next, err := schedule.Next(now)
+		if err != nil {
+			return err
+		}
+
+		nextRunAt = next
+	}
+
+	if schedule.MissedRunPolicy != model.MissedRunCatchUp {
+		dueRuns = dueRuns[len(dueRuns)-1:]
+	}
+
+	for _, scheduledAt := range dueRuns {
+		claimed, err := s.cache.ClaimScheduleRun(ctx, schedule.ID, scheduledAt)
+		if err != nil {
+			return err
+		}
+
+		// another instance has already created the task for this firing
+		if !claimed {
+			continue
+		}
+
+		task := schedule.Task
+		task.ScheduleID = schedule.ID
+
+		resp, err := s.tasks.TasksCreate(ctx, task)
+		if err != nil {
+			log.Printf("Error creating task for schedule:%s: %v", schedule.ID, err)
+
+			continue
+		}
+
+		run := &model.ScheduleRun{TaskID: resp.ID, ScheduledAt: scheduledAt, CreatedAt: now}
+		if err = s.cache.AddScheduleRun(ctx, schedule.ID, run); err != nil {
+			return err
+		}
+	}
+
+	// re-fetch the schedule so that an update, pause or delete made in between is not overwritten
+	latest, err := s.cache.GetSchedule(ctx, schedule.ID)
+	if err != nil {
+		return err
+	}
+
+	if latest.ID == "" || latest.Paused {
+		return nil
+	}
+
+	lastRunAt := dueRuns[len(dueRuns)-1]
+	latest.LastRunAt = &lastRunAt
+
+	// an update in between has already computed the next firing as per the new cron expression
+	if latest.NextRunAt != nil && latest.NextRunAt.Equal(*schedule.NextRunAt) {
+		latest.NextRunAt = &nextRunAt
+	}
+
+	return s.cache.StoreSchedule(ctx, latest)
+}
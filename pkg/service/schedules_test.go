@@ -0,0 +1,263 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/axxonsoft-assignment/pkg/cache"
+	"github.com/axxonsoft-assignment/pkg/model"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func timePointer(t time.Time) *time.Time {
+	return &t
+}
+
+func TestSchedules_SchedulesCreate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cacheMock := cache.NewMockCache(ctrl)
+	tasksMock := NewMockTasks(ctrl)
+
+	schedules := NewSchedules(cacheMock, tasksMock)
+
+	tcs := []struct {
+		description string
+		req         model.Schedule
+		mockCalls   []*gomock.Call
+		expErr      error
+	}{
+		{
+			description: "Positive case: valid schedule",
+			req: model.Schedule{
+				Task: model.Task{Method: "GET", URL: "https://www.getyourtasks.com/health"},
+				Cron: "*/5 * * * *",
+			},
+			mockCalls: []*gomock.Call{
+				tasksMock.EXPECT().TasksCheck(gomock.Any(), gomock.Any()).Return(nil),
+				cacheMock.EXPECT().StoreSchedule(gomock.Any(), gomock.Any()).Return(nil),
+			},
+		},
+		{
+			description: "Negative case: invalid cron",
+			req: model.Schedule{
+				Task: model.Task{Method: "GET", URL: "https://www.getyourtasks.com/health"},
+			},
+			mockCalls: []*gomock.Call{
+				tasksMock.EXPECT().TasksCheck(gomock.Any(), gomock.Any()).Return(nil),
+			},
+			expErr: invalidRequest("/cron", model.RuleRequired, "Invalid request: cron cannot be empty"),
+		},
+		{
+			description: "Negative case: task fails the checks of a created task",
+			req: model.Schedule{
+				Task: model.Task{Method: "GET", URL: "https://www.getyourtasks.com/health", TLSProfile: "strict"},
+				Cron: "*/5 * * * *",
+			},
+			mockCalls: []*gomock.Call{
+				tasksMock.EXPECT().TasksCheck(gomock.Any(), model.Task{Method: "GET", URL: "https://www.getyourtasks.com/health", TLSProfile: "strict"}).
+					Return(invalidRequest("/tlsProfile", model.RuleReference, "Invalid request: unknown tlsProfile strict")),
+			},
+			expErr: invalidRequest("/task/tlsProfile", model.RuleReference, "Invalid request: unknown tlsProfile strict"),
+		},
+		{
+			description: "Negative case: error from cache",
+			req: model.Schedule{
+				Task: model.Task{Method: "GET", URL: "https://www.getyourtasks.com/health"},
+				Cron: "*/5 * * * *",
+			},
+			mockCalls: []*gomock.Call{
+				tasksMock.EXPECT().TasksCheck(gomock.Any(), gomock.Any()).Return(nil),
+				cacheMock.EXPECT().StoreSchedule(gomock.Any(), gomock.Any()).Return(errors.New("DB error")),
+			},
+			expErr: unavailableError(errors.New("DB error")),
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			resp, err := schedules.SchedulesCreate(context.TODO(), tc.req)
+
+			assert.Equal(t, tc.expErr, err)
+
+			if tc.expErr == nil {
+				assert.NotEmpty(t, resp.ID)
+				assert.Equal(t, model.MissedRunSkip, resp.MissedRunPolicy)
+				assert.NotNil(t, resp.NextRunAt)
+			}
+		})
+	}
+}
+
+func TestSchedules_SchedulesGet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cacheMock := cache.NewMockCache(ctrl)
+
+	schedule := &model.Schedule{ID: "123122", Cron: "* * * * *"}
+
+	tcs := []struct {
+		description string
+		scheduleID  string
+		resp        *model.Schedule
+		mockCalls   []*gomock.Call
+		expErr      error
+	}{
+		{
+			description: "Positive case: valid scheduleID",
+			scheduleID:  "123122",
+			mockCalls: []*gomock.Call{
+				cacheMock.EXPECT().GetSchedule(gomock.Any(), "123122").Return(schedule, nil),
+			},
+			resp: schedule,
+		},
+		{
+			description: "Negative case: schedule not found",
+			scheduleID:  "4242",
+			mockCalls: []*gomock.Call{
+				cacheMock.EXPECT().GetSchedule(gomock.Any(), "4242").Return(&model.Schedule{}, nil),
+			},
+			expErr: errScheduleNotFound,
+		},
+		{
+			description: "Negative case: error from cache",
+			scheduleID:  "!@#!",
+			mockCalls: []*gomock.Call{
+				cacheMock.EXPECT().GetSchedule(gomock.Any(), "!@#!").Return(nil, errors.New("DB error")),
+			},
+			expErr: unavailableError(errors.New("DB error")),
+		},
+	}
+
+	schedules := NewSchedules(cacheMock, NewMockTasks(ctrl))
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			resp, err := schedules.SchedulesGet(context.TODO(), tc.scheduleID)
+
+			assert.Equal(t, tc.expErr, err)
+			assert.Equal(t, tc.resp, resp)
+		})
+	}
+}
+
+func TestSchedules_SchedulesPause(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cacheMock := cache.NewMockCache(ctrl)
+
+	cacheMock.EXPECT().GetSchedule(gomock.Any(), "123122").Return(&model.Schedule{ID: "123122", Cron: "* * * * *"}, nil)
+	cacheMock.EXPECT().StoreSchedule(gomock.Any(), &model.Schedule{ID: "123122", Cron: "* * * * *", Paused: true}).Return(nil)
+
+	resp, err := NewSchedules(cacheMock, NewMockTasks(ctrl)).SchedulesPause(context.TODO(), "123122")
+
+	assert.Nil(t, err)
+	assert.True(t, resp.Paused)
+
+	cacheMock.EXPECT().GetSchedule(gomock.Any(), "123122").Return(&model.Schedule{ID: "123122", Cron: "* * * * *", Paused: true}, nil)
+
+	_, err = NewSchedules(cacheMock, NewMockTasks(ctrl)).SchedulesPause(context.TODO(), "123122")
+	assert.Equal(t, errSchedulePaused, err)
+	assert.Equal(t, KindConflict, KindOf(err))
+}
+
+func TestSchedules_SchedulesResume(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cacheMock := cache.NewMockCache(ctrl)
+
+	cacheMock.EXPECT().GetSchedule(gomock.Any(), "123122").Return(&model.Schedule{ID: "123122", Cron: "* * * * *", Paused: true}, nil)
+	cacheMock.EXPECT().StoreSchedule(gomock.Any(), gomock.Any()).Return(nil)
+
+	resp, err := NewSchedules(cacheMock, NewMockTasks(ctrl)).SchedulesResume(context.TODO(), "123122")
+
+	assert.Nil(t, err)
+	assert.False(t, resp.Paused)
+	assert.NotNil(t, resp.NextRunAt)
+
+	cacheMock.EXPECT().GetSchedule(gomock.Any(), "123122").Return(&model.Schedule{ID: "123122", Cron: "* * * * *"}, nil)
+
+	_, err = NewSchedules(cacheMock, NewMockTasks(ctrl)).SchedulesResume(context.TODO(), "123122")
+	assert.Equal(t, errScheduleActive, err)
+}
+
+func TestSchedules_SchedulesRunDue(t *testing.T) {
+	now := time.Date(2023, 11, 20, 10, 30, 20, 0, time.UTC)
+	task := model.Task{Method: "GET", URL: "https://www.getyourtasks.com/health"}
+
+	tcs := []struct {
+		description string
+		schedule    *model.Schedule
+		expRuns     []time.Time
+		expNextRun  time.Time
+	}{
+		{
+			description: "Positive case: skip policy runs only the latest missed firing",
+			schedule: &model.Schedule{ID: "1", Task: task, Cron: "*/10 * * * *", MissedRunPolicy: model.MissedRunSkip,
+				NextRunAt: timePointer(time.Date(2023, 11, 20, 10, 0, 0, 0, time.UTC))},
+			expRuns:    []time.Time{time.Date(2023, 11, 20, 10, 30, 0, 0, time.UTC)},
+			expNextRun: time.Date(2023, 11, 20, 10, 40, 0, 0, time.UTC),
+		},
+		{
+			description: "Positive case: catch_up policy runs every missed firing",
+			schedule: &model.Schedule{ID: "2", Task: task, Cron: "*/10 * * * *", MissedRunPolicy: model.MissedRunCatchUp,
+				NextRunAt: timePointer(time.Date(2023, 11, 20, 10, 0, 0, 0, time.UTC))},
+			expRuns: []time.Time{
+				time.Date(2023, 11, 20, 10, 0, 0, 0, time.UTC),
+				time.Date(2023, 11, 20, 10, 10, 0, 0, time.UTC),
+				time.Date(2023, 11, 20, 10, 20, 0, 0, time.UTC),
+				time.Date(2023, 11, 20, 10, 30, 0, 0, time.UTC),
+			},
+			expNextRun: time.Date(2023, 11, 20, 10, 40, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			cacheMock := cache.NewMockCache(ctrl)
+			tasksMock := NewMockTasks(ctrl)
+
+			scheduleTask := task
+			scheduleTask.ScheduleID = tc.schedule.ID
+
+			cacheMock.EXPECT().ListSchedules(gomock.Any()).Return([]*model.Schedule{tc.schedule}, nil)
+
+			for _, run := range tc.expRuns {
+				cacheMock.EXPECT().ClaimScheduleRun(gomock.Any(), tc.schedule.ID, run).Return(true, nil)
+				tasksMock.EXPECT().TasksCreate(gomock.Any(), scheduleTask).Return(&model.TasksResponse{ID: "task"}, nil)
+				cacheMock.EXPECT().AddScheduleRun(gomock.Any(), tc.schedule.ID,
+					&model.ScheduleRun{TaskID: "task", ScheduledAt: run, CreatedAt: now}).Return(nil)
+			}
+
+			latest := *tc.schedule
+			cacheMock.EXPECT().GetSchedule(gomock.Any(), tc.schedule.ID).Return(&latest, nil)
+			cacheMock.EXPECT().StoreSchedule(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, schedule *model.Schedule) error {
+					assert.Equal(t, tc.expRuns[len(tc.expRuns)-1], *schedule.LastRunAt)
+					assert.Equal(t, tc.expNextRun, *schedule.NextRunAt)
+
+					return nil
+				})
+
+			err := NewSchedules(cacheMock, tasksMock).SchedulesRunDue(context.TODO(), now)
+
+			assert.Nil(t, err)
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strconv"
+
+	"github.com/axxonsoft-assignment/pkg/model"
+	"github.com/axxonsoft-assignment/pkg/secrets"
+)
+
+// resolveSecrets gives a copy of the task with the secret references of its url, headers, data, auth and proxy
+// replaced by their values, along with those of its signing keys. The copy is only used for the outbound call and is never stored.
+func (t tasks) resolveSecrets(ctx context.Context, task model.Task) (model.Task, *secrets.Resolver, error) {
+	resolver := secrets.NewResolver(t.settings.Secrets)
+
+	var err error
+
+	resolve := func(s string) string {
+		if err != nil {
+			return s
+		}
+
+		var resolved string
+
+		resolved, err = resolver.Resolve(ctx, s)
+
+		return resolved
+	}
+
+	// the url of a next page comes from the server, it is never templated
+	if task.PageURL != "" {
+		task.URL = task.PageURL
+	} else {
+		task.URL = resolve(task.URL)
+	}
+
+	// the credentials of the proxy are escaped, as they are part of its url
+	if err == nil {
+		task.Proxy, err = resolver.ResolveUserinfo(ctx, task.Proxy)
+	}
+
+	if task.Headers != nil {
+		task.Headers = resolveValue(task.Headers, resolve).(map[string]interface{})
+	}
+
+	if task.Data != nil {
+		task.Data = resolveValue(task.Data, resolve).(map[string]interface{})
+	}
+
+	if task.Auth != nil {
+		auth := *task.Auth
+		auth.Username = resolve(auth.Username)
+		auth.Password = resolve(auth.Password)
+		auth.Token = resolve(auth.Token)
+		auth.Value = resolve(auth.Value)
+		auth.ClientID = resolve(auth.ClientID)
+		auth.ClientSecret = resolve(auth.ClientSecret)
+		task.Auth = &auth
+	}
+
+	if task.Signing != nil {
+		taskSigning := *task.Signing
+		taskSigning.KeyID = resolve(taskSigning.KeyID)
+		taskSigning.Secret = resolve(taskSigning.Secret)
+		taskSigning.AccessKeyID = resolve(taskSigning.AccessKeyID)
+		taskSigning.SecretAccessKey = resolve(taskSigning.SecretAccessKey)
+		taskSigning.SessionToken = resolve(taskSigning.SessionToken)
+		task.Signing = &taskSigning
+	}
+
+	if err != nil {
+		return model.Task{}, nil, errors.New("Invalid request: " + err.Error())
+	}
+
+	return task, resolver, nil
+}
+
+// secretViolations gives a violation for each field of the task referencing a secret that cannot be resolved, at the
+// pointer of the field
+func (t tasks) secretViolations(ctx context.Context, task model.Task) []model.Violation {
+	resolver := secrets.NewResolver(t.settings.Secrets)
+
+	var found []model.Violation
+
+	check := func(pointer string, s string) {
+		if _, err := resolver.Resolve(ctx, s); err != nil {
+			found = append(found, model.Violation{Pointer: pointer, Rule: model.RuleReference, Message: "Invalid request: " + err.Error()})
+		}
+	}
+
+	check("/url", task.URL)
+	check("/proxy", task.Proxy)
+	walkStrings("/headers", task.Headers, check)
+	walkStrings("/data", task.Data, check)
+
+	if task.Auth != nil {
+		check("/auth/username", task.Auth.Username)
+		check("/auth/password", task.Auth.Password)
+		check("/auth/token", task.Auth.Token)
+		check("/auth/value", task.Auth.Value)
+		check("/auth/clientId", task.Auth.ClientID)
+		check("/auth/clientSecret", task.Auth.ClientSecret)
+	}
+
+	if task.Signing != nil {
+		check("/signing/keyId", task.Signing.KeyID)
+		check("/signing/secret", task.Signing.Secret)
+		check("/signing/accessKeyId", task.Signing.AccessKeyID)
+		check("/signing/secretAccessKey", task.Signing.SecretAccessKey)
+		check("/signing/sessionToken", task.Signing.SessionToken)
+	}
+
+	return found
+}
+
+// walkStrings visits the strings of the decoded JSON value with their pointers, the keys of its maps in order
+func walkStrings(pointer string, value interface{}, visit func(pointer string, s string)) {
+	switch v := value.(type) {
+	case string:
+		visit(pointer, v)
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			walkStrings(pointer+"/"+model.PointerToken(key), v[key], visit)
+		}
+	case []interface{}:
+		for i, item := range v {
+			walkStrings(pointer+"/"+strconv.Itoa(i), item, visit)
+		}
+	}
+}
+
+// resolveValue walks the decoded JSON value, copying its maps and slices so that the task's own are left untouched
+func resolveValue(value interface{}, resolve func(string) string) interface{} {
+	switch v := value.(type) {
+	case string:
+		return resolve(v)
+	case map[string]interface{}:
+		resolved := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			resolved[key] = resolveValue(item, resolve)
+		}
+
+		return resolved
+	case []interface{}:
+		resolved := make([]interface{}, len(v))
+		for i, item := range v {
+			resolved[i] = resolveValue(item, resolve)
+		}
+
+		return resolved
+	default:
+		return v
+	}
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/axxonsoft-assignment/pkg/breaker"
+	"github.com/axxonsoft-assignment/pkg/cache"
+	"github.com/axxonsoft-assignment/pkg/model"
+	"github.com/axxonsoft-assignment/pkg/secrets"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTasks_resolveSecrets(t *testing.T) {
+	t.Setenv("TEST_SECRET_TOKEN", "abc")
+
+	task := New(nil, breaker.New(breaker.Settings{}), Settings{Secrets: secrets.NewEnv("TEST_SECRET_")}).(*tasks)
+
+	taskDetails := model.Task{
+		Method:  "POST",
+		URL:     "https://api.partner.com/items?key=${secret:token}",
+		Headers: map[string]interface{}{"X-Token": "${secret:token}", model.ContentType: "application/json"},
+		Data:    map[string]interface{}{"nested": []interface{}{"${secret:token}", 1.0}},
+		Auth:    &model.Auth{Type: model.AuthBearer, Token: "${secret:token}"},
+	}
+
+	resolved, resolver, err := task.resolveSecrets(context.TODO(), taskDetails)
+	assert.Nil(t, err)
+
+	assert.Equal(t, "https://api.partner.com/items?key=abc", resolved.URL)
+	assert.Equal(t, "abc", resolved.Headers["X-Token"])
+	assert.Equal(t, []interface{}{"abc", 1.0}, resolved.Data["nested"])
+	assert.Equal(t, "abc", resolved.Auth.Token)
+	assert.Equal(t, "https://api.partner.com/items?key=${secret:token}", resolver.Redact(resolved.URL))
+
+	// the task itself keeps its references
+	assert.Equal(t, "${secret:token}", taskDetails.Headers["X-Token"])
+	assert.Equal(t, []interface{}{"${secret:token}", 1.0}, taskDetails.Data["nested"])
+	assert.Equal(t, "${secret:token}", taskDetails.Auth.Token)
+
+	// the credentials of the proxy are escaped within its url
+	t.Setenv("TEST_SECRET_PROXY_PASSWORD", "p@ss:w/rd")
+
+	resolved, resolver, err = task.resolveSecrets(context.TODO(), model.Task{URL: "https://api.partner.com/items",
+		Proxy: "http://${secret:token}:${secret:PROXY_PASSWORD}@proxy.partner.com:3128"})
+	assert.Nil(t, err)
+
+	proxyURL, err := model.ParseProxy(resolved.Proxy)
+	assert.Nil(t, err)
+
+	password, _ := proxyURL.User.Password()
+	assert.Equal(t, "abc", proxyURL.User.Username())
+	assert.Equal(t, "p@ss:w/rd", password)
+	assert.Equal(t, "http://${secret:token}:${secret:PROXY_PASSWORD}@proxy.partner.com:3128", resolver.Redact(resolved.Proxy))
+
+	_, _, err = task.resolveSecrets(context.TODO(), model.Task{URL: "https://api.partner.com/?key=${secret:other}"})
+	assert.Equal(t, errors.New("Invalid request: unknown secret other"), err)
+}
+
+func TestTasks_dispatch_redactsBlockedHost(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Setenv("TEST_SECRET_TENANT", "tenant-xyz")
+
+	cacheMock := cache.NewMockCache(ctrl)
+	cacheMock.EXPECT().GetRateLimit(gomock.Any(), gomock.Any()).Return(&model.RateLimit{}, nil).AnyTimes()
+	cacheMock.EXPECT().StoreTask(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	// through a proxy the host is resolved upfront, its resolution fails with the host in the error. The task is
+	// dispatched as is, the validation of its url being bypassed.
+	proxyURL, _ := url.Parse("http://127.0.0.1:1")
+	task := New(cacheMock, breaker.New(breaker.Settings{}), Settings{Proxy: proxyURL, Secrets: secrets.NewEnv("TEST_SECRET_")}).(*tasks)
+
+	taskObj := &model.TasksObject{ID: "task-1"}
+
+	body := task.dispatch(context.TODO(), model.Task{Method: "GET", URL: "https://${secret:tenant}.invalid/items"}, taskObj, true)
+	assert.Nil(t, body)
+
+	assert.Equal(t, model.Error, taskObj.Status)
+	assert.Equal(t, model.ErrCodeBlockedDestination, taskObj.Error.Code)
+	assert.NotContains(t, taskObj.Error.Message, "tenant-xyz")
+	assert.Contains(t, taskObj.Error.Message, "${secret:tenant}")
+}
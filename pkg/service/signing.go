@@ -0,0 +1,32 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/axxonsoft-assignment/pkg/model"
+	"github.com/axxonsoft-assignment/pkg/signing"
+)
+
+// signerFor builds the signer of the task's signing, nil if the task is not signed.
+func (t tasks) signerFor(taskSigning *model.Signing) (signing.Signer, error) {
+	if taskSigning == nil {
+		return nil, nil
+	}
+
+	if taskSigning.Type == model.SigningSigV4 {
+		return signing.NewSigV4(taskSigning.AccessKeyID, taskSigning.SecretAccessKey, taskSigning.SessionToken,
+			taskSigning.Region, taskSigning.Service), nil
+	}
+
+	name := taskSigning.Scheme
+	if name == "" {
+		name = model.DefaultSigningScheme
+	}
+
+	scheme, ok := t.settings.HMACSchemes[name]
+	if !ok {
+		return nil, errors.New("Invalid request: unknown signing scheme " + name)
+	}
+
+	return signing.NewHMAC(scheme, taskSigning.KeyID, taskSigning.Secret), nil
+}
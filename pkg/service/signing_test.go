@@ -0,0 +1,98 @@
+package service
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/axxonsoft-assignment/internal/s3test"
+	"github.com/axxonsoft-assignment/pkg/breaker"
+	"github.com/axxonsoft-assignment/pkg/model"
+	"github.com/axxonsoft-assignment/pkg/ssrf"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTasks_signerFor(t *testing.T) {
+	task := New(nil, breaker.New(breaker.Settings{}), Settings{}).(*tasks)
+
+	signer, err := task.signerFor(nil)
+	assert.Nil(t, err)
+	assert.Nil(t, signer)
+
+	signer, err = task.signerFor(&model.Signing{Type: model.SigningHMAC, Secret: "secret"})
+	assert.Nil(t, err)
+	assert.NotNil(t, signer)
+
+	_, err = task.signerFor(&model.Signing{Type: model.SigningHMAC, Scheme: "partner", Secret: "secret"})
+	assert.Equal(t, errors.New("Invalid request: unknown signing scheme partner"), err)
+}
+
+func TestTasks_signerFor_S3(t *testing.T) {
+	server := s3test.NewServer()
+	defer server.Close()
+
+	guard, _ := ssrf.New([]string{"127.0.0.0/8"})
+	task := New(nil, breaker.New(breaker.Settings{}), Settings{Guard: guard}).(*tasks)
+
+	tcs := []struct {
+		description string
+		method      string
+		body        []byte
+		signing     *model.Signing
+		expStatus   int
+		expBody     string
+	}{
+		{
+			description: "Positive case: signed PUT",
+			method:      http.MethodPut,
+			body:        []byte(`{"name":"task"}`),
+			signing: &model.Signing{Type: model.SigningSigV4, AccessKeyID: s3test.AccessKeyID,
+				SecretAccessKey: s3test.SecretAccessKey, Region: s3test.Region, Service: "s3"},
+			expStatus: http.StatusOK,
+		},
+		{
+			description: "Positive case: signed GET",
+			method:      http.MethodGet,
+			signing: &model.Signing{Type: model.SigningSigV4, AccessKeyID: s3test.AccessKeyID,
+				SecretAccessKey: s3test.SecretAccessKey, Region: s3test.Region, Service: "s3"},
+			expStatus: http.StatusOK,
+			expBody:   `{"name":"task"}`,
+		},
+		{
+			description: "Negative case: wrong secret key",
+			method:      http.MethodGet,
+			signing: &model.Signing{Type: model.SigningSigV4, AccessKeyID: s3test.AccessKeyID,
+				SecretAccessKey: "wrong", Region: s3test.Region, Service: "s3"},
+			expStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			request, _ := http.NewRequest(tc.method, server.URL+"/bucket/tasks/1.json", bytes.NewReader(tc.body))
+			request.Header.Set(model.ContentType, "application/json")
+
+			signer, err := task.signerFor(tc.signing)
+			assert.Nil(t, err)
+			assert.Nil(t, signer.Sign(request, tc.body, time.Now()))
+
+			response, err := task.client.Do(request)
+			assert.Nil(t, err)
+
+			defer response.Body.Close()
+
+			body, _ := io.ReadAll(response.Body)
+
+			assert.Equal(t, tc.expStatus, response.StatusCode)
+
+			if tc.expBody != "" {
+				assert.Equal(t, tc.expBody, string(body))
+			}
+		})
+	}
+}
@@ -3,147 +3,606 @@ package service
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"net/http"
+	"net/url"
+	"time"
 
+	"github.com/axxonsoft-assignment/pkg/blob"
+	"github.com/axxonsoft-assignment/pkg/breaker"
 	"github.com/axxonsoft-assignment/pkg/cache"
 	"github.com/axxonsoft-assignment/pkg/model"
+	"github.com/axxonsoft-assignment/pkg/policy"
+	"github.com/axxonsoft-assignment/pkg/secrets"
+	"github.com/axxonsoft-assignment/pkg/signing"
+	"github.com/axxonsoft-assignment/pkg/ssrf"
 	"github.com/google/uuid"
 )
 
+// Settings holds the configuration of the outbound calls made by the tasks
+type Settings struct {
+	// ConcurrencyLimits caps the in-flight calls per host, hosts not matching any pattern are not capped
+	ConcurrencyLimits []model.ConcurrencyLimit
+	// Guard blocks the calls to internal addresses, all of them are blocked if not set
+	Guard ssrf.Guard
+	// Policy restricts the destinations of the tasks, every destination is allowed if not set
+	Policy policy.Engine
+	// Proxy is the global outbound proxy, a task may override it with its own
+	Proxy *url.URL
+	// NoProxy lists the hosts called directly, as "*", host globs, ".domain" suffixes or CIDRs
+	NoProxy []string
+	// TLSProfiles are the TLS configs the tasks select by name
+	TLSProfiles map[string]*tls.Config
+	// Secrets holds the values of the ${secret:name} references of the tasks, none are resolved if not set
+	Secrets secrets.Provider
+	// HMACSchemes are the HMAC signatures the tasks select by name, only the default one if not set
+	HMACSchemes map[string]model.HMACScheme
+	// Blobs keeps the response bodies larger than BlobThreshold, every body is read in memory if not set
+	Blobs blob.Store
+	// BlobThreshold is the size in bytes above which a response body goes to Blobs, defaults to 1MiB
+	BlobThreshold int64
+	// MaxResponseBytes caps the size of the decoded response bodies, defaults to 100MiB
+	MaxResponseBytes int64
+}
+
+var errTaskNotFound = notFoundError("Task not found")
+
+// maxTasksPage caps the number of tasks listed at once
+const maxTasksPage = 100
+
 type tasks struct {
-	cache  cache.Cache
-	client http.Client
+	cache          cache.Cache
+	client         http.Client
+	profileClients map[string]http.Client
+	breakers       breaker.Breakers
+	settings       Settings
 }
 
-func New(cache cache.Cache) Tasks {
-	return &tasks{cache: cache}
+func New(cache cache.Cache, breakers breaker.Breakers, settings Settings) Tasks {
+	if settings.Guard == nil {
+		settings.Guard, _ = ssrf.New(nil)
+	}
+
+	if settings.Policy == nil {
+		settings.Policy, _ = policy.New("", 0)
+	}
+
+	if settings.Secrets == nil {
+		settings.Secrets = secrets.NewChain()
+	}
+
+	if settings.MaxResponseBytes <= 0 {
+		settings.MaxResponseBytes = defaultMaxResponseBytes
+	}
+
+	if settings.BlobThreshold <= 0 {
+		settings.BlobThreshold = defaultBlobThreshold
+	}
+
+	if settings.HMACSchemes == nil {
+		settings.HMACSchemes = map[string]model.HMACScheme{model.DefaultSigningScheme: signing.DefaultScheme}
+	}
+
+	// every TLS profile gets a transport of its own, so that their connections are never shared
+	profileClients := make(map[string]http.Client, len(settings.TLSProfiles))
+	for name, tlsConfig := range settings.TLSProfiles {
+		profileClients[name] = newClient(settings, tlsConfig)
+	}
+
+	return &tasks{
+		cache:          cache,
+		client:         newClient(settings, nil),
+		profileClients: profileClients,
+		breakers:       breakers,
+		settings:       settings,
+	}
 }
 
 // TasksCreate takes the request body, makes the call to third party service and updates the cache respectively.
 func (t tasks) TasksCreate(ctx context.Context, taskDetails model.Task) (*model.TasksResponse, error) {
+	if taskDetails.FanOut != nil {
+		taskObj, targets, targetObjs, err := t.prepareFanOut(ctx, taskDetails)
+		if err != nil {
+			return nil, err
+		}
+
+		go t.dispatchFanOut(ctx, taskObj, targets, targetObjs)
+
+		return &model.TasksResponse{ID: taskObj.ID}, nil
+	}
+
+	taskObj, err := t.prepare(ctx, taskDetails)
+	if err != nil {
+		return nil, err
+	}
+
+	// launch a go routine by pass task details to call the 3rd party service
+	go t.execute(ctx, taskDetails, taskObj)
+
+	return &model.TasksResponse{ID: taskObj.ID}, nil
+}
+
+// TasksRun creates the task like TasksCreate but makes the call in the caller's go routine, giving the final
+// task details along with the response body, nil if the call failed. A fan-out task gives no response body.
+func (t tasks) TasksRun(ctx context.Context, taskDetails model.Task) (*model.TasksObject, []byte, error) {
+	if taskDetails.FanOut != nil {
+		taskObj, targets, targetObjs, err := t.prepareFanOut(ctx, taskDetails)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		t.dispatchFanOut(ctx, taskObj, targets, targetObjs)
+
+		return taskObj, nil, nil
+	}
+
+	taskObj, err := t.prepare(ctx, taskDetails)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return taskObj, t.execute(ctx, taskDetails, taskObj), nil
+}
+
+// TasksCheck validates the task as TasksCreate does, without creating it, e.g. for the task of a schedule.
+func (t tasks) TasksCheck(ctx context.Context, taskDetails model.Task) error {
+	if taskDetails.FanOut != nil {
+		_, err := t.checkFanOut(ctx, taskDetails)
+
+		return err
+	}
+
+	return t.check(ctx, taskDetails)
+}
+
+// prepare validates the task and stores it in the cache with the status "new".
+func (t tasks) prepare(ctx context.Context, taskDetails model.Task) (*model.TasksObject, error) {
+	if err := t.check(ctx, taskDetails); err != nil {
+		return nil, err
+	}
+
+	return t.store(ctx, taskDetails)
+}
+
+// check validates the task along with its tlsProfile, signing, secrets and destination, giving all of their
+// violations at once.
+func (t tasks) check(ctx context.Context, taskDetails model.Task) error {
+	var found []model.Violation
+
 	// validate request body
 	if err := model.ValidateRequestBody(taskDetails); err != nil {
-		return nil, err
+		var validationErr *model.ValidationError
+		if !errors.As(err, &validationErr) {
+			return validationError(err)
+		}
+
+		found = append(found, validationErr.Violations...)
+	}
+
+	if _, ok := t.clientFor(taskDetails.TLSProfile); !ok {
+		found = append(found, model.Violation{Pointer: "/tlsProfile", Rule: model.RuleReference,
+			Message: "Invalid request: unknown tlsProfile " + taskDetails.TLSProfile})
+	}
+
+	if _, err := t.signerFor(taskDetails.Signing); err != nil {
+		found = append(found, model.Violation{Pointer: "/signing/scheme", Rule: model.RuleReference, Message: err.Error()})
+	}
+
+	// every referenced secret should exist, the resolved values are only used for the policy below
+	secretViolations := t.secretViolations(ctx, taskDetails)
+	found = append(found, secretViolations...)
+
+	if len(secretViolations) == 0 {
+		resolvedTask, _, err := t.resolveSecrets(ctx, taskDetails)
+		if err != nil {
+			return validationError(err)
+		}
+
+		// check the destination against the policy, along with the token endpoint the task gets its credentials from
+		if !hasViolation(found, "/url") {
+			if err = t.settings.Policy.Evaluate(ctx, resolvedTask.Method, resolvedTask.URL); err != nil {
+				found = append(found, model.Violation{Pointer: "/url", Rule: model.RuleInvalid, Message: err.Error()})
+			}
+		}
+
+		if resolvedTask.Auth != nil && resolvedTask.Auth.Type == model.AuthOAuth2 && !hasViolation(found, "/auth/tokenUrl") {
+			if err = t.settings.Policy.Evaluate(ctx, http.MethodPost, resolvedTask.Auth.TokenURL); err != nil {
+				found = append(found, model.Violation{Pointer: "/auth/tokenUrl", Rule: model.RuleInvalid, Message: err.Error()})
+			}
+		}
+	}
+
+	if len(found) > 0 {
+		return validationError(&model.ValidationError{Violations: found})
 	}
 
+	return nil
+}
+
+// hasViolation tells if a violation was found for the field at the pointer
+func hasViolation(found []model.Violation, pointer string) bool {
+	for _, violation := range found {
+		if violation.Pointer == pointer {
+			return true
+		}
+	}
+
+	return false
+}
+
+// store stores a new task in the cache with the status "new".
+func (t tasks) store(ctx context.Context, taskDetails model.Task) (*model.TasksObject, error) {
 	taskID := uuid.New().String()
 
 	// when a new task is created, its status is "new"
 	taskObj := &model.TasksObject{
-		ID:     taskID,
-		Status: model.New,
+		ID:         taskID,
+		Status:     model.New,
+		ScheduleID: taskDetails.ScheduleID,
+		Template:   taskDetails.Template,
+		ChainID:    taskDetails.ChainID,
+		WorkflowID: taskDetails.WorkflowID,
+		ParentID:   taskDetails.ParentID,
 	}
 
 	// store the new task details into the cache
 	if err := t.cache.StoreTask(ctx, taskID, taskObj); err != nil {
-		return nil, err
+		return nil, unavailableError(err)
 	}
 
-	// launch a go routine by pass task details to call the 3rd party service
-	go func(taskObj *model.TasksObject) {
-		var (
-			taskBytes []byte
-		)
+	return taskObj, nil
+}
+
+// execute makes the call of the task, repeating it till its condition is met if the task polls or for each page if
+// it paginates, then extracts the outputs of the task from the response. It gives the response body, nil if the
+// task failed.
+func (t tasks) execute(ctx context.Context, taskDetails model.Task, taskObj *model.TasksObject) []byte {
+	var body []byte
+
+	switch {
+	case taskDetails.Until != nil:
+		body = t.poll(ctx, taskDetails, taskObj)
+	case taskDetails.Paginate != nil:
+		body = t.paginate(ctx, taskDetails, taskObj)
+	default:
+		body = t.dispatch(ctx, taskDetails, taskObj, true)
+	}
+
+	if len(taskDetails.Extract) == 0 || taskObj.Status != model.Done {
+		return body
+	}
+
+	outputs, err := extractOutputs(taskDetails.Extract, taskObj, body)
+	if err != nil {
+		log.Printf("Error extracting outputs of task:%s: %v", taskObj.ID, err)
+
+		t.failTask(ctx, taskObj, model.ErrCodeExtractFailed, err)
+
+		return nil
+	}
+
+	taskObj.Outputs = outputs
+	_ = t.cache.StoreTask(ctx, taskObj.ID, taskObj)
+
+	return body
+}
+
+// dispatch makes the call of the task to the 3rd party service, updating the task in the cache along the way.
+// It gives the response body, nil if the call failed or if the body was offloaded to the blob store, which a body
+// used by the task never is.
+func (t tasks) dispatch(ctx context.Context, taskDetails model.Task, taskObj *model.TasksObject, offload bool) []byte {
+	var (
+		taskBytes []byte
+	)
+
+	// the secrets are resolved only now, anything stored or logged from here on has their values redacted
+	taskDetails, resolver, er := t.resolveSecrets(ctx, taskDetails)
+	if er != nil {
+		log.Printf("Error resolving secrets: %v", er)
+
+		t.failTask(ctx, taskObj, model.ErrCodeSecret, er)
+
+		return nil
+	}
+
+	// create a new http request instance, if failed update the task's status to "error" in the cache
+	// the call is bound to the context, e.g. cancelled along with the workflow node it runs for
+	request, er := http.NewRequestWithContext(ctx, taskDetails.Method, taskDetails.URL, nil)
+	if er != nil {
+		er = resolver.RedactError(er)
+		log.Printf("Error creating request: %v", er)
+
+		t.failTask(ctx, taskObj, model.ErrCodeInvalidRequest, er)
+
+		return nil
+	}
+
+	// set headers from the task details
+	for key, value := range taskDetails.Headers {
+		request.Header.Set(key, fmt.Sprintf("%v", value))
+	}
 
-		// create a new http request instance, if failed update the task's status to "error" in the cache
-		request, er := http.NewRequest(taskDetails.Method, taskDetails.URL, nil)
+	// the compressed responses are decoded below, so that the task gets the same body whatever the encoding
+	if request.Header.Get("Accept-Encoding") == "" {
+		request.Header.Set("Accept-Encoding", acceptEncoding)
+	}
+
+	// in-case the method is POST/PUT/PATCH, fetch the request body
+	if taskDetails.Data != nil {
+		taskBytes, er = json.Marshal(taskDetails.Data)
 		if er != nil {
-			log.Printf("Error creating request: %v", er)
+			log.Printf("Error marshaling JSON")
 
-			taskObj.Status = model.Error
-			if er = t.cache.StoreTask(ctx, taskID, taskObj); er != nil {
-				return
-			}
+			t.failTask(ctx, taskObj, model.ErrCodeInvalidRequest, er)
 
-			return
+			return nil
 		}
 
-		// set headers from the task details
-		for key, value := range taskDetails.Headers {
-			request.Header.Set(key, fmt.Sprintf("%v", value))
+		body := bytes.NewBuffer(taskBytes)
+		request.Body = io.NopCloser(body)
+		request.ContentLength = int64(len(taskBytes))
+	}
+
+	// apply the credentials of the task's auth scheme, an api key in the query is part of the url from here on
+	if er = t.applyAuth(ctx, request, taskDetails, resolver); er != nil {
+		er = resolver.RedactError(er)
+		log.Printf("Error applying auth: %v", er)
+
+		t.failTask(ctx, taskObj, model.ErrCodeAuthFailed, er)
+
+		return nil
+	}
+
+	host := request.URL.Hostname()
+
+	// only the redacted url of the task's own proxy is stored with the task
+	request = request.WithContext(withTaskProxy(request.Context(), taskDetails))
+
+	proxyURL, _ := t.settings.proxyFor(request)
+	if proxyURL != nil {
+		taskObj.Proxy = resolver.Redact(proxyURL.Redacted())
+	}
+
+	// wait for the rate limit of the target host, the task stays throttled instead of failing meanwhile
+	if er = t.waitForRateLimit(ctx, host, taskObj); er != nil {
+		log.Printf("Error applying rate limit: %v", er)
+
+		t.failTask(ctx, taskObj, model.ErrCodeRateLimit, er)
+
+		return nil
+	}
+
+	// wait for a free slot of the target host, the task stays queued in FIFO order meanwhile
+	slotCtx, releaseSlot, er := t.acquireSlot(ctx, host, taskObj)
+	if er != nil {
+		log.Printf("Error applying concurrency limit: %v", er)
+
+		t.failTask(ctx, taskObj, model.ErrCodeConcurrency, er)
+
+		return nil
+	}
+	defer releaseSlot()
+
+	request = request.WithContext(slotCtx)
+
+	// the policy may have been reloaded while the task was waiting
+	if er = t.settings.Policy.Evaluate(ctx, request.Method, request.URL.String()); er != nil {
+		er = resolver.RedactError(er)
+		log.Printf("Skipping call to %s: %v", resolver.Redact(host), er)
+
+		t.failTask(ctx, taskObj, model.ErrCodePolicyDenied, er)
+
+		return nil
+	}
+
+	// fail fast while the target host's circuit is open
+	generation, er := t.breakers.Allow(host)
+	if er != nil {
+		log.Printf("Skipping call to %s: %v", resolver.Redact(host), er)
+
+		t.failTask(ctx, taskObj, model.ErrCodeCircuitOpen, er)
+
+		return nil
+	}
+
+	// through a proxy the dialer never sees the target's address, so it is resolved and checked upfront
+	if proxyURL != nil {
+		if er = t.settings.Guard.CheckHost(ctx, host); er != nil {
+			er = resolver.RedactError(er)
+			log.Printf("Skipping call to %s: %v", resolver.Redact(host), er)
+
+			t.breakers.Cancel(host, generation)
+			t.failTask(ctx, taskObj, model.ErrCodeBlockedDestination, er)
+
+			return nil
 		}
+	}
 
-		// in-case the method is POST/PUT/PATCH, fetch the request body
-		if taskDetails.Data != nil {
-			taskBytes, er = json.Marshal(taskDetails.Data)
-			if er != nil {
-				log.Printf("Error marshaling JSON")
+	traceCtx, isProxyError := proxyTrace(request.Context(), proxyURL)
+	request = request.WithContext(traceCtx)
 
-				taskObj.Status = model.Error
-				if er = t.cache.StoreTask(ctx, taskID, taskObj); er != nil {
-					return
-				}
+	// make the http call following the redirects as per the task, if failed update the task's status in the cache
+	client, _ := t.clientFor(taskDetails.TLSProfile)
+	client.CheckRedirect = t.checkRedirect(taskDetails, taskObj, resolver)
 
-				return
-			}
+	// sign as late as possible, so that a timestamped signature does not expire while the task waits
+	signer, _ := t.signerFor(taskDetails.Signing)
+	if signer != nil {
+		if er = signer.Sign(request, taskBytes, time.Now()); er != nil {
+			log.Printf("Error signing request: %v", er)
+
+			t.breakers.Cancel(host, generation)
+			t.failTask(ctx, taskObj, model.ErrCodeSigningFailed, resolver.RedactError(er))
 
-			body := bytes.NewBuffer(taskBytes)
-			request.Body = io.NopCloser(body)
+			return nil
 		}
+	}
 
-		// make the http call, if failed update the task's status in the cache
-		response, er := t.client.Do(request)
-		if er != nil {
-			log.Printf("Error while calling the 3rd party servicce: %v", er)
+	response, er := client.Do(request)
+	if er != nil {
+		er = resolver.RedactError(er)
+		log.Printf("Error while calling the 3rd party servicce: %v", er)
 
-			taskObj.Status = model.Error
-			if er = t.cache.StoreTask(ctx, taskID, taskObj); er != nil {
-				return
-			}
+		// a blocked destination says nothing about the health of the host
+		if errors.Is(er, ssrf.ErrBlocked) {
+			t.breakers.Cancel(host, generation)
+			t.failTask(ctx, taskObj, model.ErrCodeBlockedDestination, er)
 
-			return
+			return nil
 		}
-		defer response.Body.Close()
 
-		// when the call to 3rd party service is successfully made, update the task's status to "in_process".
-		taskObj.Status = model.InProcess
-		if er = t.cache.StoreTask(ctx, taskID, taskObj); er != nil {
-			return
+		if errors.Is(er, policy.ErrDenied) {
+			t.breakers.Cancel(host, generation)
+			t.failTask(ctx, taskObj, model.ErrCodePolicyDenied, er)
+
+			return nil
 		}
 
-		_, e := io.ReadAll(response.Body)
-		if e != nil {
-			log.Printf("Error reading response body: %v", e)
+		// a cancelled call says nothing about the health of the host either
+		if errors.Is(er, context.Canceled) {
+			t.breakers.Cancel(host, generation)
 
-			taskObj.Status = model.Error
-			if er = t.cache.StoreTask(ctx, taskID, taskObj); er != nil {
-				return
+			if cause := context.Cause(slotCtx); errors.Is(cause, errSlotLost) {
+				t.failTask(ctx, taskObj, model.ErrCodeConcurrency, cause)
+
+				return nil
 			}
 
-			return
+			t.failTask(ctx, taskObj, model.ErrCodeCallFailed, er)
+
+			return nil
 		}
 
-		// update the task's status as per the status of the 3rd party service's call and update the cache respectively
-		if er != nil || response.StatusCode != http.StatusOK {
-			taskObj.Status = model.Error
-		} else {
-			taskObj.Status = model.Done
+		// the call did not get past the proxy, so it says nothing about the health of the host either
+		if isProxyError(er) {
+			t.breakers.Cancel(host, generation)
+			t.failTask(ctx, taskObj, model.ErrCodeProxy, er)
+
+			return nil
 		}
 
-		taskObj.HTTPStatusCode = &response.StatusCode
-		taskObj.Length = &response.ContentLength
-		taskObj.Headers = response.Header
+		t.breakers.Failure(host, generation)
+		t.failTask(ctx, taskObj, model.ErrCodeCallFailed, er)
+
+		return nil
+	}
+	defer response.Body.Close()
+
+	// server errors count towards opening the circuit, anything else shows the host is up
+	if response.StatusCode >= http.StatusInternalServerError {
+		t.breakers.Failure(host, generation)
+	} else {
+		t.breakers.Success(host, generation)
+	}
+
+	t.invalidateAuth(ctx, taskDetails.Auth, response.StatusCode)
 
-		if er = t.cache.StoreTask(ctx, taskID, taskObj); er != nil {
-			return
+	// when the call to 3rd party service is successfully made, update the task's status to "in_process".
+	taskObj.Status = model.InProcess
+	if er = t.cache.StoreTask(ctx, taskObj.ID, taskObj); er != nil {
+		return nil
+	}
+
+	// the bytes are counted as received and once decoded, the decoded ones being capped by the max response size
+	wire := &countingReader{reader: response.Body}
+
+	decoded, e := decodeBody(wire, response.Header)
+	if e != nil {
+		log.Printf("Error decoding response body: %v", e)
+
+		t.failTask(ctx, taskObj, model.ErrCodeReadFailed, fmt.Errorf("cannot decode the body: %w", e))
+
+		return nil
+	}
+
+	body := &countingReader{reader: decoded, limit: t.settings.MaxResponseBytes}
+
+	respBody, e := t.readBody(ctx, taskObj, body, response.Header.Get(model.ContentType), offload, keepsBody(taskDetails))
+	if e != nil {
+		log.Printf("Error reading response body: %v", e)
+
+		if errors.Is(e, errResponseTooLarge) {
+			t.failTask(ctx, taskObj, model.ErrCodeResponseTooLarge, e)
+		} else {
+			t.failTask(ctx, taskObj, model.ErrCodeReadFailed, e)
 		}
 
-		return
-	}(taskObj)
+		return nil
+	}
 
-	return &model.TasksResponse{ID: taskID}, nil
+	// update the task's status as per the status of the 3rd party service's call and update the cache respectively
+	if er != nil || response.StatusCode != http.StatusOK {
+		taskObj.Status = model.Error
+	} else {
+		taskObj.Status = model.Done
+	}
+
+	taskObj.HTTPStatusCode = &response.StatusCode
+	taskObj.Length = &body.count
+	taskObj.WireLength = &wire.count
+	taskObj.Headers = response.Header
+
+	// the task is final even if it could not be stored, the response is still given to the caller
+	_ = t.cache.StoreTask(ctx, taskObj.ID, taskObj)
+
+	return respBody
 }
 
-// TasksGet gives the complete task details given a taskID, return an empty object if not found.
+// TasksGet gives the complete task details given a taskID.
 func (t tasks) TasksGet(ctx context.Context, taskID string) (*model.TasksObject, error) {
 	taskObj, err := t.cache.GetTask(ctx, taskID)
 	if err != nil {
-		return nil, err
+		return nil, unavailableError(err)
+	}
+
+	if taskObj.ID == "" {
+		return nil, errTaskNotFound
+	}
+
+	// a fan-out task reports the details of the task of each of its targets
+	for i := range taskObj.Targets {
+		if taskObj.Targets[i].TaskID == "" {
+			continue
+		}
+
+		if taskObj.Targets[i].Result, err = t.cache.GetTask(ctx, taskObj.Targets[i].TaskID); err != nil {
+			return nil, unavailableError(err)
+		}
 	}
 
 	return taskObj, nil
 }
+
+// TasksList gives limit tasks from the offset, the newest first, as stored in the cache, along with the offset of the
+// next page, 0 if there is none
+func (t tasks) TasksList(ctx context.Context, offset, limit int) ([]*model.TasksObject, int, error) {
+	if limit < 1 || limit > maxTasksPage {
+		return nil, 0, validationError(fmt.Errorf("Invalid request: limit should be between 1 and %d", maxTasksPage))
+	}
+
+	if offset < 0 {
+		return nil, 0, validationError(errors.New("Invalid request: offset cannot be negative"))
+	}
+
+	tasks, next, err := t.cache.ListTasks(ctx, int64(offset), int64(limit))
+	if err != nil {
+		return nil, 0, unavailableError(err)
+	}
+
+	return tasks, int(next), nil
+}
+
+// failTask updates the task's status to "error" in the cache along with the reason of the failure.
+func (t tasks) failTask(ctx context.Context, taskObj *model.TasksObject, code string, err error) {
+	taskObj.Status = model.Error
+	taskObj.Error = &model.TaskError{Code: code, Message: err.Error()}
+
+	// the failure of a cancelled task is stored all the same. The cache layer logs the failure to store the task,
+	// nothing more can be done here.
+	_ = t.cache.StoreTask(context.WithoutCancel(ctx), taskObj.ID, taskObj)
+}
@@ -3,10 +3,18 @@ package service
 import (
 	"context"
 	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/axxonsoft-assignment/pkg/breaker"
 	"github.com/axxonsoft-assignment/pkg/cache"
 	"github.com/axxonsoft-assignment/pkg/model"
+	"github.com/axxonsoft-assignment/pkg/policy"
+	"github.com/axxonsoft-assignment/pkg/ssrf"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 )
@@ -21,7 +29,10 @@ func TestTasks_TasksCreate(t *testing.T) {
 
 	cacheMock := cache.NewMockCache(ctrl)
 
-	task := New(cacheMock)
+	// the outbound call is made in the background, the target hosts are not rate limited in these tests
+	cacheMock.EXPECT().GetRateLimit(gomock.Any(), gomock.Any()).Return(&model.RateLimit{}, nil).AnyTimes()
+
+	task := New(cacheMock, breaker.New(breaker.Settings{}), Settings{})
 
 	tcs := []struct {
 		description string
@@ -47,6 +58,9 @@ func TestTasks_TasksCreate(t *testing.T) {
 		{
 			description: "Positive case: valid request body; POST method",
 			taskDetails: model.Task{Method: "POST", URL: "https://petstore.swagger.io/v2/pet",
+				Headers: map[string]interface{}{
+					"Content-Type": "application/json",
+				},
 				Data: map[string]interface{}{
 					"id": 0,
 					"category": map[string]interface{}{
@@ -74,10 +88,41 @@ func TestTasks_TasksCreate(t *testing.T) {
 			description: "Negative case: invalid request body; wrong scheme in url",
 			taskDetails: model.Task{Method: "GET", URL: "ftp://www.getyourtasks.com/task"},
 			taskID:      "2313",
-			expErr:      errors.New("Invalid URL: only the following schemes are supported: [http, https]"),
+			expErr:      invalidRequest("/url", model.RuleEnum, "Invalid URL: only the following schemes are supported: [http, https]"),
+		},
+		{
+			description: "Negative case: unknown tlsProfile",
+			taskDetails: model.Task{Method: "GET", URL: "https://www.getyourtasks.com/task", TLSProfile: "partner"},
+			taskID:      "2313",
+			expErr:      invalidRequest("/tlsProfile", model.RuleReference, "Invalid request: unknown tlsProfile partner"),
+		},
+		{
+			description: "Negative case: unknown secret",
+			taskDetails: model.Task{Method: "GET", URL: "https://www.getyourtasks.com/task?key=${secret:partner.key}"},
+			taskID:      "2313",
+			expErr:      invalidRequest("/url", model.RuleReference, "Invalid request: unknown secret partner.key"),
+		},
+		{
+			description: "Negative case: every violation of the request and of its references at once",
+			taskDetails: model.Task{Method: "FETCH", URL: "https://www.getyourtasks.com/task", TLSProfile: "partner",
+				Headers: map[string]interface{}{"X-Key": "${secret:partner.key}"},
+				Auth:    &model.Auth{Type: model.AuthBearer, Token: "${secret:partner.token}"},
+				Signing: &model.Signing{Type: model.SigningHMAC, Scheme: "partner", KeyID: "key", Secret: "secret"},
+			},
+			taskID: "2313",
+			expErr: validationError(&model.ValidationError{Violations: []model.Violation{
+				{Pointer: "/method", Rule: model.RuleEnum, Message: "Invalid request: only the following methods are supported: [GET, PATCH, POST, PUT, DELETE]"},
+				{Pointer: "/tlsProfile", Rule: model.RuleReference, Message: "Invalid request: unknown tlsProfile partner"},
+				{Pointer: "/signing/scheme", Rule: model.RuleReference, Message: "Invalid request: unknown signing scheme partner"},
+				{Pointer: "/headers/X-Key", Rule: model.RuleReference, Message: "Invalid request: unknown secret partner.key"},
+				{Pointer: "/auth/token", Rule: model.RuleReference, Message: "Invalid request: unknown secret partner.token"},
+			}}),
 		},
 	}
 
+	// the background calls update the tasks till after the test, e.g. failing them as the hosts do not resolve
+	cacheMock.EXPECT().StoreTask(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
 	for _, tc := range tcs {
 		tc := tc
 
@@ -93,6 +138,159 @@ func TestTasks_TasksCreate(t *testing.T) {
 	}
 }
 
+func TestTasks_TasksCreate_PolicyDenied(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	file := filepath.Join(t.TempDir(), "policy.json")
+	assert.Nil(t, os.WriteFile(file, []byte(`{"rules": [{"name": "no-admin", "action": "deny", "pathPrefixes": ["/admin"]}]}`), 0o600))
+
+	engine, err := policy.New(file, 0)
+	assert.Nil(t, err)
+
+	task := New(cache.NewMockCache(ctrl), breaker.New(breaker.Settings{}), Settings{Policy: engine})
+
+	resp, err := task.TasksCreate(context.TODO(), model.Task{Method: "GET", URL: "https://www.getyourtasks.com/admin"})
+
+	assert.Equal(t, invalidRequest("/url", model.RuleInvalid, "Invalid request: destination denied by policy rule no-admin"), err)
+	assert.Nil(t, resp)
+
+	// the token endpoint of oauth2 is checked as well
+	resp, err = task.TasksCreate(context.TODO(), model.Task{Method: "GET", URL: "https://www.getyourtasks.com/orders",
+		Auth: &model.Auth{Type: model.AuthOAuth2, TokenURL: "https://auth.getyourtasks.com/admin/token", ClientID: "client",
+			ClientSecret: "secret"}})
+
+	assert.Equal(t, invalidRequest("/auth/tokenUrl", model.RuleInvalid, "Invalid request: destination denied by policy rule no-admin"), err)
+	assert.Nil(t, resp)
+}
+
+func TestTasks_TasksCheck(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	file := filepath.Join(t.TempDir(), "policy.json")
+	assert.Nil(t, os.WriteFile(file, []byte(`{"rules": [{"name": "no-admin", "action": "deny", "pathPrefixes": ["/admin"]}]}`), 0o600))
+
+	engine, err := policy.New(file, 0)
+	assert.Nil(t, err)
+
+	task := New(cache.NewMockCache(ctrl), breaker.New(breaker.Settings{}), Settings{Policy: engine})
+
+	tcs := []struct {
+		description string
+		req         model.Task
+		expErr      error
+	}{
+		{
+			description: "Positive case: valid task",
+			req:         model.Task{Method: "GET", URL: "https://www.getyourtasks.com/orders"},
+		},
+		{
+			description: "Negative case: destination denied by policy",
+			req:         model.Task{Method: "GET", URL: "https://www.getyourtasks.com/admin"},
+			expErr:      invalidRequest("/url", model.RuleInvalid, "Invalid request: destination denied by policy rule no-admin"),
+		},
+		{
+			description: "Negative case: fan-out target denied by policy",
+			req: model.Task{Method: "GET", URL: "https://{{.host}}/admin",
+				FanOut: &model.FanOut{Hosts: []string{"eu.partner.com", "us.partner.com"}}},
+			expErr: validationError(&model.ValidationError{Violations: []model.Violation{
+				{Pointer: "/fanOut/hosts/0", Rule: model.RuleInvalid, Message: "Invalid fanOut target 0: Invalid request: destination denied by policy rule no-admin"},
+				{Pointer: "/fanOut/hosts/1", Rule: model.RuleInvalid, Message: "Invalid fanOut target 1: Invalid request: destination denied by policy rule no-admin"},
+			}}),
+		},
+		{
+			description: "Negative case: unknown tlsProfile of the fan-out targets is reported once",
+			req: model.Task{Method: "GET", TLSProfile: "partner",
+				FanOut: &model.FanOut{URLs: []string{"https://eu.partner.com", "https://us.partner.com"}}},
+			expErr: invalidRequest("/tlsProfile", model.RuleReference, "Invalid request: unknown tlsProfile partner"),
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, tc.expErr, task.TasksCheck(context.TODO(), tc.req))
+		})
+	}
+}
+
+func TestTasks_TasksRun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+
+		_, _ = w.Write([]byte(`{"access_token": "abc"}`))
+	}))
+	defer server.Close()
+
+	cacheMock := cache.NewMockCache(ctrl)
+	cacheMock.EXPECT().GetRateLimit(gomock.Any(), gomock.Any()).Return(&model.RateLimit{}, nil).AnyTimes()
+	cacheMock.EXPECT().StoreTask(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	guard, _ := ssrf.New([]string{"127.0.0.0/8"})
+	task := New(cacheMock, breaker.New(breaker.Settings{}), Settings{Guard: guard})
+
+	taskObj, body, err := task.TasksRun(context.TODO(), model.Task{Method: "GET", URL: server.URL + "/login", ChainID: "chain-1"})
+	assert.Nil(t, err)
+	assert.Equal(t, model.Done, taskObj.Status)
+	assert.Equal(t, "chain-1", taskObj.ChainID)
+	assert.Equal(t, `{"access_token": "abc"}`, string(body))
+
+	taskObj, _, err = task.TasksRun(context.TODO(), model.Task{Method: "GET", URL: server.URL + "/missing"})
+	assert.Nil(t, err)
+	assert.Equal(t, model.Error, taskObj.Status)
+	assert.Equal(t, http.StatusNotFound, *taskObj.HTTPStatusCode)
+
+	_, _, err = task.TasksRun(context.TODO(), model.Task{Method: "GET"})
+	assert.Equal(t, invalidRequest("/url", model.RuleRequired, "Invalid request: url cannot be empty"), err)
+}
+
+func TestTasks_TasksRun_cancelled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	var stored *model.TasksObject
+
+	cacheMock := cache.NewMockCache(ctrl)
+	cacheMock.EXPECT().GetRateLimit(gomock.Any(), gomock.Any()).Return(&model.RateLimit{}, nil).AnyTimes()
+	cacheMock.EXPECT().StoreTask(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, _ string, taskObj *model.TasksObject) error {
+		// the failure is stored even though the task's context is done
+		if ctx.Err() == nil {
+			copied := *taskObj
+			stored = &copied
+		}
+
+		return nil
+	}).AnyTimes()
+
+	guard, _ := ssrf.New([]string{"127.0.0.0/8"})
+	breakers := breaker.New(breaker.Settings{ConsecutiveFailures: 1})
+	task := New(cacheMock, breakers, Settings{Guard: guard})
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	time.AfterFunc(20*time.Millisecond, cancel)
+
+	taskObj, _, err := task.TasksRun(ctx, model.Task{Method: "GET", URL: server.URL + "/orders"})
+	assert.Nil(t, err)
+	assert.Equal(t, model.Error, taskObj.Status)
+	assert.Equal(t, model.ErrCodeCallFailed, taskObj.Error.Code)
+	assert.Equal(t, model.Error, stored.Status)
+
+	// a cancelled call says nothing about the health of the host
+	assert.Equal(t, model.CircuitClosed, breakers.State("127.0.0.1").State)
+}
+
 func TestTasks_TasksGet(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -122,17 +320,25 @@ func TestTasks_TasksGet(t *testing.T) {
 			taskID: "123122",
 			resp:   taskData,
 		},
+		{
+			description: "Negative case: task not found",
+			mockCalls: []*gomock.Call{
+				cacheMock.EXPECT().GetTask(gomock.Any(), "123123").Return(&model.TasksObject{}, nil),
+			},
+			taskID: "123123",
+			expErr: errTaskNotFound,
+		},
 		{
 			description: "Negative case: error from cache",
 			mockCalls: []*gomock.Call{
 				cacheMock.EXPECT().GetTask(gomock.Any(), "!@#!").Return(nil, errors.New("DB error")),
 			},
 			taskID: "!@#!",
-			expErr: errors.New("DB error"),
+			expErr: unavailableError(errors.New("DB error")),
 		},
 	}
 
-	task := New(cacheMock)
+	task := New(cacheMock, breaker.New(breaker.Settings{}), Settings{})
 
 	for _, tc := range tcs {
 		tc := tc
@@ -145,3 +351,66 @@ func TestTasks_TasksGet(t *testing.T) {
 		})
 	}
 }
+
+func TestTasks_TasksList(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cacheMock := cache.NewMockCache(ctrl)
+
+	taskData := []*model.TasksObject{{ID: "123122", Status: model.Done}}
+
+	tcs := []struct {
+		description string
+		offset      int
+		limit       int
+		resp        []*model.TasksObject
+		mockCalls   []*gomock.Call
+		expNext     int
+		expErr      error
+	}{
+		{
+			description: "Positive case: a page of tasks",
+			mockCalls: []*gomock.Call{
+				cacheMock.EXPECT().ListTasks(gomock.Any(), int64(20), int64(10)).Return(taskData, int64(31), nil),
+			},
+			offset:  20,
+			limit:   10,
+			resp:    taskData,
+			expNext: 31,
+		},
+		{
+			description: "Negative case: limit too large",
+			limit:       101,
+			expErr:      validationError(errors.New("Invalid request: limit should be between 1 and 100")),
+		},
+		{
+			description: "Negative case: negative offset",
+			offset:      -1,
+			limit:       10,
+			expErr:      validationError(errors.New("Invalid request: offset cannot be negative")),
+		},
+		{
+			description: "Negative case: error from cache",
+			mockCalls: []*gomock.Call{
+				cacheMock.EXPECT().ListTasks(gomock.Any(), int64(0), int64(10)).Return(nil, int64(0), errors.New("DB error")),
+			},
+			limit:  10,
+			expErr: unavailableError(errors.New("DB error")),
+		},
+	}
+
+	task := New(cacheMock, breaker.New(breaker.Settings{}), Settings{})
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			resp, next, err := task.TasksList(context.TODO(), tc.offset, tc.limit)
+
+			assert.Equal(t, tc.expErr, err)
+			assert.Equal(t, tc.resp, resp)
+			assert.Equal(t, tc.expNext, next)
+		})
+	}
+}
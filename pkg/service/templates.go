@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+
+	"github.com/axxonsoft-assignment/pkg/cache"
+	"github.com/axxonsoft-assignment/pkg/model"
+)
+
+var errTemplateNotFound = notFoundError("Template not found")
+
+type templates struct {
+	cache cache.Cache
+	tasks Tasks
+}
+
+func NewTemplates(cache cache.Cache, tasks Tasks) Templates {
+	return &templates{cache: cache, tasks: tasks}
+}
+
+// TemplatesSet creates or replaces the named task template.
+func (t templates) TemplatesSet(ctx context.Context, name string, tmpl model.TaskTemplate) (*model.TaskTemplate, error) {
+	tmpl.Name = name
+
+	if err := model.ValidateTemplate(tmpl); err != nil {
+		return nil, validationError(err)
+	}
+
+	if err := t.cache.StoreTemplate(ctx, &tmpl); err != nil {
+		return nil, unavailableError(err)
+	}
+
+	return &tmpl, nil
+}
+
+// TemplatesGet gives the task template given its name.
+func (t templates) TemplatesGet(ctx context.Context, name string) (*model.TaskTemplate, error) {
+	tmpl, err := t.cache.GetTemplate(ctx, name)
+	if err != nil {
+		return nil, unavailableError(err)
+	}
+
+	if tmpl.Name == "" {
+		return nil, errTemplateNotFound
+	}
+
+	return tmpl, nil
+}
+
+// TemplatesList gives all the task templates.
+func (t templates) TemplatesList(ctx context.Context) ([]*model.TaskTemplate, error) {
+	tmpls, err := t.cache.ListTemplates(ctx)
+
+	return tmpls, unavailableError(err)
+}
+
+// TemplatesDelete removes the task template, tasks already created from it are retained.
+func (t templates) TemplatesDelete(ctx context.Context, name string) error {
+	if _, err := t.TemplatesGet(ctx, name); err != nil {
+		return err
+	}
+
+	return unavailableError(t.cache.DeleteTemplate(ctx, name))
+}
+
+// TemplatesCreateTask renders the template with the variables and creates a task of the result, which is
+// validated as any other task and records the template it was rendered from.
+func (t templates) TemplatesCreateTask(ctx context.Context, name string, variables map[string]interface{}) (*model.TasksResponse, error) {
+	tmpl, err := t.TemplatesGet(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	task, err := tmpl.Render(variables)
+	if err != nil {
+		return nil, validationError(err)
+	}
+
+	task.Template = tmpl.Name
+
+	return t.tasks.TasksCreate(ctx, task)
+}
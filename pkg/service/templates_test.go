@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/axxonsoft-assignment/pkg/cache"
+	"github.com/axxonsoft-assignment/pkg/model"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTemplates_TemplatesSet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cacheMock := cache.NewMockCache(ctrl)
+	templates := NewTemplates(cacheMock, NewMockTasks(ctrl))
+
+	tmpl := model.TaskTemplate{Task: model.Task{Method: "GET", URL: "https://{{.region}}.partner.com/health"}}
+
+	cacheMock.EXPECT().StoreTemplate(gomock.Any(), &model.TaskTemplate{Name: "health", Task: tmpl.Task}).Return(nil)
+
+	resp, err := templates.TemplatesSet(context.TODO(), "health", tmpl)
+	assert.Nil(t, err)
+	assert.Equal(t, "health", resp.Name)
+
+	_, err = templates.TemplatesSet(context.TODO(), "health", model.TaskTemplate{Task: model.Task{Method: "GET"}})
+	assert.Equal(t, invalidRequest("/task/url", model.RuleRequired, "Invalid request: url cannot be empty"), err)
+}
+
+func TestTemplates_TemplatesCreateTask(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cacheMock := cache.NewMockCache(ctrl)
+	tasksMock := NewMockTasks(ctrl)
+
+	templates := NewTemplates(cacheMock, tasksMock)
+
+	tmpl := &model.TaskTemplate{Name: "health", Task: model.Task{Method: "GET", URL: "https://{{.region}}.partner.com/health"}}
+
+	tcs := []struct {
+		description string
+		name        string
+		variables   map[string]interface{}
+		mockCalls   []*gomock.Call
+		resp        *model.TasksResponse
+		expErr      error
+	}{
+		{
+			description: "Positive case: rendered task is created",
+			name:        "health",
+			variables:   map[string]interface{}{"region": "eu"},
+			mockCalls: []*gomock.Call{
+				cacheMock.EXPECT().GetTemplate(gomock.Any(), "health").Return(tmpl, nil),
+				tasksMock.EXPECT().TasksCreate(gomock.Any(), model.Task{Method: "GET", URL: "https://eu.partner.com/health",
+					Template: "health"}).Return(&model.TasksResponse{ID: "2313"}, nil),
+			},
+			resp: &model.TasksResponse{ID: "2313"},
+		},
+		{
+			description: "Negative case: missing variable",
+			name:        "health",
+			mockCalls: []*gomock.Call{
+				cacheMock.EXPECT().GetTemplate(gomock.Any(), "health").Return(tmpl, nil),
+			},
+			expErr: validationError(errors.New(`Invalid request: cannot render url: template: url:1:10: executing "url" at <.region>: map has no entry for key "region"`)),
+		},
+		{
+			description: "Negative case: template not found",
+			name:        "other",
+			mockCalls: []*gomock.Call{
+				cacheMock.EXPECT().GetTemplate(gomock.Any(), "other").Return(&model.TaskTemplate{}, nil),
+			},
+			expErr: errTemplateNotFound,
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			resp, err := templates.TemplatesCreateTask(context.TODO(), tc.name, tc.variables)
+
+			assert.Equal(t, tc.expErr, err)
+			assert.Equal(t, tc.resp, resp)
+		})
+	}
+}
@@ -0,0 +1,389 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/axxonsoft-assignment/pkg/cache"
+	"github.com/axxonsoft-assignment/pkg/model"
+	"github.com/google/uuid"
+)
+
+const (
+	// workflowLease is how long an instance keeps a workflow without renewing it, before another instance resumes it
+	workflowLease = 30 * time.Second
+	// nodePollInterval is how often the task of a running node is checked for its final status
+	nodePollInterval = time.Second
+)
+
+var errWorkflowNotFound = notFoundError("Workflow not found")
+
+type workflows struct {
+	cache        cache.Cache
+	tasks        Tasks
+	pollInterval time.Duration
+}
+
+// nodeResult is the outcome of the task of a node, sent back to the go routine running the workflow
+type nodeResult struct {
+	index   int
+	taskObj *model.TasksObject
+	err     error
+}
+
+func NewWorkflows(cache cache.Cache, tasks Tasks) Workflows {
+	return &workflows{cache: cache, tasks: tasks, pollInterval: nodePollInterval}
+}
+
+// WorkflowsCreate validates the workflow, stores it in the cache and runs its nodes in the background.
+func (w workflows) WorkflowsCreate(ctx context.Context, workflow model.Workflow) (*model.Workflow, error) {
+	if err := model.ValidateWorkflow(workflow); err != nil {
+		return nil, validationError(err)
+	}
+
+	workflow.ID = uuid.New().String()
+	workflow.Status = model.New
+	workflow.Error = nil
+
+	for i := range workflow.Nodes {
+		workflow.Nodes[i].Status = model.New
+		workflow.Nodes[i].TaskID = ""
+		workflow.Nodes[i].Result = nil
+		workflow.Nodes[i].Error = nil
+	}
+
+	// the workflow is claimed before it is stored, so that no other instance resumes it meanwhile
+	holderID := uuid.New().String()
+	if _, err := w.cache.ClaimWorkflow(ctx, workflow.ID, holderID, workflowLease); err != nil {
+		return nil, unavailableError(err)
+	}
+
+	if err := w.cache.StoreWorkflow(ctx, &workflow); err != nil {
+		return nil, unavailableError(err)
+	}
+
+	// the running workflow gets its own copy of the nodes, as the returned workflow is marshalled meanwhile
+	running := workflow
+	running.Nodes = append([]model.WorkflowNode(nil), workflow.Nodes...)
+
+	go w.run(ctx, &running, holderID)
+
+	return &workflow, nil
+}
+
+// WorkflowsGet gives the workflow details given a workflowID, along with the task details of each node run so far.
+func (w workflows) WorkflowsGet(ctx context.Context, workflowID string) (*model.Workflow, error) {
+	workflow, err := w.cache.GetWorkflow(ctx, workflowID)
+	if err != nil {
+		return nil, unavailableError(err)
+	}
+
+	if workflow.ID == "" {
+		return nil, errWorkflowNotFound
+	}
+
+	for i := range workflow.Nodes {
+		if workflow.Nodes[i].TaskID == "" {
+			continue
+		}
+
+		// the task of a node may have expired before the workflow
+		workflow.Nodes[i].Result, err = w.tasks.TasksGet(ctx, workflow.Nodes[i].TaskID)
+		if err != nil && KindOf(err) != KindNotFound {
+			return nil, err
+		}
+	}
+
+	return workflow, nil
+}
+
+// WorkflowsResume runs in the background the active workflows nobody holds, e.g. the ones left by a restarted instance.
+func (w workflows) WorkflowsResume(ctx context.Context) error {
+	active, err := w.cache.ListActiveWorkflows(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, workflow := range active {
+		holderID := uuid.New().String()
+
+		claimed, err := w.cache.ClaimWorkflow(ctx, workflow.ID, holderID, workflowLease)
+		if err != nil {
+			return err
+		}
+
+		if !claimed {
+			continue
+		}
+
+		log.Printf("Resuming workflow:%s", workflow.ID)
+
+		go w.run(ctx, workflow, holderID)
+	}
+
+	return nil
+}
+
+// run starts the nodes whose dependencies reached a terminal status till every node did, as long as the holder
+// keeps the workflow's lease. The task of a node is recorded as soon as it is created, so that the nodes left in
+// process by a previous holder wait for their task instead of running it again; a node left without one is run.
+func (w workflows) run(ctx context.Context, workflow *model.Workflow, holderID string) {
+	leaseCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	go w.renew(leaseCtx, cancel, workflow.ID, holderID)
+
+	defer func() {
+		// the cache layer logs the failure to release the lease, it expires anyway
+		_ = w.cache.ReleaseWorkflow(ctx, workflow.ID, holderID)
+	}()
+
+	// the results are buffered, so that the waits in flight do not block once the lease is lost
+	results := make(chan nodeResult, len(workflow.Nodes))
+	running := 0
+
+	for i := range workflow.Nodes {
+		node := &workflow.Nodes[i]
+		if node.Status != model.InProcess {
+			continue
+		}
+
+		if node.TaskID == "" {
+			node.Status = model.New
+
+			continue
+		}
+
+		log.Printf("Workflow:%s node %s waits for its task:%s", workflow.ID, node.ID, node.TaskID)
+
+		running++
+
+		go w.wait(leaseCtx, i, node.TaskID, results)
+	}
+
+	workflow.Status = model.InProcess
+
+	for {
+		// the tasks of the nodes are bound to the lease, they are cancelled along with it
+		running += w.startReady(leaseCtx, workflow, results)
+
+		if leaseCtx.Err() != nil {
+			return
+		}
+
+		w.store(ctx, workflow)
+
+		if running == 0 {
+			break
+		}
+
+		select {
+		case <-leaseCtx.Done():
+			return
+		case result := <-results:
+			running--
+
+			node := &workflow.Nodes[result.index]
+
+			switch {
+			case result.err != nil:
+				log.Printf("Workflow:%s node %s failed: %v", workflow.ID, node.ID, result.err)
+
+				node.Status = model.Error
+				node.Error = &model.TaskError{Code: model.ErrCodeInvalidRequest, Message: result.err.Error()}
+			case result.taskObj.Status != model.Done:
+				node.Status = model.Error
+			default:
+				node.Status = model.Done
+			}
+		}
+	}
+
+	workflow.Status = model.Done
+	workflow.Error = unhandledFailure(workflow)
+
+	if workflow.Error != nil {
+		workflow.Status = model.Error
+	}
+
+	w.store(ctx, workflow)
+}
+
+// startReady creates the task of every node whose dependencies reached a terminal status and skips the nodes whose
+// dependencies' conditions are not met, giving the number of nodes started. Skipping a node may get the nodes
+// depending on it ready, so the nodes are checked again till none is. The task of a started node runs in the
+// background, its final status being sent back once it reaches one.
+func (w workflows) startReady(ctx context.Context, workflow *model.Workflow, results chan<- nodeResult) int {
+	started := 0
+
+	for changed := true; changed; {
+		changed = false
+
+		statuses := make(map[string]string, len(workflow.Nodes))
+		for _, node := range workflow.Nodes {
+			statuses[node.ID] = node.Status
+		}
+
+		for i := range workflow.Nodes {
+			node := &workflow.Nodes[i]
+			if node.Status != model.New {
+				continue
+			}
+
+			ready, met := dependenciesMet(node.DependsOn, statuses)
+			if !ready {
+				continue
+			}
+
+			changed = true
+
+			if !met {
+				node.Status = model.Skipped
+				statuses[node.ID] = model.Skipped
+
+				continue
+			}
+
+			node.Status = model.InProcess
+			statuses[node.ID] = model.InProcess
+			started++
+
+			task := node.Task
+			task.WorkflowID = workflow.ID
+
+			resp, err := w.tasks.TasksCreate(ctx, task)
+			if err != nil {
+				results <- nodeResult{index: i, err: err}
+
+				continue
+			}
+
+			node.TaskID = resp.ID
+
+			go w.wait(ctx, i, resp.ID, results)
+		}
+	}
+
+	return started
+}
+
+// wait checks the task of the node till it reaches a terminal status, sending it back as the node's result. It gives
+// up once the context is done, e.g. when the workflow's lease is lost.
+func (w workflows) wait(ctx context.Context, index int, taskID string, results chan<- nodeResult) {
+	for {
+		taskObj, err := w.tasks.TasksGet(ctx, taskID)
+		if err != nil && KindOf(err) != KindUnavailable {
+			results <- nodeResult{index: index, err: err}
+
+			return
+		}
+
+		// the cache not being reachable is retried on the next check
+		if err == nil && model.Terminal(taskObj.Status) {
+			results <- nodeResult{index: index, taskObj: taskObj}
+
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(w.pollInterval):
+		}
+	}
+}
+
+// renew keeps the workflow's lease while it runs, cancelling the run and the tasks of its nodes once another holder
+// took it over, or once the lease could not be renewed before it expired.
+func (w workflows) renew(ctx context.Context, cancel context.CancelFunc, workflowID string, holderID string) {
+	ticker := time.NewTicker(workflowLease / 3)
+	defer ticker.Stop()
+
+	renewed := time.Now()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			claimed, err := w.cache.ClaimWorkflow(ctx, workflowID, holderID, workflowLease)
+
+			switch {
+			case err == nil && claimed:
+				renewed = time.Now()
+			case err == nil:
+				log.Printf("Workflow:%s lease lost to another instance", workflowID)
+
+				cancel()
+
+				return
+			case time.Since(renewed) >= workflowLease:
+				// failing to reach the cache is retried on the next tick, till the lease expired anyway
+				log.Printf("Workflow:%s lease expired without being renewed: %v", workflowID, err)
+
+				cancel()
+
+				return
+			}
+		}
+	}
+}
+
+func (w workflows) store(ctx context.Context, workflow *model.Workflow) {
+	// the cache layer logs the failure to store the workflow, the nodes go on regardless
+	_ = w.cache.StoreWorkflow(ctx, workflow)
+}
+
+// dependenciesMet tells if every dependency reached a terminal status, and if so whether all their conditions are met
+func dependenciesMet(dependencies []model.Dependency, statuses map[string]string) (bool, bool) {
+	met := true
+
+	for _, dependency := range dependencies {
+		status := statuses[dependency.Node]
+		if !model.Terminal(status) {
+			return false, false
+		}
+
+		switch dependency.Condition {
+		case model.DependsOnAlways:
+		case model.DependsOnFailure:
+			met = met && status == model.Error
+		default:
+			met = met && status == model.Done
+		}
+	}
+
+	return true, met
+}
+
+// unhandledFailure gives the error of the first failed node no other node handles, i.e. depends on with the
+// condition failure or always
+func unhandledFailure(workflow *model.Workflow) *model.TaskError {
+	handled := map[string]bool{}
+
+	for _, node := range workflow.Nodes {
+		for _, dependency := range node.DependsOn {
+			if dependency.Condition == model.DependsOnFailure || dependency.Condition == model.DependsOnAlways {
+				handled[dependency.Node] = true
+			}
+		}
+	}
+
+	for _, node := range workflow.Nodes {
+		if node.Status != model.Error || handled[node.ID] {
+			continue
+		}
+
+		message := "node " + node.ID + ": "
+		if node.Error != nil {
+			message += node.Error.Message
+		} else {
+			message += "task " + node.TaskID + " ended with status " + model.Error
+		}
+
+		return &model.TaskError{Code: model.ErrCodeNodeFailed, Message: message}
+	}
+
+	return nil
+}
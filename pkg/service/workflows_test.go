@@ -0,0 +1,255 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/axxonsoft-assignment/pkg/cache"
+	"github.com/axxonsoft-assignment/pkg/model"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkflows_WorkflowsCreate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	workflows := NewWorkflows(cache.NewMockCache(ctrl), NewMockTasks(ctrl))
+
+	_, err := workflows.WorkflowsCreate(context.TODO(), model.Workflow{Nodes: []model.WorkflowNode{
+		{ID: "a", Task: model.Task{Method: "GET", URL: "https://www.getyourtasks.com/a"}, DependsOn: []model.Dependency{{Node: "a"}}},
+	}})
+	assert.Equal(t, invalidRequest("/nodes/0/dependsOn", model.RuleCycle, "Invalid request: dependsOn forms a cycle through node a"), err)
+}
+
+func TestWorkflows_run(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cacheMock := cache.NewMockCache(ctrl)
+	tasksMock := NewMockTasks(ctrl)
+
+	w := &workflows{cache: cacheMock, tasks: tasksMock, pollInterval: time.Millisecond}
+
+	node := func(id string, dependsOn ...model.Dependency) model.WorkflowNode {
+		return model.WorkflowNode{ID: id, Task: model.Task{Method: "GET", URL: "https://www.getyourtasks.com/" + id},
+			DependsOn: dependsOn, Status: model.New}
+	}
+
+	// a, then b on its success or c on its failure, then d whatever happened to them
+	graph := []model.WorkflowNode{
+		node("a"),
+		node("b", model.Dependency{Node: "a"}),
+		node("c", model.Dependency{Node: "a", Condition: model.DependsOnFailure}),
+		node("d", model.Dependency{Node: "b", Condition: model.DependsOnAlways}, model.Dependency{Node: "c", Condition: model.DependsOnAlways}),
+	}
+
+	tcs := []struct {
+		description string
+		nodes       []model.WorkflowNode
+		failing     string
+		expStatus   string
+		expRun      []string
+		expWaited   []string
+		expNodes    map[string]string
+		expErr      *model.TaskError
+	}{
+		{
+			description: "Positive case: the success branch is run",
+			nodes:       graph,
+			expStatus:   model.Done,
+			expRun:      []string{"a", "b", "d"},
+			expWaited:   []string{"a", "b", "d"},
+			expNodes:    map[string]string{"a": model.Done, "b": model.Done, "c": model.Skipped, "d": model.Done},
+		},
+		{
+			description: "Positive case: the failure branch handles the failed node",
+			nodes:       graph,
+			failing:     "a",
+			expStatus:   model.Done,
+			expRun:      []string{"a", "c", "d"},
+			expWaited:   []string{"a", "c", "d"},
+			expNodes:    map[string]string{"a": model.Error, "b": model.Skipped, "c": model.Done, "d": model.Done},
+		},
+		{
+			description: "Positive case: the resumed workflow runs the nodes left in process without a task",
+			nodes: []model.WorkflowNode{
+				{ID: "a", Task: model.Task{Method: "GET", URL: "https://www.getyourtasks.com/a"}, Status: model.Done, TaskID: "task-a"},
+				{ID: "b", Task: model.Task{Method: "GET", URL: "https://www.getyourtasks.com/b"}, Status: model.InProcess,
+					DependsOn: []model.Dependency{{Node: "a"}}},
+			},
+			expStatus: model.Done,
+			expRun:    []string{"b"},
+			expWaited: []string{"b"},
+			expNodes:  map[string]string{"a": model.Done, "b": model.Done},
+		},
+		{
+			description: "Positive case: the resumed workflow waits for the task of a node left in process instead of running it",
+			nodes: []model.WorkflowNode{
+				{ID: "a", Task: model.Task{Method: "POST", URL: "https://www.getyourtasks.com/a"}, Status: model.InProcess, TaskID: "task-a"},
+				node("b", model.Dependency{Node: "a"}),
+			},
+			expStatus: model.Done,
+			expRun:    []string{"b"},
+			expWaited: []string{"a", "b"},
+			expNodes:  map[string]string{"a": model.Done, "b": model.Done},
+		},
+		{
+			description: "Negative case: a failed node nobody handles fails the workflow",
+			nodes:       []model.WorkflowNode{node("a"), node("b", model.Dependency{Node: "a"})},
+			failing:     "a",
+			expStatus:   model.Error,
+			expRun:      []string{"a"},
+			expWaited:   []string{"a"},
+			expNodes:    map[string]string{"a": model.Error, "b": model.Skipped},
+			expErr:      &model.TaskError{Code: model.ErrCodeNodeFailed, Message: "node a: task task-a ended with status error"},
+		},
+	}
+
+	var (
+		mu     sync.Mutex
+		run    []string
+		waited []string
+		stored *model.Workflow
+		// the task of every node started is stored with the workflow while the node is still in process
+		recorded map[string]string
+	)
+
+	cacheMock.EXPECT().ClaimWorkflow(gomock.Any(), "workflow-1", "holder-1", gomock.Any()).Return(true, nil).AnyTimes()
+	cacheMock.EXPECT().ReleaseWorkflow(gomock.Any(), "workflow-1", "holder-1").Return(nil).AnyTimes()
+	cacheMock.EXPECT().StoreWorkflow(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, workflow *model.Workflow) error {
+		stored = workflow
+
+		for _, node := range workflow.Nodes {
+			if node.Status == model.InProcess {
+				recorded[node.ID] = node.TaskID
+			}
+		}
+
+		return nil
+	}).AnyTimes()
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			run, waited, recorded = nil, nil, map[string]string{}
+
+			tasksMock.EXPECT().TasksCreate(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, task model.Task) (*model.TasksResponse, error) {
+				id := strings.TrimPrefix(task.URL, "https://www.getyourtasks.com/")
+
+				assert.Equal(t, "workflow-1", task.WorkflowID)
+
+				mu.Lock()
+				run = append(run, id)
+				mu.Unlock()
+
+				return &model.TasksResponse{ID: "task-" + id}, nil
+			}).Times(len(tc.expRun))
+
+			// the task is in process on the first check, then done or failed
+			checks := map[string]int{}
+
+			tasksMock.EXPECT().TasksGet(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, taskID string) (*model.TasksObject, error) {
+				id := strings.TrimPrefix(taskID, "task-")
+
+				mu.Lock()
+				defer mu.Unlock()
+
+				checks[id]++
+
+				switch {
+				case checks[id] == 1:
+					waited = append(waited, id)
+
+					return &model.TasksObject{ID: taskID, Status: model.InProcess}, nil
+				case id == tc.failing:
+					return &model.TasksObject{ID: taskID, Status: model.Error}, nil
+				default:
+					return &model.TasksObject{ID: taskID, Status: model.Done}, nil
+				}
+			}).Times(2 * len(tc.expWaited))
+
+			workflow := &model.Workflow{ID: "workflow-1", Status: model.InProcess, Nodes: append([]model.WorkflowNode(nil), tc.nodes...)}
+
+			w.run(context.TODO(), workflow, "holder-1")
+
+			assert.Equal(t, tc.expStatus, stored.Status)
+			assert.Equal(t, tc.expErr, stored.Error)
+			assert.ElementsMatch(t, tc.expRun, run)
+			assert.ElementsMatch(t, tc.expWaited, waited)
+
+			for id, taskID := range recorded {
+				assert.Equal(t, "task-"+id, taskID, id)
+			}
+
+			for _, node := range stored.Nodes {
+				assert.Equal(t, tc.expNodes[node.ID], node.Status, node.ID)
+			}
+		})
+	}
+}
+
+func TestWorkflows_WorkflowsResume(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cacheMock := cache.NewMockCache(ctrl)
+
+	held := &model.Workflow{ID: "workflow-1", Status: model.InProcess}
+	left := &model.Workflow{ID: "workflow-2", Status: model.InProcess, Nodes: []model.WorkflowNode{
+		{ID: "a", Task: model.Task{Method: "GET", URL: "https://www.getyourtasks.com/a"}, Status: model.Done, TaskID: "task-a"},
+	}}
+
+	finished := make(chan *model.Workflow, 1)
+	released := make(chan struct{})
+
+	cacheMock.EXPECT().ListActiveWorkflows(gomock.Any()).Return([]*model.Workflow{held, left}, nil)
+	cacheMock.EXPECT().ClaimWorkflow(gomock.Any(), "workflow-1", gomock.Any(), workflowLease).Return(false, nil)
+	cacheMock.EXPECT().ClaimWorkflow(gomock.Any(), "workflow-2", gomock.Any(), workflowLease).Return(true, nil)
+	cacheMock.EXPECT().ReleaseWorkflow(gomock.Any(), "workflow-2", gomock.Any()).DoAndReturn(func(context.Context, string, string) error {
+		close(released)
+
+		return nil
+	})
+	cacheMock.EXPECT().StoreWorkflow(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, workflow *model.Workflow) error {
+		if model.Terminal(workflow.Status) {
+			finished <- workflow
+		}
+
+		return nil
+	}).AnyTimes()
+
+	w := NewWorkflows(cacheMock, NewMockTasks(ctrl))
+
+	assert.Nil(t, w.WorkflowsResume(context.TODO()))
+
+	// only the workflow nobody holds is resumed, its nodes are all done already
+	<-released
+
+	workflow := <-finished
+	assert.Equal(t, "workflow-2", workflow.ID)
+	assert.Equal(t, model.Done, workflow.Status)
+}
+
+func TestWorkflows_wait(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tasksMock := NewMockTasks(ctrl)
+	tasksMock.EXPECT().TasksGet(gomock.Any(), "task-a").Return(&model.TasksObject{ID: "task-a", Status: model.InProcess}, nil).AnyTimes()
+
+	w := &workflows{tasks: tasksMock, pollInterval: time.Millisecond}
+
+	// the wait gives up along with the lease, leaving the node to the next holder
+	ctx, cancel := context.WithTimeout(context.TODO(), 20*time.Millisecond)
+	defer cancel()
+
+	results := make(chan nodeResult, 1)
+	w.wait(ctx, 0, "task-a", results)
+
+	assert.Empty(t, results)
+}
@@ -0,0 +1,200 @@
+package signing
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"hash"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/axxonsoft-assignment/pkg/model"
+)
+
+// DefaultScheme signs the method, path, timestamp and body with HMAC-SHA256, sending the hex signature in X-Signature
+var DefaultScheme = model.HMACScheme{Components: []string{"method", "path", "timestamp", "body"}}
+
+var algorithms = map[string]func() hash.Hash{
+	"sha1":   sha1.New,
+	"sha256": sha256.New,
+	"sha512": sha512.New,
+}
+
+type hmacSigner struct {
+	scheme model.HMACScheme
+	keyID  string
+	secret string
+}
+
+// NewHMAC builds a signer of the scheme with the given key
+func NewHMAC(scheme model.HMACScheme, keyID string, secret string) Signer {
+	return &hmacSigner{scheme: withDefaults(scheme), keyID: keyID, secret: secret}
+}
+
+// LoadSchemes reads the named HMAC schemes from a JSON file of name -> scheme, along with the default scheme
+// unless the file overrides it. Without a file there is only the default scheme.
+func LoadSchemes(file string) (map[string]model.HMACScheme, error) {
+	schemes := map[string]model.HMACScheme{model.DefaultSigningScheme: DefaultScheme}
+
+	if file == "" {
+		return schemes, nil
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var fileSchemes map[string]model.HMACScheme
+
+	if err = json.Unmarshal(data, &fileSchemes); err != nil {
+		return nil, errors.New("Invalid HMAC schemes: " + err.Error())
+	}
+
+	for name, scheme := range fileSchemes {
+		if err = ValidateScheme(scheme); err != nil {
+			return nil, errors.New("Invalid HMAC scheme " + name + ": " + err.Error())
+		}
+
+		schemes[name] = scheme
+	}
+
+	return schemes, nil
+}
+
+// ValidateScheme checks the algorithm, encoding, timestamp format and components of the scheme
+func ValidateScheme(scheme model.HMACScheme) error {
+	scheme = withDefaults(scheme)
+
+	if _, ok := algorithms[scheme.Algorithm]; !ok {
+		return errors.New("algorithm should be one of [sha1, sha256, sha512]")
+	}
+
+	if scheme.Encoding != "hex" && scheme.Encoding != "base64" {
+		return errors.New("encoding should be one of [hex, base64]")
+	}
+
+	if scheme.TimestampFormat != "unix" && scheme.TimestampFormat != "unixMillis" && scheme.TimestampFormat != "rfc3339" {
+		return errors.New("timestampFormat should be one of [unix, unixMillis, rfc3339]")
+	}
+
+	if len(scheme.Components) == 0 {
+		return errors.New("components cannot be empty")
+	}
+
+	for _, component := range scheme.Components {
+		switch component {
+		case "method", "host", "path", "query", "body", "bodySha256", "timestamp", "keyId":
+		default:
+			if !strings.HasPrefix(component, "header:") {
+				return errors.New("unknown component " + component)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s hmacSigner) Sign(request *http.Request, body []byte, now time.Time) error {
+	var timestamp string
+
+	switch s.scheme.TimestampFormat {
+	case "unixMillis":
+		timestamp = strconv.FormatInt(now.UnixMilli(), 10)
+	case "rfc3339":
+		timestamp = now.UTC().Format(time.RFC3339)
+	default:
+		timestamp = strconv.FormatInt(now.Unix(), 10)
+	}
+
+	request.Header.Set(s.scheme.TimestampHeader, timestamp)
+
+	if s.scheme.KeyIDHeader != "" {
+		request.Header.Set(s.scheme.KeyIDHeader, s.keyID)
+	}
+
+	parts := make([]string, 0, len(s.scheme.Components))
+
+	for _, component := range s.scheme.Components {
+		switch component {
+		case "method":
+			parts = append(parts, request.Method)
+		case "host":
+			parts = append(parts, requestHost(request))
+		case "path":
+			parts = append(parts, request.URL.EscapedPath())
+		case "query":
+			parts = append(parts, request.URL.RawQuery)
+		case "body":
+			parts = append(parts, string(body))
+		case "bodySha256":
+			sum := sha256.Sum256(body)
+			parts = append(parts, hex.EncodeToString(sum[:]))
+		case "timestamp":
+			parts = append(parts, timestamp)
+		case "keyId":
+			parts = append(parts, s.keyID)
+		default:
+			parts = append(parts, request.Header.Get(strings.TrimPrefix(component, "header:")))
+		}
+	}
+
+	mac := hmac.New(algorithms[s.scheme.Algorithm], []byte(s.secret))
+	mac.Write([]byte(strings.Join(parts, s.scheme.Separator)))
+
+	var signature string
+	if s.scheme.Encoding == "base64" {
+		signature = base64.StdEncoding.EncodeToString(mac.Sum(nil))
+	} else {
+		signature = hex.EncodeToString(mac.Sum(nil))
+	}
+
+	request.Header.Set(s.scheme.Header, s.scheme.Prefix+signature)
+
+	return nil
+}
+
+func withDefaults(scheme model.HMACScheme) model.HMACScheme {
+	if scheme.Algorithm == "" {
+		scheme.Algorithm = "sha256"
+	}
+
+	if scheme.Separator == "" {
+		scheme.Separator = "\n"
+	}
+
+	if scheme.Header == "" {
+		scheme.Header = "X-Signature"
+	}
+
+	if scheme.Encoding == "" {
+		scheme.Encoding = "hex"
+	}
+
+	if scheme.TimestampHeader == "" {
+		scheme.TimestampHeader = "X-Timestamp"
+	}
+
+	if scheme.TimestampFormat == "" {
+		scheme.TimestampFormat = "unix"
+	}
+
+	return scheme
+}
+
+// requestHost gives the host the request is sent to, the Host header if overridden
+func requestHost(request *http.Request) string {
+	if request.Host != "" {
+		return request.Host
+	}
+
+	return request.URL.Host
+}
@@ -0,0 +1,104 @@
+package signing
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/axxonsoft-assignment/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func sign(secret string, data string) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(data))
+
+	return mac.Sum(nil)
+}
+
+func TestHMAC_Sign(t *testing.T) {
+	now := time.Date(2023, 11, 20, 10, 30, 0, 0, time.UTC)
+	body := []byte(`{"name":"task"}`)
+	bodySum := sha256.Sum256(body)
+
+	tcs := []struct {
+		description string
+		scheme      model.HMACScheme
+		expHeaders  map[string]string
+	}{
+		{
+			description: "Positive case: default scheme",
+			scheme:      DefaultScheme,
+			expHeaders: map[string]string{
+				"X-Timestamp": "1700476200",
+				"X-Signature": hex.EncodeToString(sign("secret", "POST\n/items\n1700476200\n"+string(body))),
+			},
+		},
+		{
+			description: "Positive case: custom scheme",
+			scheme: model.HMACScheme{
+				Components:      []string{"keyId", "host", "query", "bodySha256", "header:X-Request-Id", "timestamp"},
+				Separator:       "|",
+				Header:          "Authorization",
+				Prefix:          "HMAC ",
+				Encoding:        "base64",
+				TimestampHeader: "Date",
+				TimestampFormat: "rfc3339",
+				KeyIDHeader:     "X-Key-Id",
+			},
+			expHeaders: map[string]string{
+				"Date":     "2023-11-20T10:30:00Z",
+				"X-Key-Id": "key-1",
+				"Authorization": "HMAC " + base64.StdEncoding.EncodeToString(sign("secret",
+					"key-1|api.partner.com|page=2|"+hex.EncodeToString(bodySum[:])+"|req-1|2023-11-20T10:30:00Z")),
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			request, _ := http.NewRequest(http.MethodPost, "https://api.partner.com/items?page=2", nil)
+			request.Header.Set("X-Request-Id", "req-1")
+
+			err := NewHMAC(tc.scheme, "key-1", "secret").Sign(request, body, now)
+			assert.Nil(t, err)
+
+			for key, value := range tc.expHeaders {
+				assert.Equal(t, value, request.Header.Get(key), key)
+			}
+		})
+	}
+}
+
+func TestHMAC_LoadSchemes(t *testing.T) {
+	schemes, err := LoadSchemes("")
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]model.HMACScheme{model.DefaultSigningScheme: DefaultScheme}, schemes)
+
+	file := filepath.Join(t.TempDir(), "schemes.json")
+	assert.Nil(t, os.WriteFile(file, []byte(`{"partner": {"algorithm": "sha512", "components": ["method", "body"]}}`), 0o600))
+
+	schemes, err = LoadSchemes(file)
+	assert.Nil(t, err)
+	assert.Len(t, schemes, 2)
+	assert.Equal(t, "sha512", schemes["partner"].Algorithm)
+
+	assert.Nil(t, os.WriteFile(file, []byte(`{"partner": {"algorithm": "md5", "components": ["method"]}}`), 0o600))
+
+	_, err = LoadSchemes(file)
+	assert.Equal(t, errors.New("Invalid HMAC scheme partner: algorithm should be one of [sha1, sha256, sha512]"), err)
+
+	assert.Nil(t, os.WriteFile(file, []byte(`{"partner": {"components": ["cookie"]}}`), 0o600))
+
+	_, err = LoadSchemes(file)
+	assert.Equal(t, errors.New("Invalid HMAC scheme partner: unknown component cookie"), err)
+}
@@ -0,0 +1,11 @@
+package signing
+
+import (
+	"net/http"
+	"time"
+)
+
+// Signer signs the outbound request, given its body, by setting the headers of the signature
+type Signer interface {
+	Sign(request *http.Request, body []byte, now time.Time) error
+}
@@ -0,0 +1,201 @@
+package signing
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	sigV4Algorithm  = "AWS4-HMAC-SHA256"
+	sigV4TimeFormat = "20060102T150405Z"
+)
+
+// ignoredHeaders are left out of the signature, as they are set or changed on the way to the target
+var ignoredHeaders = map[string]bool{
+	"authorization":     true,
+	"user-agent":        true,
+	"x-amzn-trace-id":   true,
+	"expect":            true,
+	"content-length":    true,
+	"connection":        true,
+	"transfer-encoding": true,
+	"accept-encoding":   true,
+}
+
+type sigV4 struct {
+	accessKeyID     string
+	secretAccessKey string
+	sessionToken    string
+	region          string
+	service         string
+}
+
+// NewSigV4 builds an AWS Signature Version 4 signer of the credentials scoped to the region and service.
+// For s3 the payload hash is sent in X-Amz-Content-Sha256 and the path is not encoded twice, a hash already set
+// there is signed as is, so that a streamed body need not be given to Sign.
+func NewSigV4(accessKeyID, secretAccessKey, sessionToken, region, service string) Signer {
+	return &sigV4{
+		accessKeyID:     accessKeyID,
+		secretAccessKey: secretAccessKey,
+		sessionToken:    sessionToken,
+		region:          region,
+		service:         service,
+	}
+}
+
+func (s sigV4) Sign(request *http.Request, body []byte, now time.Time) error {
+	now = now.UTC()
+	amzDate := now.Format(sigV4TimeFormat)
+	scope := now.Format("20060102") + "/" + s.region + "/" + s.service + "/aws4_request"
+
+	payloadSum := sha256.Sum256(body)
+	payloadHash := hex.EncodeToString(payloadSum[:])
+
+	if presetHash := request.Header.Get("X-Amz-Content-Sha256"); s.service == "s3" && presetHash != "" {
+		payloadHash = presetHash
+	}
+
+	request.Header.Del("Authorization")
+	request.Header.Set("X-Amz-Date", amzDate)
+
+	if s.sessionToken != "" {
+		request.Header.Set("X-Amz-Security-Token", s.sessionToken)
+	}
+
+	if s.service == "s3" {
+		request.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	}
+
+	canonicalHeaders, signedHeaders := s.canonicalHeaders(request)
+
+	canonicalRequest := strings.Join([]string{
+		request.Method,
+		s.canonicalURI(request.URL),
+		canonicalQuery(request.URL),
+		canonicalHeaders,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	requestSum := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{sigV4Algorithm, amzDate, scope, hex.EncodeToString(requestSum[:])}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.secretAccessKey), now.Format("20060102"))
+	for _, part := range []string{s.region, s.service, "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	request.Header.Set("Authorization", sigV4Algorithm+" Credential="+s.accessKeyID+"/"+scope+
+		", SignedHeaders="+signedHeaders+", Signature="+signature)
+
+	return nil
+}
+
+// canonicalURI encodes every segment of the path, twice for the services other than s3
+func (s sigV4) canonicalURI(u *url.URL) string {
+	if u.Path == "" {
+		return "/"
+	}
+
+	segments := strings.Split(u.Path, "/")
+	for i, segment := range segments {
+		segments[i] = uriEncode(segment)
+
+		if s.service != "s3" {
+			segments[i] = uriEncode(segments[i])
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
+
+// canonicalHeaders gives the sorted "name:value" lines of the signed headers, and the list of their names
+func (s sigV4) canonicalHeaders(request *http.Request) (string, string) {
+	headers := map[string]string{"host": requestHost(request)}
+
+	for name, values := range request.Header {
+		name = strings.ToLower(name)
+		if ignoredHeaders[name] {
+			continue
+		}
+
+		trimmed := make([]string, len(values))
+		for i, value := range values {
+			trimmed[i] = strings.Join(strings.Fields(value), " ")
+		}
+
+		headers[name] = strings.Join(trimmed, ",")
+	}
+
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	var lines strings.Builder
+	for _, name := range names {
+		lines.WriteString(name + ":" + headers[name] + "\n")
+	}
+
+	return lines.String(), strings.Join(names, ";")
+}
+
+// canonicalQuery gives the encoded query parameters sorted by name and value
+func canonicalQuery(u *url.URL) string {
+	var params [][2]string
+
+	for name, values := range u.Query() {
+		for _, value := range values {
+			params = append(params, [2]string{uriEncode(name), uriEncode(value)})
+		}
+	}
+
+	sort.Slice(params, func(i, j int) bool {
+		if params[i][0] != params[j][0] {
+			return params[i][0] < params[j][0]
+		}
+
+		return params[i][1] < params[j][1]
+	})
+
+	encoded := make([]string, len(params))
+	for i, param := range params {
+		encoded[i] = param[0] + "=" + param[1]
+	}
+
+	return strings.Join(encoded, "&")
+}
+
+// uriEncode percent-encodes every byte except the unreserved characters, as per the SigV4 spec
+func uriEncode(s string) string {
+	var encoded strings.Builder
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') ||
+			c == '-' || c == '_' || c == '.' || c == '~' {
+			encoded.WriteByte(c)
+		} else {
+			encoded.WriteString("%" + strings.ToUpper(hex.EncodeToString([]byte{c})))
+		}
+	}
+
+	return encoded.String()
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+
+	return mac.Sum(nil)
+}
@@ -0,0 +1,183 @@
+package ssrf
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"path"
+	"strings"
+	"syscall"
+)
+
+// ErrBlocked is returned for a call to a loopback, private, link-local or otherwise internal address
+var ErrBlocked = errors.New("destination address is not allowed")
+
+// maxRedirects is the number of hops followed, as many as a task may follow at most so that the task's own limit
+// applies first
+const maxRedirects = 10
+
+// blockedPrefixes are the internal ranges not covered by the netip.Addr predicates
+var blockedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+}
+
+type guard struct {
+	prefixes []netip.Prefix
+	hosts    []string
+}
+
+// New builds a guard with an allowlist of internal targets, each entry being either a CIDR (10.1.0.0/16),
+// an IP (10.1.2.3) or a host name glob (*.svc.cluster.local).
+func New(allowlist []string) (Guard, error) {
+	g := &guard{}
+
+	for _, entry := range allowlist {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		if entry == "" {
+			continue
+		}
+
+		if prefix, err := netip.ParsePrefix(entry); err == nil {
+			g.prefixes = append(g.prefixes, prefix.Masked())
+
+			continue
+		}
+
+		if addr, err := netip.ParseAddr(entry); err == nil {
+			g.prefixes = append(g.prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+
+			continue
+		}
+
+		if _, err := path.Match(entry, ""); err != nil {
+			return nil, errors.New("Invalid allowlist entry: " + entry)
+		}
+
+		g.hosts = append(g.hosts, entry)
+	}
+
+	return g, nil
+}
+
+// DialContext wraps the dialer so that every connection is checked against the address it actually connects to,
+// i.e. after DNS resolution, which also covers every redirect hop and DNS rebinding.
+func (g guard) DialContext(dialer *net.Dialer) func(ctx context.Context, network, address string) (net.Conn, error) {
+	return func(ctx context.Context, network, address string) (net.Conn, error) {
+		host, _, err := net.SplitHostPort(address)
+		if err != nil {
+			return nil, err
+		}
+
+		if g.allowedHost(host) {
+			return dialer.DialContext(ctx, network, address)
+		}
+
+		guarded := *dialer
+		guarded.Control = func(network, resolved string, _ syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(resolved)
+			if err != nil {
+				return err
+			}
+
+			if !g.allowedAddr(addrPort.Addr()) {
+				return fmt.Errorf("%w: %s resolves to %s", ErrBlocked, host, addrPort.Addr())
+			}
+
+			return nil
+		}
+
+		return guarded.DialContext(ctx, network, address)
+	}
+}
+
+// CheckRedirect rejects redirects to other schemes and to internal IP literals before they are dialled.
+func (g guard) CheckRedirect(req *http.Request, via []*http.Request) error {
+	// via holds the original request along with the hops followed so far
+	if len(via) > maxRedirects {
+		return errors.New("stopped after 10 redirects")
+	}
+
+	if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+		return errors.New("redirect to unsupported scheme " + req.URL.Scheme)
+	}
+
+	host := req.URL.Hostname()
+
+	if addr, err := netip.ParseAddr(host); err == nil && !g.allowedHost(host) && !g.allowedAddr(addr) {
+		return fmt.Errorf("%w: redirect to %s", ErrBlocked, host)
+	}
+
+	return nil
+}
+
+// CheckHost resolves the host and checks every address it resolves to. It is meant for the calls made through a proxy,
+// where the proxy and not the dialer connects to the host.
+func (g guard) CheckHost(ctx context.Context, host string) error {
+	if g.allowedHost(host) {
+		return nil
+	}
+
+	if addr, err := netip.ParseAddr(host); err == nil {
+		if !g.allowedAddr(addr) {
+			return fmt.Errorf("%w: %s", ErrBlocked, host)
+		}
+
+		return nil
+	}
+
+	addrs, err := net.DefaultResolver.LookupNetIP(ctx, "ip", host)
+	if err != nil {
+		return err
+	}
+
+	for _, addr := range addrs {
+		if !g.allowedAddr(addr) {
+			return fmt.Errorf("%w: %s resolves to %s", ErrBlocked, host, addr)
+		}
+	}
+
+	return nil
+}
+
+func (g guard) allowedHost(host string) bool {
+	host = strings.ToLower(host)
+
+	for _, pattern := range g.hosts {
+		if ok, _ := path.Match(pattern, host); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (g guard) allowedAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+
+	for _, prefix := range g.prefixes {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+
+	if addr.IsLoopback() || addr.IsPrivate() || addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() || addr.IsMulticast() || addr.IsUnspecified() {
+		return false
+	}
+
+	for _, prefix := range blockedPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+
+	return true
+}
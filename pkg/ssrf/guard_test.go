@@ -0,0 +1,166 @@
+package ssrf
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newClient(g Guard) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = g.DialContext(&net.Dialer{})
+
+	return &http.Client{Transport: transport, CheckRedirect: g.CheckRedirect}
+}
+
+func TestGuard_DialContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	_, port, _ := net.SplitHostPort(server.Listener.Addr().String())
+
+	tcs := []struct {
+		description string
+		allowlist   []string
+		url         string
+		blocked     bool
+	}{
+		{
+			description: "Negative case: loopback IP is blocked",
+			url:         server.URL,
+			blocked:     true,
+		},
+		{
+			description: "Negative case: host resolving to loopback is blocked",
+			url:         "http://localhost:" + port,
+			blocked:     true,
+		},
+		{
+			description: "Positive case: allowlisted CIDR",
+			allowlist:   []string{"127.0.0.0/8"},
+			url:         server.URL,
+		},
+		{
+			description: "Positive case: allowlisted host",
+			allowlist:   []string{"LOCALHOST"},
+			url:         "http://localhost:" + port,
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			g, err := New(tc.allowlist)
+			assert.Nil(t, err)
+
+			resp, err := newClient(g).Get(tc.url)
+			if resp != nil {
+				resp.Body.Close()
+			}
+
+			assert.Equal(t, tc.blocked, errors.Is(err, ErrBlocked))
+
+			if !tc.blocked {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestGuard_CheckRedirect(t *testing.T) {
+	g, _ := New([]string{"10.1.0.0/16"})
+
+	tcs := []struct {
+		description string
+		url         string
+		via         int
+		blocked     bool
+		expErr      bool
+	}{
+		{description: "Positive case: public host", url: "https://www.getyourtasks.com/task"},
+		{description: "Positive case: allowlisted IP", url: "http://10.1.2.3/task"},
+		{description: "Negative case: metadata address", url: "http://169.254.169.254/latest", blocked: true, expErr: true},
+		{description: "Negative case: IPv4 mapped loopback", url: "http://[::ffff:127.0.0.1]/", blocked: true, expErr: true},
+		{description: "Negative case: unsupported scheme", url: "file:///etc/passwd", expErr: true},
+		{description: "Positive case: tenth redirect", url: "https://www.getyourtasks.com/task", via: 10},
+		{description: "Negative case: too many redirects", url: "https://www.getyourtasks.com/task", via: 11, expErr: true},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			u, _ := url.Parse(tc.url)
+
+			err := g.CheckRedirect(&http.Request{URL: u}, make([]*http.Request, tc.via))
+
+			assert.Equal(t, tc.expErr, err != nil)
+			assert.Equal(t, tc.blocked, errors.Is(err, ErrBlocked))
+		})
+	}
+}
+
+func TestGuard_allowedAddr(t *testing.T) {
+	g := guard{}
+
+	for addr, allowed := range map[string]bool{
+		"93.184.216.34":   true,
+		"2606:4700::1111": true,
+		"127.0.0.1":       false,
+		"10.20.30.40":     false,
+		"172.16.0.1":      false,
+		"192.168.1.1":     false,
+		"169.254.169.254": false,
+		"100.64.0.1":      false,
+		"0.0.0.0":         false,
+		"::1":             false,
+		"fd00::1":         false,
+		"fe80::1":         false,
+	} {
+		assert.Equal(t, allowed, g.allowedAddr(netip.MustParseAddr(addr)), addr)
+	}
+}
+
+func TestGuard_New(t *testing.T) {
+	_, err := New([]string{"[bad"})
+
+	assert.Equal(t, errors.New("Invalid allowlist entry: [bad"), err)
+}
+
+func TestGuard_CheckHost(t *testing.T) {
+	g, _ := New([]string{"10.1.0.0/16", "internal.example.com"})
+
+	tcs := []struct {
+		description string
+		host        string
+		blocked     bool
+	}{
+		{description: "Positive case: public IP", host: "93.184.216.34"},
+		{description: "Positive case: allowlisted IP", host: "10.1.2.3"},
+		{description: "Positive case: allowlisted host is not resolved", host: "internal.example.com"},
+		{description: "Negative case: private IP", host: "10.2.0.1", blocked: true},
+		{description: "Negative case: host resolving to loopback", host: "localhost", blocked: true},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			err := g.CheckHost(context.Background(), tc.host)
+
+			assert.Equal(t, tc.blocked, errors.Is(err, ErrBlocked))
+
+			if !tc.blocked {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,14 @@
+package ssrf
+
+import (
+	"context"
+	"net"
+	"net/http"
+)
+
+// Guard keeps the outbound calls away from internal addresses
+type Guard interface {
+	DialContext(dialer *net.Dialer) func(ctx context.Context, network, address string) (net.Conn, error)
+	CheckRedirect(req *http.Request, via []*http.Request) error
+	CheckHost(ctx context.Context, host string) error
+}
@@ -0,0 +1,102 @@
+package tlsprofiles
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"log"
+	"os"
+
+	"github.com/axxonsoft-assignment/pkg/model"
+)
+
+var tlsVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// Load reads the named TLS profiles from a JSON file of name -> profile, and builds the TLS config of each.
+// Without a file there are no profiles.
+func Load(file string) (map[string]*tls.Config, error) {
+	configs := map[string]*tls.Config{}
+
+	if file == "" {
+		return configs, nil
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var profiles map[string]model.TLSProfile
+
+	if err = json.Unmarshal(data, &profiles); err != nil {
+		return nil, errors.New("Invalid TLS profiles: " + err.Error())
+	}
+
+	for name, profile := range profiles {
+		config, err := Build(profile)
+		if err != nil {
+			return nil, errors.New("Invalid TLS profile " + name + ": " + err.Error())
+		}
+
+		if profile.InsecureSkipVerify {
+			log.Printf("TLS profile %s skips the verification of server certificates, use it for development only", name)
+		}
+
+		configs[name] = config
+	}
+
+	return configs, nil
+}
+
+// Build creates the TLS config of a profile, loading its client certificate and CA bundle
+func Build(profile model.TLSProfile) (*tls.Config, error) {
+	config := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         profile.ServerName,
+		InsecureSkipVerify: profile.InsecureSkipVerify,
+	}
+
+	if profile.MinVersion != "" {
+		version, ok := tlsVersions[profile.MinVersion]
+		if !ok {
+			return nil, errors.New("minVersion should be one of [1.0, 1.1, 1.2, 1.3]")
+		}
+
+		config.MinVersion = version
+	}
+
+	if (profile.CertFile == "") != (profile.KeyFile == "") {
+		return nil, errors.New("certFile and keyFile should be set together")
+	}
+
+	if profile.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(profile.CertFile, profile.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	if profile.CAFile != "" {
+		bundle, err := os.ReadFile(profile.CAFile)
+		if err != nil {
+			return nil, err
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(bundle) {
+			return nil, errors.New("no certificates found in caFile " + profile.CAFile)
+		}
+
+		config.RootCAs = pool
+	}
+
+	return config, nil
+}
@@ -0,0 +1,145 @@
+package tlsprofiles
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/axxonsoft-assignment/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+// writeCert creates a certificate signed by the parent (self-signed if nil) and writes it along with its key as PEM files
+func writeCert(t *testing.T, name string, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate,
+	*ecdsa.PrivateKey, string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  parent == nil,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	assert.Nil(t, err)
+
+	cert, _ := x509.ParseCertificate(der)
+	keyDER, _ := x509.MarshalECPrivateKey(key)
+
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, name+".crt")
+	keyFile := filepath.Join(dir, name+".key")
+
+	assert.Nil(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	assert.Nil(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+
+	return cert, key, certFile, keyFile
+}
+
+func TestTLSProfiles_mTLS(t *testing.T) {
+	clientCA, clientCAKey, _, _ := writeCert(t, "client-ca", nil, nil)
+	_, _, certFile, keyFile := writeCert(t, "client", clientCA, clientCAKey)
+
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(clientCA)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.TLS.PeerCertificates[0].Subject.CommonName))
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs}
+	server.StartTLS()
+	defer server.Close()
+
+	serverCAFile := filepath.Join(t.TempDir(), "server-ca.crt")
+	assert.Nil(t, os.WriteFile(serverCAFile,
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), 0o600))
+
+	tcs := []struct {
+		description string
+		profile     model.TLSProfile
+		expErr      bool
+	}{
+		{
+			description: "Positive case: client certificate and private CA",
+			profile:     model.TLSProfile{CertFile: certFile, KeyFile: keyFile, CAFile: serverCAFile, MinVersion: "1.3"},
+		},
+		{
+			description: "Negative case: server certificate is not trusted without the CA bundle",
+			profile:     model.TLSProfile{CertFile: certFile, KeyFile: keyFile},
+			expErr:      true,
+		},
+		{
+			description: "Negative case: server requires a client certificate",
+			profile:     model.TLSProfile{CAFile: serverCAFile},
+			expErr:      true,
+		},
+		{
+			description: "Positive case: verification skipped",
+			profile:     model.TLSProfile{CertFile: certFile, KeyFile: keyFile, InsecureSkipVerify: true},
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			config, err := Build(tc.profile)
+			assert.Nil(t, err)
+
+			client := &http.Client{Transport: &http.Transport{TLSClientConfig: config}}
+
+			resp, err := client.Get(server.URL)
+			assert.Equal(t, tc.expErr, err != nil, err)
+
+			if err == nil {
+				resp.Body.Close()
+			}
+		})
+	}
+}
+
+func TestTLSProfiles_Load(t *testing.T) {
+	configs, err := Load("")
+	assert.Nil(t, err)
+	assert.Empty(t, configs)
+
+	file := filepath.Join(t.TempDir(), "profiles.json")
+	assert.Nil(t, os.WriteFile(file, []byte(`{"partner": {"minVersion": "1.3", "serverName": "api.partner.com"}}`), 0o600))
+
+	configs, err = Load(file)
+	assert.Nil(t, err)
+	assert.Equal(t, uint16(tls.VersionTLS13), configs["partner"].MinVersion)
+	assert.Equal(t, "api.partner.com", configs["partner"].ServerName)
+
+	assert.Nil(t, os.WriteFile(file, []byte(`{"partner": {"minVersion": "2.0"}}`), 0o600))
+
+	_, err = Load(file)
+	assert.Equal(t, errors.New("Invalid TLS profile partner: minVersion should be one of [1.0, 1.1, 1.2, 1.3]"), err)
+
+	assert.Nil(t, os.WriteFile(file, []byte(`{"partner": {"certFile": "client.crt"}}`), 0o600))
+
+	_, err = Load(file)
+	assert.Equal(t, errors.New("Invalid TLS profile partner: certFile and keyFile should be set together"), err)
+}